@@ -52,6 +52,9 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.CredentialsRef = restored.Spec.CredentialsRef
 	}
 
+	dst.Status.PendingOperations = restored.Status.PendingOperations
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
@@ -53,6 +53,8 @@ func (src *GCPMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 		dst.Spec.Template.Spec.ConfidentialCompute = restored.Spec.Template.Spec.ConfidentialCompute
 	}
 
+	dst.Status = restored.Status
+
 	return nil
 }
 
@@ -83,6 +85,11 @@ func (dst *GCPMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error { //
 	return Convert_v1beta1_GCPMachineTemplateList_To_v1alpha3_GCPMachineTemplateList(src, dst, nil)
 }
 
+func Convert_v1beta1_GCPMachineTemplate_To_v1alpha3_GCPMachineTemplate(in *infrav1beta1.GCPMachineTemplate, out *GCPMachineTemplate, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because status has been added in v1beta1.
+	return autoConvert_v1beta1_GCPMachineTemplate_To_v1alpha3_GCPMachineTemplate(in, out, s)
+}
+
 func Convert_v1beta1_GCPMachineTemplateResource_To_v1alpha3_GCPMachineTemplateResource(in *infrav1beta1.GCPMachineTemplateResource, out *GCPMachineTemplateResource, s apiconversion.Scope) error {
 	// NOTE: custom conversion func is required because spec.template.metadata has been added in v1beta1.
 	return autoConvert_v1beta1_GCPMachineTemplateResource_To_v1alpha3_GCPMachineTemplateResource(in, out, s)
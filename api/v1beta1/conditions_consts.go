@@ -0,0 +1,37 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// PreflightChecksSucceededCondition condition reports on whether the GCP project is ready for the resources of the
+	// object to be created: the APIs are enabled, the credentials have the permissions, the machine types exist and the
+	// quotas have room.
+	PreflightChecksSucceededCondition clusterv1.ConditionType = "PreflightChecksSucceeded"
+
+	// APINotEnabledReason used to report that a GCP API the resources need is not enabled in the project.
+	APINotEnabledReason = "APINotEnabled"
+	// PermissionsMissingReason used to report that the credentials lack IAM permissions the resources need.
+	PermissionsMissingReason = "PermissionsMissing"
+	// MachineTypeNotFoundReason used to report that the machine type doesn't exist in a zone.
+	MachineTypeNotFoundReason = "MachineTypeNotFound"
+	// QuotaExceededReason used to report that a regional quota has no room for the resources.
+	QuotaExceededReason = "QuotaExceeded"
+	// PreflightChecksFailedReason used to report that the preflight checks could not be performed.
+	PreflightChecksFailedReason = "PreflightChecksFailed"
+)
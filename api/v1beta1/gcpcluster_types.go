@@ -67,6 +67,16 @@ type GCPClusterStatus struct {
 
 	// Bastion Instance `json:"bastion,omitempty"`
 	Ready bool `json:"ready"`
+	// PendingOperations are the GCE operations started by the provider that are still in progress.
+	// No other operation is started on their resources until they complete.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	PendingOperations []GCEOperation `json:"pendingOperations,omitempty"`
+
+	// Conditions defines current service state of the GCPCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -96,6 +106,16 @@ type GCPClusterList struct {
 	Items           []GCPCluster `json:"items"`
 }
 
+// GetConditions returns the cluster conditions.
+func (r *GCPCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the GCPCluster.
+func (r *GCPCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPCluster{}, &GCPClusterList{})
 }
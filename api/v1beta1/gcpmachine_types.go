@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
@@ -306,6 +307,23 @@ type GCPMachineStatus struct {
 	// controller's output.
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Zone is the zone the instance is placed in. It is the failure domain of the Machine when it has one, otherwise
+	// a zone of the cluster's failure domains, which may change while the instance is being created if the zone runs
+	// out of resources.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// PendingOperations are the GCE operations started by the provider that are still in progress.
+	// No other operation is started on their resources until they complete.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	PendingOperations []GCEOperation `json:"pendingOperations,omitempty"`
+
+	// Conditions defines current service state of the GCPMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -336,6 +354,16 @@ type GCPMachineList struct {
 	Items           []GCPMachine `json:"items"`
 }
 
+// GetConditions returns the machine conditions.
+func (r *GCPMachine) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the GCPMachine.
+func (r *GCPMachine) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPMachine{}, &GCPMachineList{})
 }
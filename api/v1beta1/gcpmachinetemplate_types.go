@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,16 +26,54 @@ type GCPMachineTemplateSpec struct {
 	Template GCPMachineTemplateResource `json:"template"`
 }
 
+// GCPMachineTemplateStatus defines the observed state of GCPMachineTemplate.
+type GCPMachineTemplateStatus struct {
+	// Capacity defines the resource capacity of the machines created from the template.
+	// This value is used for autoscaling from zero operations as defined in:
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// NodeInfo contains information about the nodes of the machines created from the template.
+	// +optional
+	NodeInfo *NodeInfo `json:"nodeInfo,omitempty"`
+}
+
+// NodeInfo contains information about the node's architecture and operating system.
+type NodeInfo struct {
+	// Architecture is the CPU architecture of the node.
+	// Its underlying type is a string and its value can be any of amd64, arm64.
+	// +kubebuilder:validation:Enum=amd64;arm64
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// OperatingSystem is the operating system of the node.
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+}
+
+// Architecture represents the CPU architecture of the node.
+type Architecture string
+
+const (
+	// ArchitectureAmd64 is the amd64 architecture.
+	ArchitectureAmd64 Architecture = "amd64"
+	// ArchitectureArm64 is the arm64 architecture.
+	ArchitectureArm64 Architecture = "arm64"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=gcpmachinetemplates,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 
 // GCPMachineTemplate is the Schema for the gcpmachinetemplates API.
 type GCPMachineTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GCPMachineTemplateSpec `json:"spec,omitempty"`
+	Spec   GCPMachineTemplateSpec   `json:"spec,omitempty"`
+	Status GCPMachineTemplateStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -267,3 +268,21 @@ type ObjectReference struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 }
+
+// GCEOperation is a GCE operation started by the provider that is still in progress.
+type GCEOperation struct {
+	// Resource is the relative name of the resource modified by the operation,
+	// e.g. projects/my-project/zones/us-central1-a/instances/my-instance.
+	Resource string `json:"resource"`
+
+	// SelfLink is the URL of the operation.
+	SelfLink string `json:"selfLink"`
+
+	// Type is the type of the operation, e.g. insert or delete.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// StartTime is the time at which the operation was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
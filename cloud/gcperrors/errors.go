@@ -19,19 +19,164 @@ package gcperrors
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/pkg/errors"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
-// IsNotFound reports whether err is a Google API error
-// with http.StatusNotFround.
-func IsNotFound(err error) bool {
+// Class is the class of a GCP API error, which tells how a controller should handle it.
+type Class string
+
+const (
+	// Unknown is the class of the errors which aren't GCP API errors or aren't classified.
+	Unknown Class = ""
+	// NotFound is the class of the errors reporting that a resource doesn't exist.
+	NotFound Class = "NotFound"
+	// AlreadyExists is the class of the errors reporting that a resource already exists.
+	AlreadyExists Class = "AlreadyExists"
+	// PermissionDenied is the class of the errors reporting that the credentials aren't allowed to perform the call.
+	PermissionDenied Class = "PermissionDenied"
+	// InvalidArgument is the class of the errors reporting that the request is invalid, which retrying won't fix.
+	InvalidArgument Class = "InvalidArgument"
+	// FailedPrecondition is the class of the errors reporting that the resource isn't in a state allowing the call,
+	// e.g. because another operation is in progress on it or it is in use by another resource.
+	FailedPrecondition Class = "FailedPrecondition"
+	// QuotaExceeded is the class of the errors reporting that a project quota is exhausted.
+	QuotaExceeded Class = "QuotaExceeded"
+	// RateLimited is the class of the errors reporting that too many API requests were made.
+	RateLimited Class = "RateLimited"
+	// ZoneResourceExhausted is the class of the errors reporting that a zone doesn't have enough resources available
+	// to fulfill the request, also known as a stockout.
+	ZoneResourceExhausted Class = "ZoneResourceExhausted"
+	// Unavailable is the class of the transient server side errors.
+	Unavailable Class = "Unavailable"
+)
+
+// zoneResourceExhaustedMarkers are found in the reasons or messages of the errors reporting a stockout.
+var zoneResourceExhaustedMarkers = []string{
+	"ZONE_RESOURCE_POOL_EXHAUSTED",
+	"GCE_STOCKOUT",
+	"does not have enough resources available",
+}
+
+// Classify returns the class of a GCP API error returned by either the REST clients, e.g. compute, or the gRPC
+// clients, e.g. container. The errors wrapping a GCP API error have its class.
+func Classify(err error) Class {
 	if err == nil {
-		return false
+		return Unknown
+	}
+
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return classifyHTTP(gerr)
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		reason := ""
+		var aerr *apierror.APIError
+		if errors.As(err, &aerr) {
+			reason = aerr.Reason()
+		}
+		return classifyGRPC(st.Code(), reason, st.Message())
+	}
+
+	return Unknown
+}
+
+func classifyHTTP(err *googleapi.Error) Class {
+	reasons := make([]string, 0, len(err.Errors))
+	for _, item := range err.Errors {
+		reasons = append(reasons, item.Reason)
+	}
+	if containsAny(append(reasons, err.Message), zoneResourceExhaustedMarkers) {
+		return ZoneResourceExhausted
 	}
-	ae, ok := err.(*googleapi.Error)
 
-	return ok && ae.Code == http.StatusNotFound
+	for _, reason := range reasons {
+		switch reason {
+		case "rateLimitExceeded", "userRateLimitExceeded", "RATE_LIMIT_EXCEEDED":
+			return RateLimited
+		case "quotaExceeded", "QUOTA_EXCEEDED":
+			return QuotaExceeded
+		case "resourceInUseByAnotherResource", "resourceNotReady", "RESOURCE_IN_USE_BY_ANOTHER_RESOURCE", "RESOURCE_NOT_READY":
+			return FailedPrecondition
+		case "alreadyExists", "RESOURCE_ALREADY_EXISTS":
+			return AlreadyExists
+		case "notFound", "RESOURCE_NOT_FOUND":
+			return NotFound
+		case "invalid", "invalidParameter", "required", "INVALID_ARGUMENT":
+			// Only the fields of the request reported as invalid are known to need a change of the object, other bad
+			// requests may be transient or fixed outside of it.
+			return InvalidArgument
+		}
+	}
+
+	switch code := err.Code; {
+	case code == http.StatusNotFound:
+		return NotFound
+	case code == http.StatusConflict:
+		return AlreadyExists
+	case code == http.StatusTooManyRequests:
+		return RateLimited
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return PermissionDenied
+	case code == http.StatusPreconditionFailed:
+		return FailedPrecondition
+	case code >= http.StatusInternalServerError:
+		return Unavailable
+	}
+
+	return Unknown
+}
+
+func classifyGRPC(code codes.Code, reason, message string) Class {
+	switch code {
+	case codes.NotFound:
+		return NotFound
+	case codes.AlreadyExists:
+		return AlreadyExists
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return PermissionDenied
+	case codes.InvalidArgument, codes.OutOfRange:
+		return InvalidArgument
+	case codes.FailedPrecondition, codes.Aborted:
+		return FailedPrecondition
+	case codes.ResourceExhausted:
+		switch {
+		case containsAny([]string{reason, message}, zoneResourceExhaustedMarkers):
+			return ZoneResourceExhausted
+		case strings.Contains(reason, "RATE_LIMIT"):
+			return RateLimited
+		}
+		return QuotaExceeded
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal:
+		return Unavailable
+	}
+
+	return Unknown
+}
+
+func containsAny(values, markers []string) bool {
+	for _, value := range values {
+		for _, marker := range markers {
+			if strings.Contains(value, marker) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsNotFound reports whether err is a GCP API error reporting that a resource doesn't exist.
+func IsNotFound(err error) bool {
+	return Classify(err) == NotFound
 }
 
 // IgnoreNotFound ignore Google API not found error and return nil.
@@ -43,3 +188,95 @@ func IgnoreNotFound(err error) error {
 
 	return err
 }
+
+// IsAlreadyExists reports whether err is a GCP API error reporting that a resource already exists.
+func IsAlreadyExists(err error) bool {
+	return Classify(err) == AlreadyExists
+}
+
+// IsPermissionDenied reports whether err is a GCP API error reporting that the call isn't allowed.
+func IsPermissionDenied(err error) bool {
+	return Classify(err) == PermissionDenied
+}
+
+// IsInvalidArgument reports whether err is a GCP API error reporting that the request is invalid.
+func IsInvalidArgument(err error) bool {
+	return Classify(err) == InvalidArgument
+}
+
+// IsFailedPrecondition reports whether err is a GCP API error reporting that the resource isn't in a state
+// allowing the call.
+func IsFailedPrecondition(err error) bool {
+	return Classify(err) == FailedPrecondition
+}
+
+// IsQuotaExceeded reports whether err is a GCP API error reporting that a project quota is exhausted.
+func IsQuotaExceeded(err error) bool {
+	return Classify(err) == QuotaExceeded
+}
+
+// IsRateLimited reports whether err is a GCP API error reporting that too many requests were made.
+func IsRateLimited(err error) bool {
+	return Classify(err) == RateLimited
+}
+
+// IsZoneResourceExhausted reports whether err is a GCP API error reporting that a zone doesn't have enough
+// resources available.
+func IsZoneResourceExhausted(err error) bool {
+	return Classify(err) == ZoneResourceExhausted
+}
+
+// IsTerminal reports whether err, or any of the errors it aggregates, is a GCP API error which retrying the same
+// request won't fix, so the object must be changed.
+func IsTerminal(err error) bool {
+	for _, err := range flatten(err) {
+		if Classify(err) == InvalidArgument {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryAfter returns how long to wait before retrying after err, if every error it aggregates is a GCP API error
+// which is expected to clear with time, e.g. rate limiting or exhausted quotas. It returns zero otherwise, in
+// which case the controller exponential backoff applies.
+func RetryAfter(err error) time.Duration {
+	var after time.Duration
+	for _, err := range flatten(err) {
+		var d time.Duration
+		switch Classify(err) {
+		case RateLimited:
+			d = 30 * time.Second
+		case FailedPrecondition, ZoneResourceExhausted:
+			d = time.Minute
+		case QuotaExceeded:
+			d = 5 * time.Minute
+		default:
+			return 0
+		}
+		if d > after {
+			after = d
+		}
+	}
+
+	return after
+}
+
+// flatten returns the errors aggregated by err, or err itself.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	var agg kerrors.Aggregate
+	if errors.As(err, &agg) {
+		errs := []error{}
+		for _, err := range agg.Errors() {
+			errs = append(errs, flatten(err)...)
+		}
+		return errs
+	}
+
+	return []error{err}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcperrors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Class
+	}{
+		{
+			name: "nil",
+			want: Unknown,
+		},
+		{
+			name: "not a GCP error",
+			err:  fmt.Errorf("boom"),
+			want: Unknown,
+		},
+		{
+			name: "REST not found",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+			want: NotFound,
+		},
+		{
+			name: "wrapped REST not found",
+			err:  fmt.Errorf("failed to get instance: %w", &googleapi.Error{Code: http.StatusNotFound}),
+			want: NotFound,
+		},
+		{
+			name: "REST already exists",
+			err:  &googleapi.Error{Code: http.StatusConflict, Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}}},
+			want: AlreadyExists,
+		},
+		{
+			name: "REST quota exceeded",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}},
+			want: QuotaExceeded,
+		},
+		{
+			name: "REST rate limited",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}},
+			want: RateLimited,
+		},
+		{
+			name: "REST too many requests",
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests},
+			want: RateLimited,
+		},
+		{
+			name: "REST permission denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+			want: PermissionDenied,
+		},
+		{
+			name: "REST invalid argument",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}},
+			want: InvalidArgument,
+		},
+		{
+			name: "REST invalid parameter",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalidParameter"}}},
+			want: InvalidArgument,
+		},
+		{
+			name: "REST bad request without reason",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Message: "The resource is not ready"},
+			want: Unknown,
+		},
+		{
+			name: "REST bad request with unrecognized reason",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "badRequest"}}},
+			want: Unknown,
+		},
+		{
+			name: "REST resource in use",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}}},
+			want: FailedPrecondition,
+		},
+		{
+			name: "GCE operation zone resource exhausted",
+			err:  &googleapi.Error{Code: http.StatusServiceUnavailable, Errors: []googleapi.ErrorItem{{Reason: "ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS"}}},
+			want: ZoneResourceExhausted,
+		},
+		{
+			name: "GCE operation quota exceeded",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "QUOTA_EXCEEDED"}}},
+			want: QuotaExceeded,
+		},
+		{
+			name: "REST server error",
+			err:  &googleapi.Error{Code: http.StatusBadGateway},
+			want: Unavailable,
+		},
+		{
+			name: "gRPC not found",
+			err:  status.Error(codes.NotFound, "cluster not found"),
+			want: NotFound,
+		},
+		{
+			name: "gRPC failed precondition",
+			err:  status.Error(codes.FailedPrecondition, "operation in progress"),
+			want: FailedPrecondition,
+		},
+		{
+			name: "gRPC quota exceeded",
+			err:  status.Error(codes.ResourceExhausted, "Insufficient regional quota to satisfy request"),
+			want: QuotaExceeded,
+		},
+		{
+			name: "gRPC zone resource exhausted",
+			err:  status.Error(codes.ResourceExhausted, "GCE_STOCKOUT: the zone does not have enough resources available"),
+			want: ZoneResourceExhausted,
+		},
+		{
+			name: "gRPC invalid argument",
+			err:  status.Error(codes.InvalidArgument, "invalid machine type"),
+			want: InvalidArgument,
+		},
+		{
+			name: "gRPC permission denied",
+			err:  status.Error(codes.PermissionDenied, "missing container.clusters.create"),
+			want: PermissionDenied,
+		},
+		{
+			name: "gRPC unavailable",
+			err:  status.Error(codes.Unavailable, "try again"),
+			want: Unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	invalid := &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}}
+	badRequest := &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "badRequest"}}}
+	quota := status.Error(codes.ResourceExhausted, "quota exceeded")
+	if !IsTerminal(invalid) {
+		t.Error("IsTerminal(invalid argument) = false, want true")
+	}
+	if IsTerminal(badRequest) {
+		t.Error("IsTerminal(unclassified bad request) = true, want false")
+	}
+	if IsTerminal(&googleapi.Error{Code: http.StatusBadRequest}) {
+		t.Error("IsTerminal(bad request without reason) = true, want false")
+	}
+	if IsTerminal(quota) {
+		t.Error("IsTerminal(quota exceeded) = true, want false")
+	}
+	if !IsTerminal(kerrors.NewAggregate([]error{quota, fmt.Errorf("failed to reconcile subnets: %w", invalid)})) {
+		t.Error("IsTerminal(aggregate with invalid argument) = false, want true")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	rateLimited := &googleapi.Error{Code: http.StatusTooManyRequests}
+	quota := status.Error(codes.ResourceExhausted, "quota exceeded")
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{
+			name: "rate limited",
+			err:  rateLimited,
+			want: 30 * time.Second,
+		},
+		{
+			name: "quota exceeded",
+			err:  quota,
+			want: 5 * time.Minute,
+		},
+		{
+			name: "aggregate of retryable errors",
+			err:  kerrors.NewAggregate([]error{rateLimited, quota}),
+			want: 5 * time.Minute,
+		},
+		{
+			name: "aggregate with an unknown error",
+			err:  kerrors.NewAggregate([]error{rateLimited, fmt.Errorf("boom")}),
+		},
+		{
+			name: "invalid argument",
+			err:  status.Error(codes.InvalidArgument, "invalid"),
+		},
+		{
+			name: "nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryAfter(tt.err); got != tt.want {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -46,6 +48,9 @@ type ReconcilerWithResult interface {
 // Client is an interface which can get cloud client.
 type Client interface {
 	Cloud() Cloud
+	ComputeService() *compute.Service
+	ClientOptions(ctx context.Context) ([]option.ClientOption, error)
+	CredentialsRef() *infrav1.ObjectReference
 }
 
 // ClusterGetter is an interface which can get cluster information.
@@ -67,10 +72,18 @@ type ClusterSetter interface {
 	SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint)
 }
 
+// OperationTracker is an interface which can record the GCE operations in progress on resources.
+type OperationTracker interface {
+	PendingOperation(resource string) *infrav1.GCEOperation
+	SetPendingOperation(op infrav1.GCEOperation)
+	DeletePendingOperation(resource string)
+}
+
 // Cluster is an interface which can get and set cluster information.
 type Cluster interface {
 	ClusterGetter
 	ClusterSetter
+	OperationTracker
 }
 
 // MachineGetter is an interface which can get machine information.
@@ -103,4 +116,5 @@ type MachineSetter interface {
 type Machine interface {
 	MachineGetter
 	MachineSetter
+	OperationTracker
 }
@@ -143,3 +143,17 @@ func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.
 
 	return instanceGroupManagersClient, nil
 }
+
+func newZoneOperationsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*computerest.ZoneOperationsClient, error) {
+	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+	}
+
+	zoneOperationsClient, err := computerest.NewZoneOperationsRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Errorf("failed to create gcp zone operations rest client: %v", err)
+	}
+
+	return zoneOperationsClient, nil
+}
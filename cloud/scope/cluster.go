@@ -20,14 +20,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -77,6 +80,8 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 type ClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	// operationsMu guards the pending operations, which the cluster services record concurrently.
+	operationsMu sync.Mutex
 
 	Cluster    *clusterv1.Cluster
 	GCPCluster *infrav1.GCPCluster
@@ -90,6 +95,26 @@ func (s *ClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// ComputeService returns the GCE API client, which is used to start operations without waiting for them to complete.
+func (s *ClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
+// ClientOptions returns the options of the GCP API clients, which authenticate with the credentials of the cluster.
+func (s *ClusterScope) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	return defaultClientOptions(ctx, s.GCPCluster.Spec.CredentialsRef, s.client)
+}
+
+// CredentialsRef returns the reference to the credentials of the cluster, or nil if the default credentials are used.
+func (s *ClusterScope) CredentialsRef() *infrav1.ObjectReference {
+	return s.GCPCluster.Spec.CredentialsRef
+}
+
+// ConditionSetter returns a condition setter (which is GCPCluster itself).
+func (s *ClusterScope) ConditionSetter() conditions.Setter {
+	return s.GCPCluster
+}
+
 // Project returns the current project name.
 func (s *ClusterScope) Project() string {
 	return s.GCPCluster.Spec.Project
@@ -164,6 +189,30 @@ func (s *ClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint) {
 	s.GCPCluster.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// PendingOperation returns the GCE operation in progress on the resource, or nil if there is none.
+func (s *ClusterScope) PendingOperation(resource string) *infrav1.GCEOperation {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	if op := findPendingOperation(s.GCPCluster.Status.PendingOperations, resource); op != nil {
+		return op.DeepCopy()
+	}
+	return nil
+}
+
+// SetPendingOperation records a GCE operation in progress.
+func (s *ClusterScope) SetPendingOperation(op infrav1.GCEOperation) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	s.GCPCluster.Status.PendingOperations = setPendingOperation(s.GCPCluster.Status.PendingOperations, op)
+}
+
+// DeletePendingOperation removes the GCE operation in progress on the resource.
+func (s *ClusterScope) DeletePendingOperation(resource string) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	s.GCPCluster.Status.PendingOperations = deletePendingOperation(s.GCPCluster.Status.PendingOperations, resource)
+}
+
 // ANCHOR_END: ClusterSetter
 
 // ANCHOR: ClusterNetworkSpec
@@ -28,17 +28,21 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/stockout"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -48,6 +52,9 @@ type MachineScopeParams struct {
 	ClusterGetter cloud.ClusterGetter
 	Machine       *clusterv1.Machine
 	GCPMachine    *infrav1.GCPMachine
+	// ExhaustedZones are the zones that ran out of resources for a machine type, which worker machines without a
+	// failure domain avoid. It is optional.
+	ExhaustedZones *stockout.Cache
 }
 
 // NewMachineScope creates a new MachineScope from the supplied parameters.
@@ -69,21 +76,23 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 	}
 
 	return &MachineScope{
-		client:        params.Client,
-		Machine:       params.Machine,
-		GCPMachine:    params.GCPMachine,
-		ClusterGetter: params.ClusterGetter,
-		patchHelper:   helper,
+		client:         params.Client,
+		Machine:        params.Machine,
+		GCPMachine:     params.GCPMachine,
+		ClusterGetter:  params.ClusterGetter,
+		exhaustedZones: params.ExhaustedZones,
+		patchHelper:    helper,
 	}, nil
 }
 
 // MachineScope defines a scope defined around a machine and its cluster.
 type MachineScope struct {
-	client        client.Client
-	patchHelper   *patch.Helper
-	ClusterGetter cloud.ClusterGetter
-	Machine       *clusterv1.Machine
-	GCPMachine    *infrav1.GCPMachine
+	client         client.Client
+	patchHelper    *patch.Helper
+	exhaustedZones *stockout.Cache
+	ClusterGetter  cloud.ClusterGetter
+	Machine        *clusterv1.Machine
+	GCPMachine     *infrav1.GCPMachine
 }
 
 // ANCHOR: MachineGetter
@@ -93,21 +102,71 @@ func (m *MachineScope) Cloud() cloud.Cloud {
 	return m.ClusterGetter.Cloud()
 }
 
-// Zone returns the FailureDomain for the GCPMachine.
+// ComputeService returns the GCE API client, which is used to start operations without waiting for them to complete.
+func (m *MachineScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// ClientOptions returns the options of the GCP API clients, which authenticate with the credentials of the cluster.
+func (m *MachineScope) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	return m.ClusterGetter.ClientOptions(ctx)
+}
+
+// CredentialsRef returns the reference to the credentials of the cluster, or nil if the default credentials are used.
+func (m *MachineScope) CredentialsRef() *infrav1.ObjectReference {
+	return m.ClusterGetter.CredentialsRef()
+}
+
+// ConditionSetter returns a condition setter (which is GCPMachine itself).
+func (m *MachineScope) ConditionSetter() conditions.Setter {
+	return m.GCPMachine
+}
+
+// Zone returns the zone for the GCPMachine: the FailureDomain of the Machine if it has one, otherwise the zone
+// recorded in the status, or the first of the cluster's failure domains.
+// Worker machines skip the zones that recently ran out of resources for their machine type.
 func (m *MachineScope) Zone() string {
-	if m.Machine.Spec.FailureDomain == nil {
-		fd := m.ClusterGetter.FailureDomains()
-		if len(fd) == 0 {
-			return ""
-		}
-		zones := make([]string, 0, len(fd))
-		for zone := range fd {
-			zones = append(zones, zone)
+	if m.Machine.Spec.FailureDomain != nil {
+		return *m.Machine.Spec.FailureDomain
+	}
+	if m.GCPMachine.Status.Zone != nil {
+		return *m.GCPMachine.Status.Zone
+	}
+
+	zones := m.failureDomainZones()
+	if len(zones) == 0 {
+		return ""
+	}
+	if m.canFallBack() {
+		for _, zone := range zones {
+			if !m.isZoneExhausted(zone) {
+				return zone
+			}
 		}
-		sort.Strings(zones)
-		return zones[0]
 	}
-	return *m.Machine.Spec.FailureDomain
+	return zones[0]
+}
+
+// failureDomainZones returns the sorted zones of the cluster's failure domains.
+func (m *MachineScope) failureDomainZones() []string {
+	fd := m.ClusterGetter.FailureDomains()
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// canFallBack returns true if the GCPMachine may be placed in another zone when its zone runs out of resources,
+// which is the case of worker machines without a failure domain.
+func (m *MachineScope) canFallBack() bool {
+	return m.Machine.Spec.FailureDomain == nil && !m.IsControlPlane()
+}
+
+// isZoneExhausted returns true if the zone recently ran out of resources for the machine type of the GCPMachine.
+func (m *MachineScope) isZoneExhausted(zone string) bool {
+	return m.exhaustedZones != nil && m.exhaustedZones.IsExhausted(m.GCPMachine.Spec.InstanceType, zone)
 }
 
 // Project return the project for the GCPMachine's cluster.
@@ -115,6 +174,11 @@ func (m *MachineScope) Project() string {
 	return m.ClusterGetter.Project()
 }
 
+// Region returns the region of the GCPMachine's cluster.
+func (m *MachineScope) Region() string {
+	return m.ClusterGetter.Region()
+}
+
 // Name returns the GCPMachine name.
 func (m *MachineScope) Name() string {
 	return m.GCPMachine.Name
@@ -206,11 +270,60 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 	m.GCPMachine.Annotations[key] = value
 }
 
+// SetZone records the zone the instance is placed in.
+func (m *MachineScope) SetZone(zone string) {
+	m.GCPMachine.Status.Zone = pointer.String(zone)
+}
+
+// ZoneExhausted records that the zone of the GCPMachine ran out of resources for its machine type.
+// A worker machine without a failure domain moves to another zone of the cluster's failure domains that has not
+// run out of resources; the zone is returned with true. Otherwise it returns false, and the zone is picked again on
+// the next attempt.
+func (m *MachineScope) ZoneExhausted() (string, bool) {
+	zone := m.Zone()
+	machineType := m.GCPMachine.Spec.InstanceType
+	if m.exhaustedZones != nil {
+		m.exhaustedZones.MarkExhausted(machineType, zone)
+	}
+	if !m.canFallBack() {
+		record.Warnf(m.GCPMachine, "ZoneResourceExhausted", "Zone %s has no resources available for machine type %s", zone, machineType)
+		return "", false
+	}
+
+	for _, next := range m.failureDomainZones() {
+		if next == zone || m.isZoneExhausted(next) {
+			continue
+		}
+		record.Warnf(m.GCPMachine, "ZoneResourceExhausted", "Zone %s has no resources available for machine type %s, trying zone %s", zone, machineType, next)
+		m.SetZone(next)
+		return next, true
+	}
+
+	record.Warnf(m.GCPMachine, "ZoneResourceExhausted", "Zone %s has no resources available for machine type %s, no other zone left to try", zone, machineType)
+	m.GCPMachine.Status.Zone = nil
+	return "", false
+}
+
 // SetAddresses sets the addresses field on the GCPMachine.
 func (m *MachineScope) SetAddresses(addressList []corev1.NodeAddress) {
 	m.GCPMachine.Status.Addresses = addressList
 }
 
+// PendingOperation returns the GCE operation in progress on the resource, or nil if there is none.
+func (m *MachineScope) PendingOperation(resource string) *infrav1.GCEOperation {
+	return findPendingOperation(m.GCPMachine.Status.PendingOperations, resource)
+}
+
+// SetPendingOperation records a GCE operation in progress.
+func (m *MachineScope) SetPendingOperation(op infrav1.GCEOperation) {
+	m.GCPMachine.Status.PendingOperations = setPendingOperation(m.GCPMachine.Status.PendingOperations, op)
+}
+
+// DeletePendingOperation removes the GCE operation in progress on the resource.
+func (m *MachineScope) DeletePendingOperation(resource string) {
+	m.GCPMachine.Status.PendingOperations = deletePendingOperation(m.GCPMachine.Status.PendingOperations, resource)
+}
+
 // ANCHOR_END: MachineSetter
 
 // ANCHOR: MachineInstanceSpec
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/stockout"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -72,3 +74,108 @@ func TestMachineLocalSSDDiskType(t *testing.T) {
 	assert.Equal(t, "NVME", localSSDTest.Interface)
 	assert.Equal(t, int64(375), localSSDTest.InitializeParams.DiskSizeGb)
 }
+
+// This test verifies that worker machines without a failure domain avoid the zones
+// that ran out of resources for their machine type, and that the zone is kept once recorded.
+func TestMachineZone(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	clusterScope := &ClusterScope{
+		GCPCluster: &infrav1.GCPCluster{
+			Status: infrav1.GCPClusterStatus{
+				FailureDomains: clusterv1.FailureDomains{
+					"us-central1-a": clusterv1.FailureDomainSpec{},
+					"us-central1-b": clusterv1.FailureDomainSpec{},
+				},
+			},
+		},
+	}
+	exhaustedZones := stockout.NewCache(stockout.DefaultCooldown)
+	exhaustedZones.MarkExhausted("n1-standard-2", "us-central1-a")
+
+	newScope := func(machine *clusterv1.Machine) *MachineScope {
+		testMachineScope, err := NewMachineScope(MachineScopeParams{
+			Client:  testClient,
+			Machine: machine,
+			GCPMachine: &infrav1.GCPMachine{
+				Spec: infrav1.GCPMachineSpec{InstanceType: "n1-standard-2"},
+			},
+			ClusterGetter:  clusterScope,
+			ExhaustedZones: exhaustedZones,
+		})
+		assert.Nil(t, err)
+		return testMachineScope
+	}
+
+	// A worker machine skips the exhausted zone.
+	worker := newScope(&clusterv1.Machine{})
+	assert.Equal(t, "us-central1-b", worker.Zone())
+
+	// The recorded zone is kept.
+	worker.SetZone("us-central1-a")
+	assert.Equal(t, "us-central1-a", worker.Zone())
+
+	// A control plane machine doesn't fall back.
+	controlPlane := newScope(&clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+		},
+	})
+	assert.Equal(t, "us-central1-a", controlPlane.Zone())
+
+	// A machine with a failure domain stays in it.
+	failureDomain := "us-central1-a"
+	pinned := newScope(&clusterv1.Machine{
+		Spec: clusterv1.MachineSpec{FailureDomain: &failureDomain},
+	})
+	assert.Equal(t, "us-central1-a", pinned.Zone())
+	_, ok := pinned.ZoneExhausted()
+	assert.False(t, ok)
+}
+
+// This test verifies that a worker machine whose zone runs out of resources moves to any other zone
+// that has not, and gives up once every zone ran out of resources.
+func TestMachineZoneExhausted(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	clusterScope := &ClusterScope{
+		GCPCluster: &infrav1.GCPCluster{
+			Status: infrav1.GCPClusterStatus{
+				FailureDomains: clusterv1.FailureDomains{
+					"us-central1-a": clusterv1.FailureDomainSpec{},
+					"us-central1-b": clusterv1.FailureDomainSpec{},
+					"us-central1-c": clusterv1.FailureDomainSpec{},
+				},
+			},
+		},
+	}
+	worker, err := NewMachineScope(MachineScopeParams{
+		Client:  testClient,
+		Machine: &clusterv1.Machine{},
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{InstanceType: "n1-standard-2"},
+		},
+		ClusterGetter:  clusterScope,
+		ExhaustedZones: stockout.NewCache(stockout.DefaultCooldown),
+	})
+	assert.Nil(t, err)
+
+	// The last zone falls back to an earlier one.
+	worker.SetZone("us-central1-c")
+	zone, ok := worker.ZoneExhausted()
+	assert.True(t, ok)
+	assert.Equal(t, "us-central1-a", zone)
+
+	zone, ok = worker.ZoneExhausted()
+	assert.True(t, ok)
+	assert.Equal(t, "us-central1-b", zone)
+
+	// Every zone ran out of resources.
+	_, ok = worker.ZoneExhausted()
+	assert.False(t, ok)
+	assert.Nil(t, worker.GCPMachine.Status.Zone)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MachinePoolScopeParams defines the input parameters used to create a new MachinePoolScope.
+type MachinePoolScopeParams struct {
+	Client         client.Client
+	ClusterGetter  cloud.ClusterGetter
+	MachinePool    *clusterv1exp.MachinePool
+	GCPMachinePool *infrav1exp.GCPMachinePool
+}
+
+// NewMachinePoolScope creates a new MachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachinePoolScope")
+	}
+	if params.ClusterGetter == nil {
+		return nil, errors.New("cluster getter is required when creating a MachinePoolScope")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("machine pool is required when creating a MachinePoolScope")
+	}
+	if params.GCPMachinePool == nil {
+		return nil, errors.New("gcp machine pool is required when creating a MachinePoolScope")
+	}
+
+	helper, err := patch.NewHelper(params.GCPMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &MachinePoolScope{
+		client:         params.Client,
+		ClusterGetter:  params.ClusterGetter,
+		MachinePool:    params.MachinePool,
+		GCPMachinePool: params.GCPMachinePool,
+		patchHelper:    helper,
+	}, nil
+}
+
+// MachinePoolScope defines a scope defined around a machine pool and its cluster.
+type MachinePoolScope struct {
+	client         client.Client
+	patchHelper    *patch.Helper
+	ClusterGetter  cloud.ClusterGetter
+	MachinePool    *clusterv1exp.MachinePool
+	GCPMachinePool *infrav1exp.GCPMachinePool
+}
+
+// Cloud returns initialized cloud.
+func (m *MachinePoolScope) Cloud() cloud.Cloud {
+	return m.ClusterGetter.Cloud()
+}
+
+// ComputeService returns the GCE API client, which is used to start operations without waiting for them to complete.
+func (m *MachinePoolScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// ConditionSetter returns a condition setter (which is GCPMachinePool itself).
+func (m *MachinePoolScope) ConditionSetter() conditions.Setter {
+	return m.GCPMachinePool
+}
+
+// Project returns the project for the GCPMachinePool's cluster.
+func (m *MachinePoolScope) Project() string {
+	return m.ClusterGetter.Project()
+}
+
+// Region returns the region of the GCPMachinePool's cluster.
+func (m *MachinePoolScope) Region() string {
+	return m.ClusterGetter.Region()
+}
+
+// Name returns the GCPMachinePool name, which is also the name of its managed instance group.
+func (m *MachinePoolScope) Name() string {
+	return m.GCPMachinePool.Name
+}
+
+// Namespace returns the namespace name.
+func (m *MachinePoolScope) Namespace() string {
+	return m.GCPMachinePool.Namespace
+}
+
+// Zones returns the zones of the instances: the failure domains of the MachinePool if it has any, otherwise the
+// cluster's failure domains.
+func (m *MachinePoolScope) Zones() []string {
+	zones := append([]string{}, m.MachinePool.Spec.FailureDomains...)
+	if len(zones) == 0 {
+		for zone := range m.ClusterGetter.FailureDomains() {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// Replicas returns the number of instances of the MachinePool.
+func (m *MachinePoolScope) Replicas() int64 {
+	return int64(pointer.Int32Deref(m.MachinePool.Spec.Replicas, 1))
+}
+
+// UpdateStrategy returns how the instances are updated when the instance template changes.
+func (m *MachinePoolScope) UpdateStrategy() *infrav1exp.MachinePoolUpdateStrategy {
+	return m.GCPMachinePool.Spec.UpdateStrategy
+}
+
+// InstanceGroupManager returns the URL of the managed instance group, or an empty string if it hasn't been created.
+func (m *MachinePoolScope) InstanceGroupManager() string {
+	return m.GCPMachinePool.Status.InstanceGroupManager
+}
+
+// SetInstanceGroupManager records the URL of the managed instance group.
+func (m *MachinePoolScope) SetInstanceGroupManager(selfLink string) {
+	m.GCPMachinePool.Status.InstanceGroupManager = selfLink
+}
+
+// InstanceTemplate returns the name of the instance template the instances are created from.
+func (m *MachinePoolScope) InstanceTemplate() string {
+	return m.GCPMachinePool.Status.InstanceTemplate
+}
+
+// SetInstanceTemplate records the name of the instance template the instances are created from.
+func (m *MachinePoolScope) SetInstanceTemplate(name string) {
+	m.GCPMachinePool.Status.InstanceTemplate = name
+}
+
+// SetProviderIDList sets the provider IDs of the instances in spec.
+func (m *MachinePoolScope) SetProviderIDList(providerIDs []string) {
+	m.GCPMachinePool.Spec.ProviderIDList = providerIDs
+}
+
+// SetReplicas sets the number of running instances.
+func (m *MachinePoolScope) SetReplicas(replicas int32) {
+	m.GCPMachinePool.Status.Replicas = replicas
+}
+
+// SetReady sets the GCPMachinePool Ready Status.
+func (m *MachinePoolScope) SetReady() {
+	m.GCPMachinePool.Status.Ready = true
+}
+
+// SetFailureMessage sets the GCPMachinePool status failure message.
+func (m *MachinePoolScope) SetFailureMessage(v error) {
+	m.GCPMachinePool.Status.FailureMessage = pointer.String(v.Error())
+}
+
+// SetFailureReason sets the GCPMachinePool status failure reason.
+func (m *MachinePoolScope) SetFailureReason(v capierrors.MachinePoolStatusFailure) {
+	m.GCPMachinePool.Status.FailureReason = &v
+}
+
+// PendingOperation returns the GCE operation in progress on the resource, or nil if there is none.
+func (m *MachinePoolScope) PendingOperation(resource string) *infrav1.GCEOperation {
+	return findPendingOperation(m.GCPMachinePool.Status.PendingOperations, resource)
+}
+
+// SetPendingOperation records a GCE operation in progress.
+func (m *MachinePoolScope) SetPendingOperation(op infrav1.GCEOperation) {
+	m.GCPMachinePool.Status.PendingOperations = setPendingOperation(m.GCPMachinePool.Status.PendingOperations, op)
+}
+
+// DeletePendingOperation removes the GCE operation in progress on the resource.
+func (m *MachinePoolScope) DeletePendingOperation(resource string) {
+	m.GCPMachinePool.Status.PendingOperations = deletePendingOperation(m.GCPMachinePool.Status.PendingOperations, resource)
+}
+
+// InstancePropertiesSpec returns the properties of the instance template, which are built like the instance of a
+// GCPMachine with the spec of the template, without the bootstrap data.
+func (m *MachinePoolScope) InstancePropertiesSpec(log logr.Logger) *compute.InstanceProperties {
+	var zone string
+	if zones := m.Zones(); len(zones) > 0 {
+		zone = zones[0]
+	}
+	machineScope := &MachineScope{
+		client:        m.client,
+		ClusterGetter: m.ClusterGetter,
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: m.Name(), Namespace: m.Namespace()},
+			Spec: clusterv1.MachineSpec{
+				ClusterName:   m.MachinePool.Spec.ClusterName,
+				Version:       m.MachinePool.Spec.Template.Spec.Version,
+				FailureDomain: pointer.String(zone),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: m.Name(), Namespace: m.Namespace()},
+			Spec:       *m.GCPMachinePool.Spec.Template.DeepCopy(),
+		},
+	}
+	instance := machineScope.InstanceSpec(log)
+
+	// Instance templates are global, they take the names of the machine and disk types rather than zonal URLs.
+	for _, disk := range instance.Disks {
+		if disk.InitializeParams != nil && disk.InitializeParams.DiskType != "" {
+			disk.InitializeParams.DiskType = path.Base(disk.InitializeParams.DiskType)
+		}
+	}
+
+	return &compute.InstanceProperties{
+		MachineType:                m.GCPMachinePool.Spec.Template.InstanceType,
+		Tags:                       instance.Tags,
+		Labels:                     instance.Labels,
+		Scheduling:                 instance.Scheduling,
+		CanIpForward:               instance.CanIpForward,
+		ShieldedInstanceConfig:     instance.ShieldedInstanceConfig,
+		ConfidentialInstanceConfig: instance.ConfidentialInstanceConfig,
+		Disks:                      instance.Disks,
+		Metadata:                   instance.Metadata,
+		ServiceAccounts:            instance.ServiceAccounts,
+		NetworkInterfaces:          instance.NetworkInterfaces,
+	}
+}
+
+// GetBootstrapData returns the bootstrap data from the secret in the MachinePool's bootstrap.dataSecretName.
+func (m *MachinePoolScope) GetBootstrapData() (string, error) {
+	if m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
+		return "", errors.New("error retrieving bootstrap data: linked MachinePool's bootstrap.dataSecretName is nil")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName}
+	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for GCPMachinePool %s/%s", m.Namespace(), m.Name())
+	}
+
+	value, ok := secret.Data["value"]
+	if !ok {
+		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
+	}
+
+	return string(value), nil
+}
+
+// PatchObject persists the machine pool spec and status.
+func (m *MachinePoolScope) PatchObject() error {
+	return m.patchHelper.Patch(
+		context.TODO(),
+		m.GCPMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			infrav1exp.InstanceGroupReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the machine pool spec and status.
+func (m *MachinePoolScope) Close() error {
+	return m.PatchObject()
+}
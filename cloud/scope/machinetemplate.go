@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MachineTemplateScopeParams defines the input parameters used to create a new MachineTemplateScope.
+type MachineTemplateScopeParams struct {
+	Client             client.Client
+	ClusterGetter      cloud.ClusterGetter
+	GCPMachineTemplate *infrav1.GCPMachineTemplate
+}
+
+// NewMachineTemplateScope creates a new MachineTemplateScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachineTemplateScope(params MachineTemplateScopeParams) (*MachineTemplateScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachineTemplateScope")
+	}
+	if params.ClusterGetter == nil {
+		return nil, errors.New("cluster getter is required when creating a MachineTemplateScope")
+	}
+	if params.GCPMachineTemplate == nil {
+		return nil, errors.New("gcp machine template is required when creating a MachineTemplateScope")
+	}
+
+	helper, err := patch.NewHelper(params.GCPMachineTemplate, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &MachineTemplateScope{
+		client:             params.Client,
+		ClusterGetter:      params.ClusterGetter,
+		GCPMachineTemplate: params.GCPMachineTemplate,
+		patchHelper:        helper,
+	}, nil
+}
+
+// MachineTemplateScope defines a scope defined around a machine template and its cluster.
+type MachineTemplateScope struct {
+	client             client.Client
+	patchHelper        *patch.Helper
+	ClusterGetter      cloud.ClusterGetter
+	GCPMachineTemplate *infrav1.GCPMachineTemplate
+}
+
+// ComputeService returns the GCE API client.
+func (m *MachineTemplateScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// Project returns the project name.
+func (m *MachineTemplateScope) Project() string {
+	return m.ClusterGetter.Project()
+}
+
+// Zone returns the zone the machine type is looked up in: the first of the cluster's failure domains, or an empty
+// string if the cluster has none yet.
+func (m *MachineTemplateScope) Zone() string {
+	fd := m.ClusterGetter.FailureDomains()
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	if len(zones) == 0 {
+		return ""
+	}
+	sort.Strings(zones)
+	return zones[0]
+}
+
+// MachineSpec returns the spec of the machines created from the template.
+func (m *MachineTemplateScope) MachineSpec() *infrav1.GCPMachineSpec {
+	return &m.GCPMachineTemplate.Spec.Template.Spec
+}
+
+// SetCapacity sets the resources of the machines created from the template.
+func (m *MachineTemplateScope) SetCapacity(capacity corev1.ResourceList) {
+	m.GCPMachineTemplate.Status.Capacity = capacity
+}
+
+// SetNodeInfo sets the information about the nodes of the machines created from the template.
+func (m *MachineTemplateScope) SetNodeInfo(nodeInfo *infrav1.NodeInfo) {
+	m.GCPMachineTemplate.Status.NodeInfo = nodeInfo
+}
+
+// PatchObject persists the machine template spec and status.
+func (m *MachineTemplateScope) PatchObject() error {
+	return m.patchHelper.Patch(context.TODO(), m.GCPMachineTemplate)
+}
+
+// Close closes the current scope persisting the machine template spec and status.
+func (m *MachineTemplateScope) Close() error {
+	return m.PatchObject()
+}
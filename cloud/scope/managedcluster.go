@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -79,6 +81,8 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 type ManagedClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	// operationsMu guards the pending operations, which the cluster services record concurrently.
+	operationsMu sync.Mutex
 
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
@@ -93,6 +97,21 @@ func (s *ManagedClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// ComputeService returns the GCE API client, which is used to start operations without waiting for them to complete.
+func (s *ManagedClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
+// ClientOptions returns the options of the GCP API clients, which authenticate with the credentials of the cluster.
+func (s *ManagedClusterScope) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	return defaultClientOptions(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client)
+}
+
+// CredentialsRef returns the reference to the credentials of the cluster, or nil if the default credentials are used.
+func (s *ManagedClusterScope) CredentialsRef() *infrav1.ObjectReference {
+	return s.GCPManagedCluster.Spec.CredentialsRef
+}
+
 // Project returns the current project name.
 func (s *ManagedClusterScope) Project() string {
 	return s.GCPManagedCluster.Spec.Project
@@ -164,6 +183,30 @@ func (s *ManagedClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndp
 	s.GCPManagedCluster.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// PendingOperation returns the GCE operation in progress on the resource, or nil if there is none.
+func (s *ManagedClusterScope) PendingOperation(resource string) *infrav1.GCEOperation {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	if op := findPendingOperation(s.GCPManagedCluster.Status.PendingOperations, resource); op != nil {
+		return op.DeepCopy()
+	}
+	return nil
+}
+
+// SetPendingOperation records a GCE operation in progress.
+func (s *ManagedClusterScope) SetPendingOperation(op infrav1.GCEOperation) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	s.GCPManagedCluster.Status.PendingOperations = setPendingOperation(s.GCPManagedCluster.Status.PendingOperations, op)
+}
+
+// DeletePendingOperation removes the GCE operation in progress on the resource.
+func (s *ManagedClusterScope) DeletePendingOperation(resource string) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	s.GCPManagedCluster.Status.PendingOperations = deletePendingOperation(s.GCPManagedCluster.Status.PendingOperations, resource)
+}
+
 // ANCHOR_END: ClusterSetter
 
 // ANCHOR: ClusterNetworkSpec
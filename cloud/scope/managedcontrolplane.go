@@ -27,6 +27,8 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
 	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -126,6 +128,8 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1exp.GKEControlPlaneCreatingCondition,
 			infrav1exp.GKEControlPlaneUpdatingCondition,
 			infrav1exp.GKEControlPlaneDeletingCondition,
+			infrav1exp.GKEControlPlaneUpgradingCondition,
+			infrav1exp.GKEControlPlaneOperationSucceededCondition,
 		}})
 }
 
@@ -187,6 +191,21 @@ func (s *ManagedControlPlaneScope) GetAllNodePools(ctx context.Context) ([]infra
 	return s.AllManagedMachinePools, s.AllMachinePools, nil
 }
 
+// Project returns the project of the GKE cluster.
+func (s *ManagedControlPlaneScope) Project() string {
+	return s.GCPManagedControlPlane.Spec.Project
+}
+
+// ClientOptions returns the options of the GCP API clients, which authenticate with the credentials of the cluster.
+func (s *ManagedControlPlaneScope) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	return defaultClientOptions(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client)
+}
+
+// CredentialsRef returns the reference to the credentials of the cluster, or nil if the default credentials are used.
+func (s *ManagedControlPlaneScope) CredentialsRef() *infrav1.ObjectReference {
+	return s.GCPManagedCluster.Spec.CredentialsRef
+}
+
 // Region returns the region of the GKE cluster.
 func (s *ManagedControlPlaneScope) Region() string {
 	loc, _ := location.Parse(s.GCPManagedControlPlane.Spec.Location)
@@ -220,3 +239,13 @@ func (s *ManagedControlPlaneScope) SetEndpoint(host string) {
 func (s *ManagedControlPlaneScope) IsAutopilotCluster() bool {
 	return s.GCPManagedControlPlane.Spec.EnableAutopilot
 }
+
+// ResourceLabels returns the GCP resource labels of the GKE cluster, including the label
+// marking the cluster as owned by CAPG.
+func (s *ManagedControlPlaneScope) ResourceLabels() infrav1.Labels {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.Cluster.Name,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional:  s.GCPManagedCluster.Spec.AdditionalLabels,
+	})
+}
@@ -20,7 +20,7 @@ import (
 	"context"
 	"fmt"
 
-	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -29,6 +29,10 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -40,6 +44,7 @@ import (
 type ManagedMachinePoolScopeParams struct {
 	ManagedClusterClient        *container.ClusterManagerClient
 	InstanceGroupManagersClient *compute.InstanceGroupManagersClient
+	ZoneOperationsClient        *compute.ZoneOperationsClient
 	Client                      client.Client
 	Cluster                     *clusterv1.Cluster
 	MachinePool                 *clusterv1exp.MachinePool
@@ -81,6 +86,13 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		}
 		params.InstanceGroupManagersClient = instanceGroupManagersClient
 	}
+	if params.ZoneOperationsClient == nil {
+		zoneOperationsClient, err := newZoneOperationsClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp zone operations client: %v", err)
+		}
+		params.ZoneOperationsClient = zoneOperationsClient
+	}
 
 	helper, err := patch.NewHelper(params.GCPManagedMachinePool, params.Client)
 	if err != nil {
@@ -91,10 +103,12 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		client:                 params.Client,
 		Cluster:                params.Cluster,
 		MachinePool:            params.MachinePool,
+		GCPManagedCluster:      params.GCPManagedCluster,
 		GCPManagedControlPlane: params.GCPManagedControlPlane,
 		GCPManagedMachinePool:  params.GCPManagedMachinePool,
 		mcClient:               params.ManagedClusterClient,
 		migClient:              params.InstanceGroupManagersClient,
+		zoneOpsClient:          params.ZoneOperationsClient,
 		patchHelper:            helper,
 	}, nil
 }
@@ -111,6 +125,7 @@ type ManagedMachinePoolScope struct {
 	GCPManagedMachinePool  *infrav1exp.GCPManagedMachinePool
 	mcClient               *container.ClusterManagerClient
 	migClient              *compute.InstanceGroupManagersClient
+	zoneOpsClient          *compute.ZoneOperationsClient
 }
 
 // PatchObject persists the managed control plane configuration and status.
@@ -123,6 +138,7 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 			infrav1exp.GKEMachinePoolCreatingCondition,
 			infrav1exp.GKEMachinePoolUpdatingCondition,
 			infrav1exp.GKEMachinePoolDeletingCondition,
+			infrav1exp.GKEMachinePoolOperationSucceededCondition,
 		}})
 }
 
@@ -130,6 +146,7 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 func (s *ManagedMachinePoolScope) Close() error {
 	s.mcClient.Close()
 	s.migClient.Close()
+	s.zoneOpsClient.Close()
 	return s.PatchObject()
 }
 
@@ -138,6 +155,11 @@ func (s *ManagedMachinePoolScope) ConditionSetter() conditions.Setter {
 	return s.GCPManagedMachinePool
 }
 
+// Client returns a k8s client.
+func (s *ManagedMachinePoolScope) Client() client.Client {
+	return s.client
+}
+
 // ManagedMachinePoolClient returns a client used to interact with GKE.
 func (s *ManagedMachinePoolScope) ManagedMachinePoolClient() *container.ClusterManagerClient {
 	return s.mcClient
@@ -148,17 +170,34 @@ func (s *ManagedMachinePoolScope) InstanceGroupManagersClient() *compute.Instanc
 	return s.migClient
 }
 
+// ZoneOperationsClient returns a client used to poll the GCE operations started on the instances of the node pool.
+func (s *ManagedMachinePoolScope) ZoneOperationsClient() *compute.ZoneOperationsClient {
+	return s.zoneOpsClient
+}
+
+// PendingOperation returns the GCE operation in progress on the resource, or nil if there is none.
+func (s *ManagedMachinePoolScope) PendingOperation(resource string) *infrav1.GCEOperation {
+	return findPendingOperation(s.GCPManagedMachinePool.Status.PendingOperations, resource)
+}
+
+// SetPendingOperation records a GCE operation in progress.
+func (s *ManagedMachinePoolScope) SetPendingOperation(op infrav1.GCEOperation) {
+	s.GCPManagedMachinePool.Status.PendingOperations = setPendingOperation(s.GCPManagedMachinePool.Status.PendingOperations, op)
+}
+
+// DeletePendingOperation removes the GCE operation in progress on the resource.
+func (s *ManagedMachinePoolScope) DeletePendingOperation(resource string) {
+	s.GCPManagedMachinePool.Status.PendingOperations = deletePendingOperation(s.GCPManagedMachinePool.Status.PendingOperations, resource)
+}
+
 // NodePoolVersion returns the k8s version of the node pool.
 func (s *ManagedMachinePoolScope) NodePoolVersion() *string {
 	return s.MachinePool.Spec.Template.Spec.Version
 }
 
 // ConvertToSdkNodePool converts a node pool to format that is used by GCP SDK.
-func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool clusterv1exp.MachinePool, regional bool) *containerpb.NodePool {
-	replicas := *machinePool.Spec.Replicas
-	if regional {
-		replicas /= cloud.DefaultNumRegionsPerZone
-	}
+func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool clusterv1exp.MachinePool, location string) *containerpb.NodePool {
+	replicas := *machinePool.Spec.Replicas / shared.NodePoolZoneCount(&nodePool, location)
 	nodePoolName := nodePool.Spec.NodePoolName
 	if len(nodePoolName) == 0 {
 		nodePoolName = nodePool.Name
@@ -167,12 +206,20 @@ func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool
 		Name:             nodePoolName,
 		InitialNodeCount: replicas,
 		Config: &containerpb.NodeConfig{
-			Labels:   nodePool.Spec.KubernetesLabels,
-			Taints:   infrav1exp.ConvertToSdkTaint(nodePool.Spec.KubernetesTaints),
-			Metadata: nodePool.Spec.AdditionalLabels,
+			Labels: nodePool.Spec.KubernetesLabels,
+			Taints: infrav1exp.ConvertToSdkTaint(nodePool.Spec.KubernetesTaints),
+			Tags:   nodePool.Spec.NetworkTags,
+			ResourceLabels: infrav1.Build(infrav1.BuildParams{
+				ClusterName: machinePool.Spec.ClusterName,
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Additional:  nodePool.Spec.AdditionalLabels,
+			}),
+
+			LinuxNodeConfig: convertToSdkLinuxNodeConfig(nodePool.Spec.LinuxNodeConfig),
+			KubeletConfig:   convertToSdkKubeletConfig(nodePool.Spec.KubeletConfig),
 		},
 	}
-	if nodePool.Spec.Scaling != nil {
+	if nodePool.GKEAutoscalingEnabled() {
 		sdkNodePool.Autoscaling = &containerpb.NodePoolAutoscaling{
 			Enabled:      true,
 			MinNodeCount: *nodePool.Spec.Scaling.MinCount,
@@ -180,16 +227,155 @@ func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool
 		}
 	}
 	if machinePool.Spec.Template.Spec.Version != nil {
-		sdkNodePool.Version = *machinePool.Spec.Template.Spec.Version
+		sdkNodePool.Version = shared.NormalizeVersion(*machinePool.Spec.Template.Spec.Version)
 	}
+	if nodePool.Spec.MaxPodsPerNode != nil {
+		sdkNodePool.MaxPodsConstraint = &containerpb.MaxPodsConstraint{
+			MaxPodsPerNode: *nodePool.Spec.MaxPodsPerNode,
+		}
+	}
+	sdkNodePool.Locations = nodePool.Spec.NodeLocations
+	sdkNodePool.NetworkConfig = convertToSdkNodeNetworkConfig(nodePool.Spec.NetworkConfig)
+	sdkNodePool.Management = convertToSdkNodeManagement(nodePool.Spec.Management)
+	sdkNodePool.UpgradeSettings = convertToSdkUpgradeSettings(nodePool.Spec.UpgradeSettings)
 	return &sdkNodePool
 }
 
+// convertToSdkLinuxNodeConfig converts the Linux node configuration to format that is used by GCP SDK.
+func convertToSdkLinuxNodeConfig(config *infrav1exp.LinuxNodeConfig) *containerpb.LinuxNodeConfig {
+	if config == nil {
+		return nil
+	}
+	sdkConfig := &containerpb.LinuxNodeConfig{}
+	if len(config.Sysctls) > 0 {
+		sdkConfig.Sysctls = map[string]string{}
+		for _, sysctl := range config.Sysctls {
+			sdkConfig.Sysctls[sysctl.Parameter] = sysctl.Value
+		}
+	}
+	if config.CgroupMode != nil {
+		switch *config.CgroupMode {
+		case infrav1exp.CgroupModeV1:
+			sdkConfig.CgroupMode = containerpb.LinuxNodeConfig_CGROUP_MODE_V1
+		case infrav1exp.CgroupModeV2:
+			sdkConfig.CgroupMode = containerpb.LinuxNodeConfig_CGROUP_MODE_V2
+		}
+	}
+	return sdkConfig
+}
+
+// convertToSdkKubeletConfig converts the kubelet configuration to format that is used by GCP SDK.
+func convertToSdkKubeletConfig(config *infrav1exp.KubeletConfig) *containerpb.NodeKubeletConfig {
+	if config == nil {
+		return nil
+	}
+	sdkConfig := &containerpb.NodeKubeletConfig{}
+	if config.CPUManagerPolicy != nil {
+		sdkConfig.CpuManagerPolicy = *config.CPUManagerPolicy
+	}
+	if config.CPUCFSQuota != nil {
+		sdkConfig.CpuCfsQuota = wrapperspb.Bool(*config.CPUCFSQuota)
+	}
+	if config.CPUCFSQuotaPeriod != nil {
+		sdkConfig.CpuCfsQuotaPeriod = *config.CPUCFSQuotaPeriod
+	}
+	if config.PodPidsLimit != nil {
+		sdkConfig.PodPidsLimit = *config.PodPidsLimit
+	}
+	return sdkConfig
+}
+
+// convertToSdkNodeNetworkConfig converts the node pool network config to format that is used by GCP SDK.
+func convertToSdkNodeNetworkConfig(config *infrav1exp.NodePoolNetworkConfig) *containerpb.NodeNetworkConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &containerpb.NodeNetworkConfig{
+		PodRange:           config.PodRange,
+		CreatePodRange:     config.CreatePodRange,
+		PodIpv4CidrBlock:   config.PodIPv4CIDRBlock,
+		EnablePrivateNodes: config.EnablePrivateNodes,
+	}
+}
+
+// convertToSdkNodeManagement converts the node management options to format that is used by GCP SDK.
+// Unspecified options default to true, which are also the GKE defaults.
+func convertToSdkNodeManagement(management *infrav1exp.NodePoolManagement) *containerpb.NodeManagement {
+	if management == nil {
+		return nil
+	}
+	sdkManagement := &containerpb.NodeManagement{
+		AutoRepair:  true,
+		AutoUpgrade: true,
+	}
+	if management.AutoRepair != nil {
+		sdkManagement.AutoRepair = *management.AutoRepair
+	}
+	if management.AutoUpgrade != nil {
+		sdkManagement.AutoUpgrade = *management.AutoUpgrade
+	}
+	return sdkManagement
+}
+
+// convertToSdkUpgradeSettings converts the upgrade settings to format that is used by GCP SDK.
+func convertToSdkUpgradeSettings(settings *infrav1exp.NodePoolUpgradeSettings) *containerpb.NodePool_UpgradeSettings {
+	if settings == nil {
+		return nil
+	}
+
+	if settings.Strategy != nil && *settings.Strategy == infrav1exp.BlueGreenUpgradeStrategy {
+		strategy := containerpb.NodePoolUpdateStrategy_BLUE_GREEN
+		sdkSettings := &containerpb.NodePool_UpgradeSettings{
+			Strategy: &strategy,
+		}
+		if settings.BlueGreenSettings != nil {
+			sdkSettings.BlueGreenSettings = &containerpb.BlueGreenSettings{}
+			if settings.BlueGreenSettings.NodePoolSoakDuration != nil {
+				sdkSettings.BlueGreenSettings.NodePoolSoakDuration = durationpb.New(settings.BlueGreenSettings.NodePoolSoakDuration.Duration)
+			}
+			if policy := settings.BlueGreenSettings.StandardRolloutPolicy; policy != nil {
+				sdkPolicy := &containerpb.BlueGreenSettings_StandardRolloutPolicy{}
+				switch {
+				case policy.BatchPercentage != nil:
+					sdkPolicy.UpdateBatchSize = &containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchPercentage{
+						BatchPercentage: float32(*policy.BatchPercentage) / 100,
+					}
+				case policy.BatchNodeCount != nil:
+					sdkPolicy.UpdateBatchSize = &containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchNodeCount{
+						BatchNodeCount: *policy.BatchNodeCount,
+					}
+				}
+				if policy.BatchSoakDuration != nil {
+					sdkPolicy.BatchSoakDuration = durationpb.New(policy.BatchSoakDuration.Duration)
+				}
+				sdkSettings.BlueGreenSettings.RolloutPolicy = &containerpb.BlueGreenSettings_StandardRolloutPolicy_{
+					StandardRolloutPolicy: sdkPolicy,
+				}
+			}
+		}
+		return sdkSettings
+	}
+
+	strategy := containerpb.NodePoolUpdateStrategy_SURGE
+	sdkSettings := &containerpb.NodePool_UpgradeSettings{
+		Strategy: &strategy,
+		MaxSurge: 1,
+	}
+	if settings.MaxSurge != nil {
+		sdkSettings.MaxSurge = *settings.MaxSurge
+	}
+	if settings.MaxUnavailable != nil {
+		sdkSettings.MaxUnavailable = *settings.MaxUnavailable
+	}
+	return sdkSettings
+}
+
 // ConvertToSdkNodePools converts node pools to format that is used by GCP SDK.
-func ConvertToSdkNodePools(nodePools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool, regional bool) []*containerpb.NodePool {
+func ConvertToSdkNodePools(nodePools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool, location string) []*containerpb.NodePool {
 	res := []*containerpb.NodePool{}
 	for i := range nodePools {
-		res = append(res, ConvertToSdkNodePool(nodePools[i], machinePools[i], regional))
+		res = append(res, ConvertToSdkNodePool(nodePools[i], machinePools[i], location))
 	}
 	return res
 }
@@ -207,6 +393,21 @@ func (s *ManagedMachinePoolScope) NodePoolName() string {
 	return s.GCPManagedMachinePool.Name
 }
 
+// Project returns the project of the GKE node pool.
+func (s *ManagedMachinePoolScope) Project() string {
+	return s.GCPManagedControlPlane.Spec.Project
+}
+
+// ClientOptions returns the options of the GCP API clients, which authenticate with the credentials of the cluster.
+func (s *ManagedMachinePoolScope) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	return defaultClientOptions(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client)
+}
+
+// CredentialsRef returns the reference to the credentials of the cluster, or nil if the default credentials are used.
+func (s *ManagedMachinePoolScope) CredentialsRef() *infrav1.ObjectReference {
+	return s.GCPManagedCluster.Spec.CredentialsRef
+}
+
 // Region returns the region of the GKE node pool.
 func (s *ManagedMachinePoolScope) Region() string {
 	loc, _ := location.Parse(s.GCPManagedControlPlane.Spec.Location)
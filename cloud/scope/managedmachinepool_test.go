@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestConvertToSdkNodePoolVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version *string
+		want    string
+	}{
+		{
+			name:    "no version",
+			version: nil,
+			want:    "",
+		},
+		{
+			name:    "version with v prefix",
+			version: pointer.String("v1.27.3"),
+			want:    "1.27.3",
+		},
+		{
+			name:    "GKE version",
+			version: pointer.String("1.27.3-gke.100"),
+			want:    "1.27.3-gke.100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machinePool := clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{Replicas: pointer.Int32(1)}}
+			machinePool.Spec.Template.Spec.Version = tt.version
+			if got := ConvertToSdkNodePool(infrav1exp.GCPManagedMachinePool{}, machinePool, "us-central1-a").Version; got != tt.want {
+				t.Errorf("ConvertToSdkNodePool().Version = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkNodePoolInitialNodeCount(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodeLocations []string
+		location      string
+		want          int32
+	}{
+		{
+			name:     "zonal cluster",
+			location: "us-central1-a",
+			want:     6,
+		},
+		{
+			name:     "regional cluster",
+			location: "us-central1",
+			want:     2,
+		},
+		{
+			name:          "explicit node locations",
+			nodeLocations: []string{"us-central1-a", "us-central1-b"},
+			location:      "us-central1",
+			want:          3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodePool := infrav1exp.GCPManagedMachinePool{Spec: infrav1exp.GCPManagedMachinePoolSpec{NodeLocations: tt.nodeLocations}}
+			machinePool := clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{Replicas: pointer.Int32(6)}}
+			if got := ConvertToSdkNodePool(nodePool, machinePool, tt.location).InitialNodeCount; got != tt.want {
+				t.Errorf("ConvertToSdkNodePool().InitialNodeCount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
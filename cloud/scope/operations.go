@@ -0,0 +1,53 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+// findPendingOperation returns the operation in progress on the resource, or nil if there is none.
+func findPendingOperation(ops []infrav1.GCEOperation, resource string) *infrav1.GCEOperation {
+	for i := range ops {
+		if ops[i].Resource == resource {
+			return &ops[i]
+		}
+	}
+
+	return nil
+}
+
+// setPendingOperation records the operation, replacing the previous operation on its resource.
+func setPendingOperation(ops []infrav1.GCEOperation, op infrav1.GCEOperation) []infrav1.GCEOperation {
+	if existing := findPendingOperation(ops, op.Resource); existing != nil {
+		*existing = op
+		return ops
+	}
+
+	return append(ops, op)
+}
+
+// deletePendingOperation removes the operation in progress on the resource.
+func deletePendingOperation(ops []infrav1.GCEOperation, resource string) []infrav1.GCEOperation {
+	for i := range ops {
+		if ops[i].Resource == resource {
+			return append(ops[:i], ops[i+1:]...)
+		}
+	}
+
+	return ops
+}
@@ -20,46 +20,80 @@ import (
 	"context"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Reconcile reconcile cluster firewall compoenents.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling firewall resources")
+	creating := false
 	for _, spec := range s.scope.FirewallRulesSpec() {
-		log.V(2).Info("Looking firewall", "name", spec.Name)
 		firewallKey := meta.GlobalKey(spec.Name)
+		firewallResource := operations.ResourceName(s.scope.Project(), "firewalls", firewallKey)
+		pending, err := operations.Check(ctx, s.operations, s.scope, firewallResource)
+		if err != nil {
+			log.Error(err, "Error creating firewall", "name", spec.Name)
+			return ctrl.Result{}, err
+		}
+		if pending {
+			creating = true
+			continue
+		}
+
+		log.V(2).Info("Looking firewall", "name", spec.Name)
 		if _, err := s.firewalls.Get(ctx, firewallKey); err != nil {
 			if !gcperrors.IsNotFound(err) {
-				return err
+				return ctrl.Result{}, err
 			}
 
 			log.V(2).Info("Creating firewall", "name", spec.Name)
-			if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
-				return err
+			op, err := s.operations.InsertFirewall(ctx, firewallKey, spec)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := operations.Track(s.scope, firewallResource, op); err != nil {
+				return ctrl.Result{}, err
 			}
+			creating = true
 		}
 	}
 
-	return nil
+	if creating {
+		log.Info("Waiting for firewalls to be created")
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
 
 // Delete delete cluster firewall compoenents.
-func (s *Service) Delete(ctx context.Context) error {
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Deleting firewall resources")
+	deleting := false
 	for _, spec := range s.scope.FirewallRulesSpec() {
 		log.V(2).Info("Deleting firewall", "name", spec.Name)
 		firewallKey := meta.GlobalKey(spec.Name)
-		if err := s.firewalls.Delete(ctx, firewallKey); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				log.Error(err, "Error deleting firewall", "name", spec.Name)
-				return err
-			}
+		firewallResource := operations.ResourceName(s.scope.Project(), "firewalls", firewallKey)
+		firewallDeleting, err := operations.Delete(ctx, s.operations, s.scope, firewallResource, func() (*compute.Operation, error) {
+			return s.operations.DeleteFirewall(ctx, firewallKey)
+		})
+		if err != nil {
+			log.Error(err, "Error deleting firewall", "name", spec.Name)
+			return ctrl.Result{}, err
 		}
+		deleting = deleting || firewallDeleting
+	}
+
+	if deleting {
+		log.Info("Waiting for firewalls to be deleted")
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
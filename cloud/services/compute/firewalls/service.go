@@ -22,33 +22,40 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
 )
 
 type firewallsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Firewall, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Firewall) error
-	Update(ctx context.Context, key *meta.Key, obj *compute.Firewall) error
-	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertFirewall(ctx context.Context, key *meta.Key, obj *compute.Firewall) (*compute.Operation, error)
+	DeleteFirewall(ctx context.Context, key *meta.Key) (*compute.Operation, error)
 }
 
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.ClusterGetter
+	cloud.OperationTracker
 	FirewallRulesSpec() []*compute.Firewall
 }
 
 // Service implements firewalls reconciler.
 type Service struct {
-	scope     Scope
-	firewalls firewallsInterface
+	scope      Scope
+	firewalls  firewallsInterface
+	operations operationsInterface
 }
 
-var _ cloud.Reconciler = &Service{}
+var _ cloud.ReconcilerWithResult = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
-		scope:     scope,
-		firewalls: scope.Cloud().Firewalls(),
+		scope:      scope,
+		firewalls:  scope.Cloud().Firewalls(),
+		operations: operations.NewClient(scope.ComputeService(), scope.Project()),
 	}
 }
@@ -0,0 +1,565 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// PollInterval is the interval at which the managed instance group is polled while it creates, replaces or
+	// deletes instances.
+	PollInterval = 30 * time.Second
+
+	// maxResourceNameLength leaves room in the 63 characters of an instance template name for the hash suffixes.
+	maxResourceNameLength = 45
+
+	// hashLength is the length of the hashes of the spec and of the bootstrap data in an instance template name.
+	hashLength = 8
+)
+
+// Reconcile creates the instance template of the machine pool and the managed instance group, rolls the instances
+// onto a new instance template when it changes, and reports the instances.
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling managed instance group resources")
+
+	templateName, res, err := s.reconcileInstanceTemplate(ctx)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	igm, res, err := s.reconcileInstanceGroupManager(ctx, templateName)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	if res, err := s.deleteInstanceTemplates(ctx, templateName); err != nil || !res.IsZero() {
+		return res, err
+	}
+	s.scope.SetInstanceTemplate(templateName)
+
+	return s.reconcileInstances(ctx, igm)
+}
+
+// Delete deletes the managed instance group, with its instances, and then the instance templates.
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Deleting managed instance group resources")
+
+	key, err := s.instanceGroupManagerKey()
+	if err != nil {
+		// The managed instance group can't have been created without zones.
+		log.V(2).Info("Skipping managed instance group deletion", "reason", err.Error())
+		return s.deleteInstanceTemplates(ctx, "")
+	}
+
+	resource := operations.ResourceName(s.scope.Project(), "instanceGroupManagers", key)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, resource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error deleting a managed instance group", "name", key.Name)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	log.V(2).Info("Looking for managed instance group before deleting", "name", key.Name)
+	if _, err := s.instancegroupmanagers.Get(ctx, key); err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for managed instance group before deleting", "name", key.Name)
+			return ctrl.Result{}, err
+		}
+
+		return s.deleteInstanceTemplates(ctx, "")
+	}
+
+	log.V(2).Info("Deleting a managed instance group", "name", key.Name)
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.InstanceGroupReadyCondition, infrav1exp.InstanceGroupDeletingReason, clusterv1.ConditionSeverityInfo, "")
+	op, err := s.operations.DeleteInstanceGroupManager(ctx, key)
+	if err == nil {
+		err = operations.Track(s.scope, resource, op)
+	}
+	if gcperrors.IgnoreNotFound(err) != nil {
+		log.Error(err, "Error deleting a managed instance group", "name", key.Name)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+}
+
+// reconcileInstanceTemplate creates the instance template built from the spec and the bootstrap data, and returns
+// its name once it exists. Instance templates can't be changed, so a change of either one creates a new template;
+// the spec and the bootstrap data are hashed separately in its name to tell which one changed.
+func (s *Service) reconcileInstanceTemplate(ctx context.Context) (string, ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Getting bootstrap data for machine pool")
+	bootstrapData, err := s.scope.GetBootstrapData()
+	if err != nil {
+		log.Error(err, "Error getting bootstrap data for machine pool")
+		return "", ctrl.Result{}, errors.Wrap(err, "failed to retrieve bootstrap data")
+	}
+
+	properties := s.scope.InstancePropertiesSpec(log)
+	templateName, err := instanceTemplateName(s.resourceName(), properties, bootstrapData)
+	if err != nil {
+		return "", ctrl.Result{}, err
+	}
+	properties.Metadata.Items = append(properties.Metadata.Items, &compute.MetadataItems{
+		Key:   "user-data",
+		Value: pointer.String(bootstrapData),
+	})
+
+	templateKey := meta.GlobalKey(templateName)
+	templateResource := operations.ResourceName(s.scope.Project(), "instanceTemplates", templateKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, templateResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating an instance template", "name", templateName)
+			return "", ctrl.Result{}, err
+		}
+		return "", ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	log.V(2).Info("Looking for instance template", "name", templateName)
+	if _, err := s.instancetemplates.Get(ctx, templateKey); err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for instance template", "name", templateName)
+			return "", ctrl.Result{}, err
+		}
+
+		log.V(2).Info("Creating an instance template", "name", templateName)
+		op, err := s.operations.InsertInstanceTemplate(ctx, templateKey, &compute.InstanceTemplate{
+			Description: s.instanceTemplateDescription(),
+			Properties:  properties,
+		})
+		if err == nil {
+			err = operations.Track(s.scope, templateResource, op)
+		}
+		if err != nil {
+			log.Error(err, "Error creating an instance template", "name", templateName)
+			return "", ctrl.Result{}, err
+		}
+
+		return "", ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	return templateName, ctrl.Result{}, nil
+}
+
+// reconcileInstanceGroupManager creates the managed instance group, or updates its instance template, target size
+// and update policy. It returns the managed instance group once it is up to date.
+func (s *Service) reconcileInstanceGroupManager(ctx context.Context, templateName string) (*compute.InstanceGroupManager, ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	key, err := s.instanceGroupManagerKey()
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+
+	resource := operations.ResourceName(s.scope.Project(), "instanceGroupManagers", key)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, resource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error updating a managed instance group", "name", key.Name)
+			return nil, ctrl.Result{}, err
+		}
+		return nil, ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	templateLink := path.Join("global", "instanceTemplates", templateName)
+	updatePolicy := s.updatePolicy()
+	log.V(2).Info("Looking for managed instance group", "name", key.Name)
+	igm, err := s.instancegroupmanagers.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for managed instance group", "name", key.Name)
+			return nil, ctrl.Result{}, err
+		}
+
+		igm := &compute.InstanceGroupManager{
+			Description:      fmt.Sprintf("Managed instance group of the machine pool %s/%s", s.scope.Namespace(), s.scope.Name()),
+			BaseInstanceName: s.resourceName(),
+			InstanceTemplate: templateLink,
+			TargetSize:       s.scope.Replicas(),
+			UpdatePolicy:     updatePolicy,
+			ForceSendFields:  []string{"TargetSize"},
+		}
+		if key.Type() == meta.Regional {
+			igm.DistributionPolicy = &compute.DistributionPolicy{}
+			for _, zone := range s.scope.Zones() {
+				igm.DistributionPolicy.Zones = append(igm.DistributionPolicy.Zones, &compute.DistributionPolicyZoneConfiguration{
+					Zone: path.Join("zones", zone),
+				})
+			}
+		}
+
+		log.V(2).Info("Creating a managed instance group", "name", key.Name, "template", templateName)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.InstanceGroupReadyCondition, infrav1exp.InstanceGroupCreatingReason, clusterv1.ConditionSeverityInfo, "")
+		op, err := s.operations.InsertInstanceGroupManager(ctx, key, igm)
+		if err == nil {
+			err = operations.Track(s.scope, resource, op)
+		}
+		if err != nil {
+			log.Error(err, "Error creating a managed instance group", "name", key.Name)
+			return nil, ctrl.Result{}, err
+		}
+
+		return nil, ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	s.scope.SetInstanceGroupManager(igm.SelfLink)
+	s.scope.SetReady()
+
+	patch := &compute.InstanceGroupManager{}
+	var changes []string
+	switch current := path.Base(igm.InstanceTemplate); {
+	case current == templateName:
+		// A proactive update policy isn't restored while instances only run previous bootstrap data, it would
+		// replace them; it applies again to the next change of the spec.
+		if updatePolicy.Type == "PROACTIVE" && igm.UpdatePolicy != nil && igm.UpdatePolicy.Type == "OPPORTUNISTIC" {
+			outdated, err := s.bootstrapDataOutdatedOnly(ctx, key, templateName)
+			if err != nil {
+				return nil, ctrl.Result{}, err
+			}
+			if outdated {
+				updatePolicy.Type = igm.UpdatePolicy.Type
+			}
+		}
+	case bootstrapDataChangedOnly(current, templateName):
+		// The bootstrap data, e.g. a rotated bootstrap token, is only needed by new instances: the instances
+		// which already joined the cluster are kept.
+		patch.InstanceTemplate = templateLink
+		patch.Versions = []*compute.InstanceGroupManagerVersion{{InstanceTemplate: templateLink}}
+		updatePolicy.Type = "OPPORTUNISTIC"
+		changes = append(changes, "bootstrap data")
+	default:
+		patch.InstanceTemplate = templateLink
+		patch.Versions = []*compute.InstanceGroupManagerVersion{{InstanceTemplate: templateLink}}
+		changes = append(changes, "instance template")
+	}
+	if igm.TargetSize != s.scope.Replicas() {
+		patch.TargetSize = s.scope.Replicas()
+		patch.ForceSendFields = append(patch.ForceSendFields, "TargetSize")
+		changes = append(changes, "target size")
+	}
+	if updatePolicyChanged(igm.UpdatePolicy, updatePolicy) {
+		changes = append(changes, "update policy")
+	}
+	if len(changes) == 0 {
+		return igm, ctrl.Result{}, nil
+	}
+	// The update policy applies to the change of instance template in the same request.
+	patch.UpdatePolicy = updatePolicy
+
+	log.V(2).Info("Updating a managed instance group", "name", key.Name, "changes", changes)
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.InstanceGroupReadyCondition, infrav1exp.InstanceGroupUpdatingReason, clusterv1.ConditionSeverityInfo, "Updating %s", strings.Join(changes, ", "))
+	op, err := s.operations.PatchInstanceGroupManager(ctx, key, patch)
+	if err == nil {
+		err = operations.Track(s.scope, resource, op)
+	}
+	if err != nil {
+		log.Error(err, "Error updating a managed instance group", "name", key.Name)
+		return nil, ctrl.Result{}, err
+	}
+
+	return nil, ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+}
+
+// bootstrapDataOutdatedOnly returns true if some instances of the managed instance group were created from an
+// instance template which only differs by the bootstrap data, and none from an instance template with another spec.
+func (s *Service) bootstrapDataOutdatedOnly(ctx context.Context, key *meta.Key, templateName string) (bool, error) {
+	log := log.FromContext(ctx)
+	instances, err := s.instancegroupmanagers.ListManagedInstances(ctx, key)
+	if err != nil {
+		log.Error(err, "Error listing instances of managed instance group", "name", key.Name)
+		return false, err
+	}
+
+	var outdated bool
+	for _, instance := range instances {
+		if instance.Version == nil {
+			continue
+		}
+		switch current := path.Base(instance.Version.InstanceTemplate); {
+		case current == templateName:
+		case bootstrapDataChangedOnly(current, templateName):
+			outdated = true
+		default:
+			return false, nil
+		}
+	}
+	return outdated, nil
+}
+
+// reconcileInstances reports the provider IDs and the number of running instances of the managed instance group,
+// and polls it until it is stable.
+func (s *Service) reconcileInstances(ctx context.Context, igm *compute.InstanceGroupManager) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	key, err := s.instanceGroupManagerKey()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.V(2).Info("Listing instances of managed instance group", "name", key.Name)
+	instances, err := s.instancegroupmanagers.ListManagedInstances(ctx, key)
+	if err != nil {
+		log.Error(err, "Error listing instances of managed instance group", "name", key.Name)
+		return ctrl.Result{}, err
+	}
+
+	providerIDs := make([]string, 0, len(instances))
+	var running int32
+	for _, instance := range instances {
+		if instance.Instance == "" {
+			continue
+		}
+		providerID, err := providerid.NewFromResourceURL(instance.Instance)
+		if err != nil {
+			log.Error(err, "Invalid instance URL in managed instance group", "name", key.Name, "url", instance.Instance)
+			continue
+		}
+		providerIDs = append(providerIDs, providerID.String())
+		if instance.InstanceStatus == "RUNNING" {
+			running++
+		}
+	}
+	sort.Strings(providerIDs)
+	s.scope.SetProviderIDList(providerIDs)
+	s.scope.SetReplicas(running)
+
+	if igm.Status == nil || !igm.Status.IsStable {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.InstanceGroupReadyCondition, infrav1exp.InstanceGroupUpdatingReason, clusterv1.ConditionSeverityInfo, "Managed instance group is creating, replacing or deleting instances")
+		return ctrl.Result{RequeueAfter: PollInterval}, nil
+	}
+
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.InstanceGroupReadyCondition)
+	return ctrl.Result{}, nil
+}
+
+// deleteInstanceTemplates deletes the instance templates of the machine pool, except the current one. The templates
+// are matched by their name and their description, which both identify the machine pool.
+func (s *Service) deleteInstanceTemplates(ctx context.Context, current string) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	pattern := instanceTemplatePattern(s.resourceName())
+	description := s.instanceTemplateDescription()
+	templates, err := s.instancetemplates.List(ctx, filter.Regexp("name", pattern.String()))
+	if err != nil {
+		log.Error(err, "Error listing instance templates")
+		return ctrl.Result{}, err
+	}
+
+	// The template recorded in the status may be gone already, with its deletion still tracked.
+	names := map[string]bool{}
+	if previous := s.scope.InstanceTemplate(); previous != "" {
+		names[previous] = false
+	}
+	for _, template := range templates {
+		if pattern.MatchString(template.Name) && template.Description == description {
+			names[template.Name] = true
+		}
+	}
+
+	var res ctrl.Result
+	for name, exists := range names {
+		if name == current {
+			continue
+		}
+
+		key := meta.GlobalKey(name)
+		resource := operations.ResourceName(s.scope.Project(), "instanceTemplates", key)
+		if pending, err := operations.Check(ctx, s.operations, s.scope, resource); err != nil || pending {
+			if gcperrors.IgnoreNotFound(err) != nil {
+				log.Error(err, "Error deleting an instance template", "name", name)
+				return ctrl.Result{}, err
+			}
+			res.RequeueAfter = operations.PollInterval
+			continue
+		}
+		if !exists {
+			continue
+		}
+
+		log.V(2).Info("Deleting an instance template", "name", name)
+		op, err := s.operations.DeleteInstanceTemplate(ctx, key)
+		if err == nil {
+			err = operations.Track(s.scope, resource, op)
+		}
+		if gcperrors.IgnoreNotFound(err) != nil {
+			log.Error(err, "Error deleting an instance template", "name", name)
+			return ctrl.Result{}, err
+		}
+		res.RequeueAfter = operations.PollInterval
+	}
+
+	return res, nil
+}
+
+// instanceGroupManagerKey returns the key of the managed instance group: the one recorded in the status once it
+// exists, otherwise a zonal key if the machine pool has a single zone, or a regional key.
+func (s *Service) instanceGroupManagerKey() (*meta.Key, error) {
+	if selfLink := s.scope.InstanceGroupManager(); selfLink != "" {
+		id, err := k8scloud.ParseResourceURL(selfLink)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid managed instance group URL %q", selfLink)
+		}
+		return id.Key, nil
+	}
+
+	zones := s.scope.Zones()
+	switch len(zones) {
+	case 0:
+		return nil, errors.New("machine pool has no zones, the cluster has no failure domains yet")
+	case 1:
+		return meta.ZonalKey(s.resourceName(), zones[0]), nil
+	default:
+		return meta.RegionalKey(s.resourceName(), s.scope.Region()), nil
+	}
+}
+
+// resourceName returns the name of the managed instance group, of its instances and the prefix of the instance
+// templates of the machine pool. Machine pools of the same name in other namespaces share the project, so the name
+// is followed by a hash of the namespace and name of the machine pool.
+func (s *Service) resourceName() string {
+	return resourceName(s.scope.Namespace(), s.scope.Name())
+}
+
+// instanceTemplateDescription returns the description of the instance templates of the machine pool.
+func (s *Service) instanceTemplateDescription() string {
+	return fmt.Sprintf("Instance template of the machine pool %s/%s", s.scope.Namespace(), s.scope.Name())
+}
+
+// updatePolicy returns the update policy of the managed instance group from the update strategy of the machine pool.
+func (s *Service) updatePolicy() *compute.InstanceGroupManagerUpdatePolicy {
+	policy := &compute.InstanceGroupManagerUpdatePolicy{
+		Type:          "PROACTIVE",
+		MinimalAction: "REPLACE",
+	}
+
+	strategy := s.scope.UpdateStrategy()
+	if strategy == nil {
+		return policy
+	}
+	if strategy.Type == infrav1exp.OpportunisticUpdateType {
+		policy.Type = "OPPORTUNISTIC"
+	}
+	policy.MaxSurge = fixedOrPercent(strategy.MaxSurge)
+	policy.MaxUnavailable = fixedOrPercent(strategy.MaxUnavailable)
+
+	return policy
+}
+
+// updatePolicyChanged returns true if the update policy of the managed instance group differs from the desired one.
+// The surge and unavailable instances are only compared when they are specified, GCE computes them otherwise.
+func updatePolicyChanged(current, desired *compute.InstanceGroupManagerUpdatePolicy) bool {
+	if current == nil {
+		return true
+	}
+	if current.Type != desired.Type || current.MinimalAction != desired.MinimalAction {
+		return true
+	}
+	return fixedOrPercentChanged(current.MaxSurge, desired.MaxSurge) ||
+		fixedOrPercentChanged(current.MaxUnavailable, desired.MaxUnavailable)
+}
+
+func fixedOrPercentChanged(current, desired *compute.FixedOrPercent) bool {
+	if desired == nil {
+		return false
+	}
+	if current == nil {
+		return true
+	}
+	return current.Fixed != desired.Fixed || current.Percent != desired.Percent
+}
+
+// fixedOrPercent converts a number or a percentage of instances, e.g. 1 or "25%".
+func fixedOrPercent(v *intstr.IntOrString) *compute.FixedOrPercent {
+	if v == nil {
+		return nil
+	}
+	if v.Type == intstr.Int {
+		return &compute.FixedOrPercent{Fixed: int64(v.IntVal), ForceSendFields: []string{"Fixed"}}
+	}
+	percent, err := strconv.ParseInt(strings.TrimSuffix(v.StrVal, "%"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &compute.FixedOrPercent{Percent: percent, ForceSendFields: []string{"Percent"}}
+}
+
+// instanceTemplateName returns the name of the instance template with the properties and the bootstrap data: the
+// resource name of the machine pool followed by a hash of the properties and a hash of the bootstrap data.
+func instanceTemplateName(name string, properties *compute.InstanceProperties, bootstrapData string) (string, error) {
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash instance template properties")
+	}
+
+	return fmt.Sprintf("%s-%s-%s", name, hash(data), hash([]byte(bootstrapData))), nil
+}
+
+// bootstrapDataChangedOnly returns true if the names of two instance templates only differ by the hash of the
+// bootstrap data.
+func bootstrapDataChangedOnly(current, desired string) bool {
+	if len(current) != len(desired) || len(desired) <= hashLength {
+		return false
+	}
+	spec := len(desired) - hashLength
+	return current[:spec] == desired[:spec]
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// instanceTemplatePattern matches the names of the instance templates with the resource name of the machine pool.
+func instanceTemplatePattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf("^%s-[0-9a-f]{%d}-[0-9a-f]{%d}$", regexp.QuoteMeta(name), hashLength, hashLength))
+}
+
+// resourceName returns the name of the machine pool, shortened to leave room for the hash suffixes, followed by a hash
+// of its namespace and name.
+func resourceName(namespace, name string) string {
+	suffix := hash([]byte(namespace + "/" + name))
+	if maxLength := maxResourceNameLength - hashLength - 1; len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return fmt.Sprintf("%s-%s", name, suffix)
+}
@@ -0,0 +1,547 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = clusterv1exp.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+	_ = infrav1exp.AddToScheme(scheme.Scheme)
+}
+
+// fakeInstanceGroupManagers keeps the managed instance groups and their instances.
+type fakeInstanceGroupManagers struct {
+	igms      map[meta.Key]*compute.InstanceGroupManager
+	instances map[meta.Key][]*compute.ManagedInstance
+	patches   []*compute.InstanceGroupManager
+}
+
+func (f *fakeInstanceGroupManagers) Get(_ context.Context, key *meta.Key) (*compute.InstanceGroupManager, error) {
+	igm, ok := f.igms[*key]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return igm, nil
+}
+
+func (f *fakeInstanceGroupManagers) ListManagedInstances(_ context.Context, key *meta.Key) ([]*compute.ManagedInstance, error) {
+	return f.instances[*key], nil
+}
+
+// fakeOperations starts the operations on the fakes, reporting them as running until they are polled.
+type fakeOperations struct {
+	templates *cloud.MockInstanceTemplates
+	igms      *fakeInstanceGroupManagers
+}
+
+func (f *fakeOperations) Get(_ context.Context, selfLink string) (*compute.Operation, error) {
+	return &compute.Operation{SelfLink: selfLink, Status: "DONE"}, nil
+}
+
+func (f *fakeOperations) InsertInstanceTemplate(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) (*compute.Operation, error) {
+	if err := f.templates.Insert(ctx, key, obj); err != nil {
+		return nil, err
+	}
+	return &compute.Operation{SelfLink: "operations/insert-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) DeleteInstanceTemplate(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	if err := f.templates.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	return &compute.Operation{SelfLink: "operations/delete-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) InsertInstanceGroupManager(_ context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error) {
+	location := "zones/" + key.Zone
+	if key.Type() == meta.Regional {
+		location = "regions/" + key.Region
+	}
+	obj.Name = key.Name
+	obj.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/my-proj/%s/instanceGroupManagers/%s", location, key.Name)
+	obj.InstanceTemplate = "https://www.googleapis.com/compute/v1/projects/my-proj/" + obj.InstanceTemplate
+	obj.Status = &compute.InstanceGroupManagerStatus{}
+	f.igms.igms[*key] = obj
+	return &compute.Operation{SelfLink: "operations/insert-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) PatchInstanceGroupManager(_ context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error) {
+	igm, ok := f.igms.igms[*key]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	if obj.InstanceTemplate != "" {
+		igm.InstanceTemplate = "https://www.googleapis.com/compute/v1/projects/my-proj/" + obj.InstanceTemplate
+	}
+	if len(obj.ForceSendFields) > 0 {
+		igm.TargetSize = obj.TargetSize
+	}
+	igm.UpdatePolicy = obj.UpdatePolicy
+	f.igms.patches = append(f.igms.patches, obj)
+	return &compute.Operation{SelfLink: "operations/patch-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) DeleteInstanceGroupManager(_ context.Context, key *meta.Key) (*compute.Operation, error) {
+	if _, ok := f.igms.igms[*key]; !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	delete(f.igms.igms, *key)
+	return &compute.Operation{SelfLink: "operations/delete-" + key.Name, Status: "RUNNING"}, nil
+}
+
+var fakeBootstrapSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool-bootstrap",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"value": []byte("Zm9vCg=="),
+	},
+}
+
+// fakeRotatedBootstrapSecret holds the bootstrap data of the machine pool once its bootstrap token is rotated.
+var fakeRotatedBootstrapSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool-bootstrap-rotated",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"value": []byte("YmFyCg=="),
+	},
+}
+
+func newMachinePoolScope(t *testing.T, failureDomains ...string) *scope.MachinePoolScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret, fakeRotatedBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client: fakec,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		},
+		GCPCluster: &infrav1.GCPCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+			Spec: infrav1.GCPClusterSpec{
+				Project: "my-proj",
+				Region:  "us-central1",
+			},
+			Status: infrav1.GCPClusterStatus{
+				FailureDomains: clusterv1.FailureDomains{
+					"us-central1-a": clusterv1.FailureDomainSpec{},
+					"us-central1-b": clusterv1.FailureDomainSpec{},
+					"us-central1-c": clusterv1.FailureDomainSpec{},
+				},
+			},
+		},
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:        fakec,
+		ClusterGetter: clusterScope,
+		MachinePool: &clusterv1exp.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pool", Namespace: "default"},
+			Spec: clusterv1exp.MachinePoolSpec{
+				ClusterName:    "my-cluster",
+				Replicas:       pointer.Int32(2),
+				FailureDomains: failureDomains,
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Bootstrap: clusterv1.Bootstrap{
+							DataSecretName: pointer.String("my-pool-bootstrap"),
+						},
+						Version: pointer.String("v1.27.3"),
+					},
+				},
+			},
+		},
+		GCPMachinePool: &infrav1exp.GCPMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pool", Namespace: "default"},
+			Spec: infrav1exp.GCPMachinePoolSpec{
+				Template: infrav1.GCPMachineSpec{
+					InstanceType: "n1-standard-2",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return machinePoolScope
+}
+
+func newTestService(s Scope) (*Service, *cloud.MockInstanceTemplates, *fakeInstanceGroupManagers) {
+	templates := cloud.NewMockInstanceTemplates(&cloud.SingleProjectRouter{ID: "my-proj"}, map[meta.Key]*cloud.MockInstanceTemplatesObj{})
+	igms := &fakeInstanceGroupManagers{
+		igms:      map[meta.Key]*compute.InstanceGroupManager{},
+		instances: map[meta.Key][]*compute.ManagedInstance{},
+	}
+	return &Service{
+		scope:                 s,
+		instancetemplates:     templates,
+		instancegroupmanagers: igms,
+		operations:            &fakeOperations{templates: templates, igms: igms},
+	}, templates, igms
+}
+
+func templateNames(g *WithT, templates *cloud.MockInstanceTemplates) []string {
+	list, err := templates.List(context.TODO(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	names := make([]string, 0, len(list))
+	for _, template := range list {
+		names = append(names, template.Name)
+	}
+	return names
+}
+
+func TestService_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := newMachinePoolScope(t)
+	s, templates, igms := newTestService(machinePoolScope)
+	regionalKey := *meta.RegionalKey(resourceName("default", "my-pool"), "us-central1")
+
+	// The instance template is created first.
+	res, err := s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(templateNames(g, templates)).To(HaveLen(1))
+	template := templateNames(g, templates)[0]
+	g.Expect(template).To(HavePrefix("my-pool-"))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.PendingOperations).To(HaveLen(1))
+
+	obj, err := templates.Get(context.TODO(), meta.GlobalKey(template))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(obj.Properties.MachineType).To(Equal("n1-standard-2"))
+	g.Expect(obj.Properties.Disks[0].InitializeParams.DiskType).To(Equal("pd-standard"))
+	g.Expect(obj.Properties.Disks[0].InitializeParams.SourceImage).To(Equal("projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-27"))
+	g.Expect(obj.Properties.Metadata.Items).To(ContainElement(&compute.MetadataItems{Key: "user-data", Value: pointer.String("Zm9vCg==")}))
+	g.Expect(obj.Properties.Tags.Items).To(ConsistOf("my-cluster-node", "my-cluster"))
+
+	// Then the managed instance group, spread across the zones of the cluster.
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(igms.igms).To(HaveKey(regionalKey))
+	igm := igms.igms[regionalKey]
+	g.Expect(igm.TargetSize).To(Equal(int64(2)))
+	g.Expect(igm.BaseInstanceName).To(Equal(resourceName("default", "my-pool")))
+	g.Expect(igm.DistributionPolicy.Zones).To(HaveLen(3))
+	g.Expect(igm.UpdatePolicy.Type).To(Equal("PROACTIVE"))
+	g.Expect(conditions.GetReason(machinePoolScope.GCPMachinePool, infrav1exp.InstanceGroupReadyCondition)).To(Equal(infrav1exp.InstanceGroupCreatingReason))
+
+	// The instances are reported while they are created.
+	igms.instances[regionalKey] = []*compute.ManagedInstance{
+		{Instance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-b/instances/my-pool-x7k2", InstanceStatus: "STAGING"},
+		{Instance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-q9c4", InstanceStatus: "RUNNING"},
+		{CurrentAction: "CREATING"},
+	}
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(PollInterval))
+	g.Expect(machinePoolScope.GCPMachinePool.Spec.ProviderIDList).To(Equal([]string{
+		"gce://my-proj/us-central1-a/my-pool-q9c4",
+		"gce://my-proj/us-central1-b/my-pool-x7k2",
+	}))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.Replicas).To(Equal(int32(1)))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.Ready).To(BeTrue())
+	g.Expect(machinePoolScope.GCPMachinePool.Status.InstanceTemplate).To(Equal(template))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.InstanceGroupManager).To(Equal(igm.SelfLink))
+	g.Expect(conditions.GetReason(machinePoolScope.GCPMachinePool, infrav1exp.InstanceGroupReadyCondition)).To(Equal(infrav1exp.InstanceGroupUpdatingReason))
+
+	igm.Status.IsStable = true
+	igms.instances[regionalKey][0].InstanceStatus = "RUNNING"
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.Replicas).To(Equal(int32(2)))
+	g.Expect(conditions.IsTrue(machinePoolScope.GCPMachinePool, infrav1exp.InstanceGroupReadyCondition)).To(BeTrue())
+	g.Expect(igms.patches).To(BeEmpty())
+
+	// A change of the spec rolls the instances onto a new instance template, and the previous one is deleted.
+	machinePoolScope.GCPMachinePool.Spec.Template.InstanceType = "n2-standard-4"
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(templateNames(g, templates)).To(HaveLen(2))
+
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(igms.patches).To(HaveLen(1))
+	newTemplate := strings.TrimPrefix(igms.patches[0].InstanceTemplate, "global/instanceTemplates/")
+	g.Expect(newTemplate).NotTo(Equal(template))
+	g.Expect(igms.patches[0].Versions).To(HaveLen(1))
+	g.Expect(igms.patches[0].UpdatePolicy.Type).To(Equal("PROACTIVE"))
+	g.Expect(conditions.GetReason(machinePoolScope.GCPMachinePool, infrav1exp.InstanceGroupReadyCondition)).To(Equal(infrav1exp.InstanceGroupUpdatingReason))
+
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(templateNames(g, templates)).To(ConsistOf(newTemplate))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.InstanceTemplate).To(Equal(template))
+
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.InstanceTemplate).To(Equal(newTemplate))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.PendingOperations).To(BeEmpty())
+
+	// A change of the replicas resizes the managed instance group.
+	machinePoolScope.MachinePool.Spec.Replicas = pointer.Int32(5)
+	res, err = s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(igms.patches).To(HaveLen(2))
+	g.Expect(igms.patches[1].InstanceTemplate).To(BeEmpty())
+	g.Expect(igm.TargetSize).To(Equal(int64(5)))
+}
+
+func TestService_ReconcileZonal(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := newMachinePoolScope(t, "us-central1-b")
+	machinePoolScope.GCPMachinePool.Spec.UpdateStrategy = &infrav1exp.MachinePoolUpdateStrategy{
+		Type:           infrav1exp.OpportunisticUpdateType,
+		MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+	}
+	s, _, igms := newTestService(machinePoolScope)
+
+	for i := 0; i < 2; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	zonalKey := *meta.ZonalKey(resourceName("default", "my-pool"), "us-central1-b")
+	g.Expect(igms.igms).To(HaveKey(zonalKey))
+	igm := igms.igms[zonalKey]
+	g.Expect(igm.DistributionPolicy).To(BeNil())
+	g.Expect(igm.UpdatePolicy).To(Equal(&compute.InstanceGroupManagerUpdatePolicy{
+		Type:           "OPPORTUNISTIC",
+		MinimalAction:  "REPLACE",
+		MaxSurge:       &compute.FixedOrPercent{Percent: 25, ForceSendFields: []string{"Percent"}},
+		MaxUnavailable: &compute.FixedOrPercent{Fixed: 0, ForceSendFields: []string{"Fixed"}},
+	}))
+
+	// The update policy is kept in sync with the update strategy.
+	machinePoolScope.GCPMachinePool.Spec.UpdateStrategy.Type = infrav1exp.ProactiveUpdateType
+	_, err := s.Reconcile(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(igms.patches).To(HaveLen(1))
+	g.Expect(igms.patches[0].UpdatePolicy.Type).To(Equal("PROACTIVE"))
+}
+
+func TestService_ReconcileBootstrapData(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := newMachinePoolScope(t)
+	s, templates, igms := newTestService(machinePoolScope)
+	for i := 0; i < 2; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	regionalKey := *meta.RegionalKey(resourceName("default", "my-pool"), "us-central1")
+	igm := igms.igms[regionalKey]
+	igm.Status.IsStable = true
+	template := templateNames(g, templates)[0]
+	igms.instances[regionalKey] = []*compute.ManagedInstance{
+		{
+			Instance:       "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-q9c4",
+			InstanceStatus: "RUNNING",
+			Version:        &compute.ManagedInstanceVersion{InstanceTemplate: igm.InstanceTemplate},
+		},
+	}
+
+	// A change of the bootstrap data only creates a new instance template for the new instances.
+	machinePoolScope.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = pointer.String("my-pool-bootstrap-rotated")
+	for i := 0; i < 2; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(igms.patches).To(HaveLen(1))
+	newTemplate := strings.TrimPrefix(igms.patches[0].InstanceTemplate, "global/instanceTemplates/")
+	g.Expect(newTemplate).NotTo(Equal(template))
+	g.Expect(bootstrapDataChangedOnly(template, newTemplate)).To(BeTrue())
+	g.Expect(igms.patches[0].UpdatePolicy.Type).To(Equal("OPPORTUNISTIC"))
+
+	// The proactive update policy isn't restored while the instances run the previous bootstrap data.
+	for i := 0; i < 2; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(igms.patches).To(HaveLen(1))
+	g.Expect(igm.UpdatePolicy.Type).To(Equal("OPPORTUNISTIC"))
+
+	// A change of the spec rolls the instances again.
+	machinePoolScope.GCPMachinePool.Spec.Template.InstanceType = "n2-standard-4"
+	for i := 0; i < 2; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(igms.patches).To(HaveLen(2))
+	g.Expect(igms.patches[1].UpdatePolicy.Type).To(Equal("PROACTIVE"))
+}
+
+func TestService_ReconcileSharedPrefix(t *testing.T) {
+	g := NewWithT(t)
+
+	// Two machine pools whose names only differ after the length kept in the resource names.
+	first := newMachinePoolScope(t)
+	first.GCPMachinePool.Name = strings.Repeat("a", 50) + "-1"
+	second := newMachinePoolScope(t)
+	second.GCPMachinePool.Name = strings.Repeat("a", 50) + "-2"
+	s1, templates, igms := newTestService(first)
+	s2 := &Service{scope: second, instancetemplates: templates, instancegroupmanagers: igms, operations: s1.operations}
+	for i := 0; i < 3; i++ {
+		for _, s := range []*Service{s1, s2} {
+			_, err := s.Reconcile(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+	}
+	g.Expect(igms.igms).To(HaveLen(2))
+	g.Expect(templateNames(g, templates)).To(HaveLen(2))
+	secondTemplate := second.GCPMachinePool.Status.InstanceTemplate
+	g.Expect(secondTemplate).NotTo(BeEmpty())
+
+	// Rolling the first machine pool onto a new template keeps the template of the second one.
+	first.GCPMachinePool.Spec.Template.InstanceType = "n2-standard-4"
+	for i := 0; i < 4; i++ {
+		_, err := s1.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(templateNames(g, templates)).To(HaveLen(2))
+	g.Expect(templateNames(g, templates)).To(ContainElements(secondTemplate, first.GCPMachinePool.Status.InstanceTemplate))
+
+	// Deleting the first machine pool keeps the managed instance group and the template of the second one.
+	for i := 0; i < 3; i++ {
+		_, err := s1.Delete(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(igms.igms).To(HaveLen(1))
+	g.Expect(templateNames(g, templates)).To(ConsistOf(secondTemplate))
+}
+
+func TestService_Delete(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := newMachinePoolScope(t)
+	s, templates, igms := newTestService(machinePoolScope)
+	for i := 0; i < 3; i++ {
+		_, err := s.Reconcile(context.TODO())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(igms.igms).To(HaveLen(1))
+	g.Expect(templateNames(g, templates)).To(HaveLen(1))
+
+	// The managed instance group is deleted with its instances, then the instance templates.
+	res, err := s.Delete(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(igms.igms).To(BeEmpty())
+	g.Expect(templateNames(g, templates)).To(HaveLen(1))
+	g.Expect(conditions.GetReason(machinePoolScope.GCPMachinePool, infrav1exp.InstanceGroupReadyCondition)).To(Equal(infrav1exp.InstanceGroupDeletingReason))
+
+	res, err = s.Delete(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(operations.PollInterval))
+	g.Expect(templateNames(g, templates)).To(BeEmpty())
+
+	res, err = s.Delete(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(machinePoolScope.GCPMachinePool.Status.PendingOperations).To(BeEmpty())
+}
+
+func TestInstanceTemplateName(t *testing.T) {
+	g := NewWithT(t)
+
+	properties := &compute.InstanceProperties{MachineType: "n1-standard-2", Labels: map[string]string{"b": "2", "a": "1"}}
+	name, err := instanceTemplateName("my-pool", properties, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(MatchRegexp("^my-pool-[0-9a-f]{8}-[0-9a-f]{8}$"))
+
+	again, err := instanceTemplateName("my-pool", &compute.InstanceProperties{MachineType: "n1-standard-2", Labels: map[string]string{"a": "1", "b": "2"}}, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(Equal(name))
+
+	changed, err := instanceTemplateName("my-pool", &compute.InstanceProperties{MachineType: "n1-standard-4"}, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).NotTo(Equal(name))
+	g.Expect(bootstrapDataChangedOnly(name, changed)).To(BeFalse())
+
+	rotated, err := instanceTemplateName("my-pool", properties, "bar")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rotated).NotTo(Equal(name))
+	g.Expect(bootstrapDataChangedOnly(name, rotated)).To(BeTrue())
+
+	long, err := instanceTemplateName(resourceName("default", strings.Repeat("a", 63)), properties, "foo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(long).To(HaveLen(63))
+	g.Expect(instanceTemplatePattern(resourceName("default", strings.Repeat("a", 63))).MatchString(long)).To(BeTrue())
+	g.Expect(instanceTemplatePattern("my-pool").MatchString("my-pool-2-0123abcd-0123abcd")).To(BeFalse())
+}
+
+func TestResourceName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(resourceName("default", "my-pool")).To(MatchRegexp("^my-pool-[0-9a-f]{8}$"))
+	g.Expect(resourceName("default", "my-pool")).To(Equal(resourceName("default", "my-pool")))
+	g.Expect(resourceName("default", "my-pool")).NotTo(Equal(resourceName("other", "my-pool")))
+
+	long := strings.Repeat("a", 50)
+	g.Expect(resourceName("default", long+"-1")).To(HaveLen(maxResourceNameLength))
+	g.Expect(resourceName("default", long+"-1")).NotTo(Equal(resourceName("default", long+"-2")))
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type instancetemplatesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceTemplate, error)
+	List(ctx context.Context, fl *filter.F) ([]*compute.InstanceTemplate, error)
+}
+
+type instancegroupmanagersInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroupManager, error)
+	ListManagedInstances(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error)
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertInstanceTemplate(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) (*compute.Operation, error)
+	DeleteInstanceTemplate(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertInstanceGroupManager(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error)
+	PatchInstanceGroupManager(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error)
+	DeleteInstanceGroupManager(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.OperationTracker
+	Cloud() cloud.Cloud
+	ComputeService() *compute.Service
+	ConditionSetter() conditions.Setter
+	Project() string
+	Region() string
+	Name() string
+	Namespace() string
+	Zones() []string
+	Replicas() int64
+	UpdateStrategy() *infrav1exp.MachinePoolUpdateStrategy
+	InstanceGroupManager() string
+	SetInstanceGroupManager(selfLink string)
+	InstanceTemplate() string
+	SetInstanceTemplate(name string)
+	InstancePropertiesSpec(log logr.Logger) *compute.InstanceProperties
+	GetBootstrapData() (string, error)
+	SetProviderIDList(providerIDs []string)
+	SetReplicas(replicas int32)
+	SetReady()
+}
+
+// Service implements managed instance groups reconciler.
+type Service struct {
+	scope                 Scope
+	instancetemplates     instancetemplatesInterface
+	instancegroupmanagers instancegroupmanagersInterface
+	operations            operationsInterface
+}
+
+var _ cloud.ReconcilerWithResult = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:                 scope,
+		instancetemplates:     scope.Cloud().InstanceTemplates(),
+		instancegroupmanagers: &client{compute: scope.ComputeService(), project: scope.Project()},
+		operations:            operations.NewClient(scope.ComputeService(), scope.Project()),
+	}
+}
+
+// client gets zonal and regional managed instance groups with the GCE API.
+type client struct {
+	compute *compute.Service
+	project string
+}
+
+// Get returns the managed instance group.
+func (c *client) Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroupManager, error) {
+	if key.Type() == meta.Regional {
+		return c.compute.RegionInstanceGroupManagers.Get(c.project, key.Region, key.Name).Context(ctx).Do()
+	}
+	return c.compute.InstanceGroupManagers.Get(c.project, key.Zone, key.Name).Context(ctx).Do()
+}
+
+// ListManagedInstances returns the instances of the managed instance group.
+func (c *client) ListManagedInstances(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error) {
+	var instances []*compute.ManagedInstance
+	if key.Type() == meta.Regional {
+		call := c.compute.RegionInstanceGroupManagers.ListManagedInstances(c.project, key.Region, key.Name)
+		err := call.Pages(ctx, func(page *compute.RegionInstanceGroupManagersListInstancesResponse) error {
+			instances = append(instances, page.ManagedInstances...)
+			return nil
+		})
+		return instances, err
+	}
+
+	call := c.compute.InstanceGroupManagers.ListManagedInstances(c.project, key.Zone, key.Name)
+	err := call.Pages(ctx, func(page *compute.InstanceGroupManagersListManagedInstancesResponse) error {
+		instances = append(instances, page.ManagedInstances...)
+		return nil
+	})
+	return instances, err
+}
@@ -29,16 +29,21 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Reconcile reconcile machine instance.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling instance resources")
 	instance, err := s.createOrGetInstance(ctx)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
+	}
+	if instance == nil {
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
 	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
@@ -81,47 +86,72 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		Address: machineName,
 	})
 
+	s.scope.SetZone(zone)
 	s.scope.SetProviderID()
 	s.scope.SetAddresses(addresses)
 	s.scope.SetInstanceStatus(infrav1.InstanceStatus(instance.Status))
 
 	if s.scope.IsControlPlane() {
-		if err := s.registerControlPlaneInstance(ctx, instance); err != nil {
-			return err
+		registering, err := s.registerControlPlaneInstance(ctx, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if registering {
+			return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 		}
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // Delete delete machine instance.
-func (s *Service) Delete(ctx context.Context) error {
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Deleting instance resources")
 	instanceSpec := s.scope.InstanceSpec(log)
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
-	log.V(2).Info("Looking for instance before deleting", "name", instanceName, "zone", s.scope.Zone())
-	instance, err := s.instances.Get(ctx, instanceKey)
-	if err != nil {
-		if !gcperrors.IsNotFound(err) {
-			log.Error(err, "Error looking for instance before deleting", "name", instanceName)
-			return err
-		}
+	instanceResource := operations.ResourceName(s.scope.Project(), "instances", instanceKey)
+	if s.scope.PendingOperation(instanceResource) == nil {
+		log.V(2).Info("Looking for instance before deleting", "name", instanceName, "zone", s.scope.Zone())
+		instance, err := s.instances.Get(ctx, instanceKey)
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				log.Error(err, "Error looking for instance before deleting", "name", instanceName)
+				return ctrl.Result{}, err
+			}
 
-		return nil
-	}
+			return ctrl.Result{}, nil
+		}
 
-	if s.scope.IsControlPlane() {
-		if err := s.deregisterControlPlaneInstance(ctx, instance); err != nil {
-			return err
+		if s.scope.IsControlPlane() {
+			deregistering, err := s.deregisterControlPlaneInstance(ctx, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if deregistering {
+				return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+			}
 		}
 	}
 
 	log.V(2).Info("Deleting instance", "name", instanceName, "zone", s.scope.Zone())
-	return gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey))
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, instanceResource, func() (*compute.Operation, error) {
+		return s.operations.DeleteInstance(ctx, instanceKey)
+	})
+	if err != nil {
+		log.Error(err, "Error deleting instance", "name", instanceName, "zone", s.scope.Zone())
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
 
+// createOrGetInstance creates an instance if not exist otherwise return the existing.
+// It returns nil while the instance is being created.
 func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
 	log := log.FromContext(ctx)
 	log.V(2).Info("Getting bootstrap data for machine")
@@ -134,6 +164,15 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 	instanceSpec := s.scope.InstanceSpec(log)
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
+	instanceResource := operations.ResourceName(s.scope.Project(), "instances", instanceKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, instanceResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating an instance", "name", instanceName, "zone", s.scope.Zone())
+			err = s.fallBackZone(ctx, err)
+		}
+		return nil, err
+	}
+
 	instanceSpec.Metadata.Items = append(instanceSpec.Metadata.Items, &compute.MetadataItems{
 		Key:   "user-data",
 		Value: pointer.String(bootstrapData),
@@ -148,31 +187,56 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 		}
 
 		log.V(2).Info("Creating an instance", "name", instanceName, "zone", s.scope.Zone())
-		if err := s.instances.Insert(ctx, instanceKey, instanceSpec); err != nil {
+		s.scope.SetZone(instanceSpec.Zone)
+		op, err := s.operations.InsertInstance(ctx, instanceKey, instanceSpec)
+		if err != nil {
 			log.Error(err, "Error creating an instance", "name", instanceName, "zone", s.scope.Zone())
-			return nil, err
+			return nil, s.fallBackZone(ctx, err)
 		}
 
-		instance, err = s.instances.Get(ctx, instanceKey)
-		if err != nil {
-			return nil, err
-		}
+		return nil, s.fallBackZone(ctx, operations.Track(s.scope, instanceResource, op))
 	}
 
 	return instance, nil
 }
 
-func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *compute.Instance) error {
+// fallBackZone moves the instance to another zone when its zone ran out of resources, so that it is created there on
+// the next reconciliation. It returns err if the instance can't move.
+func (s *Service) fallBackZone(ctx context.Context, err error) error {
+	if !gcperrors.IsZoneResourceExhausted(err) {
+		return err
+	}
+
+	zone, ok := s.scope.ZoneExhausted()
+	if !ok {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Zone ran out of resources, falling back to another zone", "name", s.scope.Name(), "zone", zone)
+	return nil
+}
+
+// registerControlPlaneInstance adds the instance to the control-plane instancegroup of its zone.
+// It returns true while the instance is being added.
+func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *compute.Instance) (bool, error) {
 	log := log.FromContext(ctx)
 	instancegroupName := s.scope.ControlPlaneGroupName()
-	log.V(2).Info("Ensuring instance already registered in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 	instancegroupKey := meta.ZonalKey(instancegroupName, s.scope.Zone())
+	instancegroupResource := operations.ResourceName(s.scope.Project(), "instanceGroups", instancegroupKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, instancegroupResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error registering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
+		}
+		return pending, err
+	}
+
+	log.V(2).Info("Ensuring instance already registered in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 	instanceList, err := s.instancegroups.ListInstances(ctx, instancegroupKey, &compute.InstanceGroupsListInstancesRequest{
 		InstanceState: "RUNNING",
 	}, filter.None)
 	if err != nil {
 		log.Error(err, "Error retrieving list of instances in the instancegroup", "instancegroup", instancegroupName)
-		return err
+		return false, err
 	}
 
 	instanceSets := sets.NewString()
@@ -183,30 +247,40 @@ func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *co
 
 	if !instanceSets.Has(instance.SelfLink) && instance.Status == string(infrav1.InstanceStatusRunning) {
 		log.V(2).Info("Registering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
-		if err := s.instancegroups.AddInstances(ctx, instancegroupKey, &compute.InstanceGroupsAddInstancesRequest{
+		op, err := s.operations.AddInstances(ctx, instancegroupKey, &compute.InstanceGroupsAddInstancesRequest{
 			Instances: []*compute.InstanceReference{
 				{
 					Instance: instance.SelfLink,
 				},
 			},
-		}); err != nil {
-			return err
+		})
+		if err != nil {
+			return false, err
 		}
+
+		return op.Status != "DONE", operations.Track(s.scope, instancegroupResource, op)
 	}
 
-	return nil
+	return false, nil
 }
 
-func (s *Service) deregisterControlPlaneInstance(ctx context.Context, instance *compute.Instance) error {
+// deregisterControlPlaneInstance removes the instance from the control-plane instancegroup of its zone.
+// It returns true while the instance is being removed.
+func (s *Service) deregisterControlPlaneInstance(ctx context.Context, instance *compute.Instance) (bool, error) {
 	log := log.FromContext(ctx)
 	instancegroupName := s.scope.ControlPlaneGroupName()
-	log.V(2).Info("Ensuring instance already registered in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 	instancegroupKey := meta.ZonalKey(instancegroupName, s.scope.Zone())
+	instancegroupResource := operations.ResourceName(s.scope.Project(), "instanceGroups", instancegroupKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, instancegroupResource); err != nil || pending {
+		return pending, gcperrors.IgnoreNotFound(err)
+	}
+
+	log.V(2).Info("Ensuring instance already registered in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 	instanceList, err := s.instancegroups.ListInstances(ctx, instancegroupKey, &compute.InstanceGroupsListInstancesRequest{
 		InstanceState: "RUNNING",
 	}, filter.None)
 	if err != nil {
-		return gcperrors.IgnoreNotFound(err)
+		return false, gcperrors.IgnoreNotFound(err)
 	}
 
 	instanceSets := sets.NewString()
@@ -217,16 +291,19 @@ func (s *Service) deregisterControlPlaneInstance(ctx context.Context, instance *
 
 	if len(instanceSets.List()) > 0 && instanceSets.Has(instance.SelfLink) {
 		log.V(2).Info("Deregistering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
-		if err := s.instancegroups.RemoveInstances(ctx, instancegroupKey, &compute.InstanceGroupsRemoveInstancesRequest{
+		op, err := s.operations.RemoveInstances(ctx, instancegroupKey, &compute.InstanceGroupsRemoveInstancesRequest{
 			Instances: []*compute.InstanceReference{
 				{
 					Instance: instance.SelfLink,
 				},
 			},
-		}); err != nil {
-			return gcperrors.IgnoreNotFound(err)
+		})
+		if err != nil {
+			return false, gcperrors.IgnoreNotFound(err)
 		}
+
+		return op.Status != "DONE", gcperrors.IgnoreNotFound(operations.Track(s.scope, instancegroupResource, op))
 	}
 
-	return nil
+	return false, nil
 }
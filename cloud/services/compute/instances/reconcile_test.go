@@ -33,6 +33,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/stockout"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -42,6 +43,39 @@ func init() {
 	_ = infrav1.AddToScheme(scheme.Scheme)
 }
 
+// fakeOperations starts the instance operations on the mock, reporting them as running until they are polled.
+type fakeOperations struct {
+	instances *cloud.MockInstances
+}
+
+func (f *fakeOperations) Get(_ context.Context, selfLink string) (*compute.Operation, error) {
+	return &compute.Operation{SelfLink: selfLink, Status: "DONE"}, nil
+}
+
+func (f *fakeOperations) InsertInstance(ctx context.Context, key *meta.Key, obj *compute.Instance) (*compute.Operation, error) {
+	if err := f.instances.Insert(ctx, key, obj); err != nil {
+		return nil, err
+	}
+
+	return &compute.Operation{SelfLink: "operations/insert-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) DeleteInstance(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	if err := f.instances.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &compute.Operation{SelfLink: "operations/delete-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) AddInstances(_ context.Context, key *meta.Key, _ *compute.InstanceGroupsAddInstancesRequest) (*compute.Operation, error) {
+	return &compute.Operation{SelfLink: "operations/add-instances-" + key.Name, Status: "DONE"}, nil
+}
+
+func (f *fakeOperations) RemoveInstances(_ context.Context, key *meta.Key, _ *compute.InstanceGroupsRemoveInstancesRequest) (*compute.Operation, error) {
+	return &compute.Operation{SelfLink: "operations/remove-instances-" + key.Name, Status: "DONE"}, nil
+}
+
 var fakeBootstrapSecret = &corev1.Secret{
 	ObjectMeta: metav1.ObjectMeta{
 		Name:      "my-cluster-bootstrap",
@@ -175,7 +209,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 	machineScopeWithoutFailureDomain, err := scope.NewMachineScope(scope.MachineScopeParams{
 		Client:        fakec,
 		Machine:       fakeMachineWithOutFailureDomain,
-		GCPMachine:    fakeGCPMachine,
+		GCPMachine:    getFakeGCPMachine(),
 		ClusterGetter: clusterScopeWithoutFailureDomain,
 	})
 	if err != nil {
@@ -590,7 +624,12 @@ func TestService_createOrGetInstance(t *testing.T) {
 			ctx := context.TODO()
 			s := New(tt.scope())
 			s.instances = tt.mockInstance
+			s.operations = &fakeOperations{instances: tt.mockInstance}
 			got, err := s.createOrGetInstance(ctx)
+			if got == nil && err == nil {
+				// The instance is being created, it is returned once the operation is done.
+				got, err = s.createOrGetInstance(ctx)
+			}
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.createOrGetInstance() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -602,3 +641,120 @@ func TestService_createOrGetInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestService_createOrGetInstanceZoneFallback(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterWithOutFailureDomain,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stockoutErr := &googleapi.Error{
+		Code:   http.StatusServiceUnavailable,
+		Errors: []googleapi.ErrorItem{{Reason: "ZONE_RESOURCE_POOL_EXHAUSTED"}},
+	}
+
+	tests := []struct {
+		name            string
+		machine         *clusterv1.Machine
+		exhaustedZones  []string
+		wantErr         bool
+		wantZone        string
+		wantStatusZone  *string
+		wantInsertZones []string
+	}{
+		{
+			name:            "zone ran out of resources (should fall back to the next zone)",
+			machine:         fakeMachineWithOutFailureDomain,
+			exhaustedZones:  []string{"us-central1-a"},
+			wantZone:        "us-central1-b",
+			wantStatusZone:  pointer.String("us-central1-b"),
+			wantInsertZones: []string{"us-central1-a", "us-central1-b"},
+		},
+		{
+			name:            "all zones ran out of resources (should return an error)",
+			machine:         fakeMachineWithOutFailureDomain,
+			exhaustedZones:  []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+			wantErr:         true,
+			wantInsertZones: []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+		},
+		{
+			name:            "failure domain ran out of resources (should return an error)",
+			machine:         fakeMachine,
+			exhaustedZones:  []string{"us-central1-c"},
+			wantErr:         true,
+			wantStatusZone:  pointer.String("us-central1-c"),
+			wantInsertZones: []string{"us-central1-c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			cache := stockout.NewCache(stockout.DefaultCooldown)
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.InstanceType = "n1-standard-2"
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:         fakec,
+				Machine:        tt.machine,
+				GCPMachine:     gcpMachine,
+				ClusterGetter:  clusterScope,
+				ExhaustedZones: cache,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var insertZones []string
+			mockInstance := &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+				InsertHook: func(_ context.Context, key *meta.Key, _ *compute.Instance, _ *cloud.MockInstances) (bool, error) {
+					insertZones = append(insertZones, key.Zone)
+					for _, zone := range tt.exhaustedZones {
+						if key.Zone == zone {
+							return true, stockoutErr
+						}
+					}
+					return false, nil
+				},
+			}
+			s := New(machineScope)
+			s.instances = mockInstance
+			s.operations = &fakeOperations{instances: mockInstance}
+
+			var got *compute.Instance
+			for i := 0; i < 5 && got == nil && err == nil; i++ {
+				got, err = s.createOrGetInstance(ctx)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.createOrGetInstance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantZone != "" && (got == nil || got.Zone != tt.wantZone) {
+				t.Errorf("Service.createOrGetInstance() instance = %v, want instance in zone %s", got, tt.wantZone)
+			}
+			if d := cmp.Diff(tt.wantStatusZone, gcpMachine.Status.Zone); d != "" {
+				t.Errorf("GCPMachine.Status.Zone mismatch (-want +got):\n%s", d)
+			}
+			if d := cmp.Diff(tt.wantInsertZones, insertZones); d != "" {
+				t.Errorf("inserted zones mismatch (-want +got):\n%s", d)
+			}
+			for _, zone := range tt.exhaustedZones {
+				if !cache.IsExhausted("n1-standard-2", zone) {
+					t.Errorf("zone %s is not marked as exhausted", zone)
+				}
+			}
+		})
+	}
+}
@@ -25,18 +25,23 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
 )
 
 type instancesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Instance, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Instance) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type instancegroupsInterface interface {
-	AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) error
 	ListInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsListInstancesRequest, fl *filter.F) ([]*compute.InstanceWithNamedPorts, error)
-	RemoveInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) error
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertInstance(ctx context.Context, key *meta.Key, obj *compute.Instance) (*compute.Operation, error)
+	DeleteInstance(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) (*compute.Operation, error)
+	RemoveInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) (*compute.Operation, error)
 }
 
 // Scope is an interfaces that hold used methods.
@@ -45,6 +50,8 @@ type Scope interface {
 	InstanceSpec(log logr.Logger) *compute.Instance
 	InstanceImageSpec() *compute.AttachedDisk
 	InstanceAdditionalDiskSpec() []*compute.AttachedDisk
+	SetZone(zone string)
+	ZoneExhausted() (string, bool)
 }
 
 // Service implements instances reconciler.
@@ -52,9 +59,10 @@ type Service struct {
 	scope          Scope
 	instances      instancesInterface
 	instancegroups instancegroupsInterface
+	operations     operationsInterface
 }
 
-var _ cloud.Reconciler = &Service{}
+var _ cloud.ReconcilerWithResult = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
@@ -62,5 +70,6 @@ func New(scope Scope) *Service {
 		scope:          scope,
 		instances:      scope.Cloud().Instances(),
 		instancegroups: scope.Cloud().InstanceGroups(),
+		operations:     operations.NewClient(scope.ComputeService(), scope.Project()),
 	}
 }
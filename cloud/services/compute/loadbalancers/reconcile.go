@@ -23,68 +23,88 @@ import (
 	"google.golang.org/api/compute/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Reconcile reconcile cluster control-plane loadbalancer compoenents.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling loadbalancer resources")
 	instancegroups, err := s.createOrGetInstanceGroups(ctx)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
+	}
+	if len(instancegroups) != len(s.scope.FailureDomains()) {
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
 	healthcheck, err := s.createOrGetHealthCheck(ctx)
-	if err != nil {
-		return err
+	if err != nil || healthcheck == nil {
+		return requeueIfPending(err)
 	}
 
 	backendsvc, err := s.createOrGetBackendService(ctx, instancegroups, healthcheck)
-	if err != nil {
-		return err
+	if err != nil || backendsvc == nil {
+		return requeueIfPending(err)
 	}
 
 	target, err := s.createOrGetTargetTCPProxy(ctx, backendsvc)
-	if err != nil {
-		return err
+	if err != nil || target == nil {
+		return requeueIfPending(err)
 	}
 
 	addr, err := s.createOrGetAddress(ctx)
-	if err != nil {
-		return err
+	if err != nil || addr == nil {
+		return requeueIfPending(err)
 	}
 
-	return s.createForwardingRule(ctx, target, addr)
+	forwarding, err := s.createOrGetForwardingRule(ctx, target, addr)
+	if err != nil || forwarding == nil {
+		return requeueIfPending(err)
+	}
+
+	return ctrl.Result{}, nil
 }
 
 // Delete delete cluster control-plane loadbalancer compoenents.
-func (s *Service) Delete(ctx context.Context) error {
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Deleting loadbalancer resources")
-	if err := s.deleteForwardingRule(ctx); err != nil {
-		return err
-	}
-
-	if err := s.deleteAddress(ctx); err != nil {
-		return err
+	// Each resource is in use by the previous one, so they are deleted one after the other.
+	steps := []func(context.Context) (bool, error){
+		s.deleteForwardingRule,
+		s.deleteAddress,
+		s.deleteTargetTCPProxy,
+		s.deleteBackendService,
+		s.deleteHealthCheck,
+		s.deleteInstanceGroups,
 	}
-
-	if err := s.deleteTargetTCPProxy(ctx); err != nil {
-		return err
+	for _, step := range steps {
+		deleting, err := step(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if deleting {
+			return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+		}
 	}
 
-	if err := s.deleteBackendService(ctx); err != nil {
-		return err
-	}
+	return ctrl.Result{}, nil
+}
 
-	if err := s.deleteHealthCheck(ctx); err != nil {
-		return err
+// requeueIfPending returns the error if any, otherwise a requeue to wait for the operation in progress.
+func requeueIfPending(err error) (ctrl.Result, error) {
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	return s.deleteInstanceGroups(ctx)
+	return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 }
 
+// createOrGetInstanceGroups creates an instancegroup in each failure domain if not exist otherwise return the
+// existing. The instancegroups being created are left out.
 func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.InstanceGroup, error) {
 	log := log.FromContext(ctx)
 	fd := s.scope.FailureDomains()
@@ -101,8 +121,19 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 
 	for _, zone := range zones {
 		instancegroupSpec := s.scope.InstanceGroupSpec(zone)
+		instancegroupKey := meta.ZonalKey(instancegroupSpec.Name, zone)
+		instancegroupResource := operations.ResourceName(s.scope.Project(), "instanceGroups", instancegroupKey)
+		pending, err := operations.Check(ctx, s.operations, s.scope, instancegroupResource)
+		if err != nil {
+			log.Error(err, "Error creating instancegroup", "name", instancegroupSpec.Name)
+			return groups, err
+		}
+		if pending {
+			continue
+		}
+
 		log.V(2).Info("Looking for instancegroup in zone", "zone", zone, "name", instancegroupSpec.Name)
-		instancegroup, err := s.instancegroups.Get(ctx, meta.ZonalKey(instancegroupSpec.Name, zone))
+		instancegroup, err := s.instancegroups.Get(ctx, instancegroupKey)
 		if err != nil {
 			if !gcperrors.IsNotFound(err) {
 				log.Error(err, "Error looking for instancegroup in zone", "zone", zone)
@@ -110,15 +141,16 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 			}
 
 			log.V(2).Info("Creating instancegroup in zone", "zone", zone, "name", instancegroupSpec.Name)
-			if err := s.instancegroups.Insert(ctx, meta.ZonalKey(instancegroupSpec.Name, zone), instancegroupSpec); err != nil {
+			op, err := s.operations.InsertInstanceGroup(ctx, instancegroupKey, instancegroupSpec)
+			if err != nil {
 				log.Error(err, "Error creating instancegroup", "name", instancegroupSpec.Name)
 				return groups, err
 			}
-
-			instancegroup, err = s.instancegroups.Get(ctx, meta.ZonalKey(instancegroupSpec.Name, zone))
-			if err != nil {
+			if err := operations.Track(s.scope, instancegroupResource, op); err != nil {
 				return groups, err
 			}
+
+			continue
 		}
 
 		groups = append(groups, instancegroup)
@@ -129,11 +161,22 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 	return groups, nil
 }
 
+// createOrGetHealthCheck creates a healthcheck if not exist otherwise return the existing.
+// It returns nil while the healthcheck is being created.
 func (s *Service) createOrGetHealthCheck(ctx context.Context) (*compute.HealthCheck, error) {
 	log := log.FromContext(ctx)
 	healthcheckSpec := s.scope.HealthCheckSpec()
+	healthcheckKey := meta.GlobalKey(healthcheckSpec.Name)
+	healthcheckResource := operations.ResourceName(s.scope.Project(), "healthChecks", healthcheckKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, healthcheckResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a healthcheck", "name", healthcheckSpec.Name)
+		}
+		return nil, err
+	}
+
 	log.V(2).Info("Looking for healthcheck", "name", healthcheckSpec.Name)
-	healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(healthcheckSpec.Name))
+	healthcheck, err := s.healthchecks.Get(ctx, healthcheckKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for healthcheck", "name", healthcheckSpec.Name)
@@ -141,21 +184,21 @@ func (s *Service) createOrGetHealthCheck(ctx context.Context) (*compute.HealthCh
 		}
 
 		log.V(2).Info("Creating a healthcheck", "name", healthcheckSpec.Name)
-		if err := s.healthchecks.Insert(ctx, meta.GlobalKey(healthcheckSpec.Name), healthcheckSpec); err != nil {
+		op, err := s.operations.InsertHealthCheck(ctx, healthcheckKey, healthcheckSpec)
+		if err != nil {
 			log.Error(err, "Error creating a healthcheck", "name", healthcheckSpec.Name)
 			return nil, err
 		}
 
-		healthcheck, err = s.healthchecks.Get(ctx, meta.GlobalKey(healthcheckSpec.Name))
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, healthcheckResource, op)
 	}
 
 	s.scope.Network().APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
 	return healthcheck, nil
 }
 
+// createOrGetBackendService creates a backendservice if not exist otherwise return the existing, updating its
+// backends if needed. It returns nil while the backendservice is being created or updated.
 func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups []*compute.InstanceGroup, healthcheck *compute.HealthCheck) (*compute.BackendService, error) {
 	log := log.FromContext(ctx)
 	backends := make([]*compute.Backend, 0, len(instancegroups))
@@ -169,7 +212,16 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 	backendsvcSpec := s.scope.BackendServiceSpec()
 	backendsvcSpec.Backends = backends
 	backendsvcSpec.HealthChecks = []string{healthcheck.SelfLink}
-	backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(backendsvcSpec.Name))
+	backendsvcKey := meta.GlobalKey(backendsvcSpec.Name)
+	backendsvcResource := operations.ResourceName(s.scope.Project(), "backendServices", backendsvcKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, backendsvcResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a backendservice", "name", backendsvcSpec.Name)
+		}
+		return nil, err
+	}
+
+	backendsvc, err := s.backendservices.Get(ctx, backendsvcKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for backendservice", "name", backendsvcSpec.Name)
@@ -177,35 +229,47 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 		}
 
 		log.V(2).Info("Creating a backendservice", "name", backendsvcSpec.Name)
-		if err := s.backendservices.Insert(ctx, meta.GlobalKey(backendsvcSpec.Name), backendsvcSpec); err != nil {
+		op, err := s.operations.InsertBackendService(ctx, backendsvcKey, backendsvcSpec)
+		if err != nil {
 			log.Error(err, "Error creating a backendservice", "name", backendsvcSpec.Name)
 			return nil, err
 		}
 
-		backendsvc, err = s.backendservices.Get(ctx, meta.GlobalKey(backendsvcSpec.Name))
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, backendsvcResource, op)
 	}
 
 	if len(backendsvc.Backends) != len(backendsvcSpec.Backends) {
 		log.V(2).Info("Updating a backendservice", "name", backendsvcSpec.Name)
 		backendsvc.Backends = backendsvcSpec.Backends
-		if err := s.backendservices.Update(ctx, meta.GlobalKey(backendsvcSpec.Name), backendsvc); err != nil {
+		op, err := s.operations.UpdateBackendService(ctx, backendsvcKey, backendsvc)
+		if err != nil {
 			log.Error(err, "Error updating a backendservice", "name", backendsvcSpec.Name)
 			return nil, err
 		}
+
+		return nil, operations.Track(s.scope, backendsvcResource, op)
 	}
 
 	s.scope.Network().APIServerBackendService = pointer.String(backendsvc.SelfLink)
 	return backendsvc, nil
 }
 
+// createOrGetTargetTCPProxy creates a targettcpproxy if not exist otherwise return the existing.
+// It returns nil while the targettcpproxy is being created.
 func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *compute.BackendService) (*compute.TargetTcpProxy, error) {
 	log := log.FromContext(ctx)
 	targetSpec := s.scope.TargetTCPProxySpec()
 	targetSpec.Service = service.SelfLink
-	target, err := s.targettcpproxies.Get(ctx, meta.GlobalKey(targetSpec.Name))
+	targetKey := meta.GlobalKey(targetSpec.Name)
+	targetResource := operations.ResourceName(s.scope.Project(), "targetTcpProxies", targetKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, targetResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a targettcpproxy", "name", targetSpec.Name)
+		}
+		return nil, err
+	}
+
+	target, err := s.targettcpproxies.Get(ctx, targetKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for targettcpproxy", "name", targetSpec.Name)
@@ -213,26 +277,35 @@ func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *comput
 		}
 
 		log.V(2).Info("Creating a targettcpproxy", "name", targetSpec.Name)
-		if err := s.targettcpproxies.Insert(ctx, meta.GlobalKey(targetSpec.Name), targetSpec); err != nil {
+		op, err := s.operations.InsertTargetTCPProxy(ctx, targetKey, targetSpec)
+		if err != nil {
 			log.Error(err, "Error creating a targettcpproxy", "name", targetSpec.Name)
 			return nil, err
 		}
 
-		target, err = s.targettcpproxies.Get(ctx, meta.GlobalKey(targetSpec.Name))
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, targetResource, op)
 	}
 
 	s.scope.Network().APIServerTargetProxy = pointer.String(target.SelfLink)
 	return target, nil
 }
 
+// createOrGetAddress creates an address if not exist otherwise return the existing.
+// It returns nil while the address is being created.
 func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, error) {
 	log := log.FromContext(ctx)
 	addrSpec := s.scope.AddressSpec()
+	addrKey := meta.GlobalKey(addrSpec.Name)
+	addrResource := operations.ResourceName(s.scope.Project(), "addresses", addrKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, addrResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating an address", "name", addrSpec.Name)
+		}
+		return nil, err
+	}
+
 	log.V(2).Info("Looking for address", "name", addrSpec.Name)
-	addr, err := s.addresses.Get(ctx, meta.GlobalKey(addrSpec.Name))
+	addr, err := s.addresses.Get(ctx, addrKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for address", "name", addrSpec.Name)
@@ -240,15 +313,13 @@ func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, err
 		}
 
 		log.V(2).Info("Creating an address", "name", addrSpec.Name)
-		if err := s.addresses.Insert(ctx, meta.GlobalKey(addrSpec.Name), addrSpec); err != nil {
+		op, err := s.operations.InsertGlobalAddress(ctx, addrKey, addrSpec)
+		if err != nil {
 			log.Error(err, "Error creating an address", "name", addrSpec.Name)
 			return nil, err
 		}
 
-		addr, err = s.addresses.Get(ctx, meta.GlobalKey(addrSpec.Name))
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, addrResource, op)
 	}
 
 	s.scope.Network().APIServerAddress = pointer.String(addr.SelfLink)
@@ -258,120 +329,157 @@ func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, err
 	return addr, nil
 }
 
-func (s *Service) createForwardingRule(ctx context.Context, target *compute.TargetTcpProxy, addr *compute.Address) error {
+// createOrGetForwardingRule creates a forwardingrule if not exist otherwise return the existing.
+// It returns nil while the forwardingrule is being created.
+func (s *Service) createOrGetForwardingRule(ctx context.Context, target *compute.TargetTcpProxy, addr *compute.Address) (*compute.ForwardingRule, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.ForwardingRuleSpec()
 	key := meta.GlobalKey(spec.Name)
+	resource := operations.ResourceName(s.scope.Project(), "forwardingRules", key)
 	spec.IPAddress = addr.SelfLink
 	spec.Target = target.SelfLink
+	if pending, err := operations.Check(ctx, s.operations, s.scope, resource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a forwardingrule", "name", spec.Name)
+		}
+		return nil, err
+	}
+
 	log.V(2).Info("Looking for forwardingrule", "name", spec.Name)
 	forwarding, err := s.forwardingrules.Get(ctx, key)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for forwardingrule", "name", spec.Name)
-			return err
+			return nil, err
 		}
 
 		log.V(2).Info("Creating a forwardingrule", "name", spec.Name)
-		if err := s.forwardingrules.Insert(ctx, key, spec); err != nil {
+		op, err := s.operations.InsertGlobalForwardingRule(ctx, key, spec)
+		if err != nil {
 			log.Error(err, "Error creating a forwardingrule", "name", spec.Name)
-			return err
+			return nil, err
 		}
 
-		forwarding, err = s.forwardingrules.Get(ctx, key)
-		if err != nil {
-			return err
-		}
+		return nil, operations.Track(s.scope, resource, op)
 	}
 
 	s.scope.Network().APIServerForwardingRule = pointer.String(forwarding.SelfLink)
-	return nil
+	return forwarding, nil
 }
 
-func (s *Service) deleteForwardingRule(ctx context.Context) error {
+func (s *Service) deleteForwardingRule(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.ForwardingRuleSpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a forwardingrule", "name", spec.Name)
-	if err := s.forwardingrules.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "forwardingRules", key), func() (*compute.Operation, error) {
+		return s.operations.DeleteGlobalForwardingRule(ctx, key)
+	})
+	if err != nil {
 		log.Error(err, "Error updating a forwardingrule", "name", spec.Name)
-		return err
+		return false, err
+	}
+	if !deleting {
+		s.scope.Network().APIServerForwardingRule = nil
 	}
 
-	s.scope.Network().APIServerForwardingRule = nil
-	return nil
+	return deleting, nil
 }
 
-func (s *Service) deleteAddress(ctx context.Context) error {
+func (s *Service) deleteAddress(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.AddressSpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a address", "name", spec.Name)
-	if err := s.addresses.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
-		return err
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "addresses", key), func() (*compute.Operation, error) {
+		return s.operations.DeleteGlobalAddress(ctx, key)
+	})
+	if err != nil {
+		return false, err
+	}
+	if !deleting {
+		s.scope.Network().APIServerAddress = nil
 	}
 
-	s.scope.Network().APIServerAddress = nil
-	return nil
+	return deleting, nil
 }
 
-func (s *Service) deleteTargetTCPProxy(ctx context.Context) error {
+func (s *Service) deleteTargetTCPProxy(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.TargetTCPProxySpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a targettcpproxy", "name", spec.Name)
-	if err := s.targettcpproxies.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "targetTcpProxies", key), func() (*compute.Operation, error) {
+		return s.operations.DeleteTargetTCPProxy(ctx, key)
+	})
+	if err != nil {
 		log.Error(err, "Error deleting a targettcpproxy", "name", spec.Name)
-		return err
+		return false, err
+	}
+	if !deleting {
+		s.scope.Network().APIServerTargetProxy = nil
 	}
 
-	s.scope.Network().APIServerTargetProxy = nil
-	return nil
+	return deleting, nil
 }
 
-func (s *Service) deleteBackendService(ctx context.Context) error {
+func (s *Service) deleteBackendService(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.BackendServiceSpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a backendservice", "name", spec.Name)
-	if err := s.backendservices.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "backendServices", key), func() (*compute.Operation, error) {
+		return s.operations.DeleteBackendService(ctx, key)
+	})
+	if err != nil {
 		log.Error(err, "Error deleting a backendservice", "name", spec.Name)
-		return err
+		return false, err
+	}
+	if !deleting {
+		s.scope.Network().APIServerBackendService = nil
 	}
 
-	s.scope.Network().APIServerBackendService = nil
-	return nil
+	return deleting, nil
 }
 
-func (s *Service) deleteHealthCheck(ctx context.Context) error {
+func (s *Service) deleteHealthCheck(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.HealthCheckSpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a healthcheck", "name", spec.Name)
-	if err := s.healthchecks.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "healthChecks", key), func() (*compute.Operation, error) {
+		return s.operations.DeleteHealthCheck(ctx, key)
+	})
+	if err != nil {
 		log.Error(err, "Error deleting a healthcheck", "name", spec.Name)
-		return err
+		return false, err
+	}
+	if !deleting {
+		s.scope.Network().APIServerHealthCheck = nil
 	}
 
-	s.scope.Network().APIServerHealthCheck = nil
-	return nil
+	return deleting, nil
 }
 
-func (s *Service) deleteInstanceGroups(ctx context.Context) error {
+func (s *Service) deleteInstanceGroups(ctx context.Context) (bool, error) {
 	log := log.FromContext(ctx)
+	deleting := false
 	for zone := range s.scope.Network().APIServerInstanceGroups {
 		spec := s.scope.InstanceGroupSpec(zone)
 		key := meta.ZonalKey(spec.Name, zone)
 		log.V(2).Info("Deleting a instancegroup", "name", spec.Name)
-		if err := s.instancegroups.Delete(ctx, key); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
-				return err
-			}
-
+		groupDeleting, err := operations.Delete(ctx, s.operations, s.scope, operations.ResourceName(s.scope.Project(), "instanceGroups", key), func() (*compute.Operation, error) {
+			return s.operations.DeleteInstanceGroup(ctx, key)
+		})
+		if err != nil {
+			log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
+			return false, err
+		}
+		if !groupDeleting {
 			delete(s.scope.Network().APIServerInstanceGroups, zone)
 		}
+		deleting = deleting || groupDeleting
 	}
 
-	return nil
+	return deleting, nil
 }
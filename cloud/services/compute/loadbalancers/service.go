@@ -23,44 +23,49 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
 )
 
 type addressesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Address, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Address) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type backendservicesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.BackendService, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.BackendService) error
-	Update(context.Context, *meta.Key, *compute.BackendService) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type forwardingrulesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.ForwardingRule, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type healthchecksInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.HealthCheck, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type instancegroupsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroup, error)
 	List(ctx context.Context, zone string, fl *filter.F) ([]*compute.InstanceGroup, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroup) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type targettcpproxiesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.TargetTcpProxy, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.TargetTcpProxy) error
-	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertGlobalAddress(ctx context.Context, key *meta.Key, obj *compute.Address) (*compute.Operation, error)
+	DeleteGlobalAddress(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertBackendService(ctx context.Context, key *meta.Key, obj *compute.BackendService) (*compute.Operation, error)
+	UpdateBackendService(ctx context.Context, key *meta.Key, obj *compute.BackendService) (*compute.Operation, error)
+	DeleteBackendService(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertGlobalForwardingRule(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule) (*compute.Operation, error)
+	DeleteGlobalForwardingRule(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertHealthCheck(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) (*compute.Operation, error)
+	DeleteHealthCheck(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertInstanceGroup(ctx context.Context, key *meta.Key, obj *compute.InstanceGroup) (*compute.Operation, error)
+	DeleteInstanceGroup(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertTargetTCPProxy(ctx context.Context, key *meta.Key, obj *compute.TargetTcpProxy) (*compute.Operation, error)
+	DeleteTargetTCPProxy(ctx context.Context, key *meta.Key) (*compute.Operation, error)
 }
 
 // Scope is an interfaces that hold used methods.
@@ -83,9 +88,10 @@ type Service struct {
 	healthchecks     healthchecksInterface
 	instancegroups   instancegroupsInterface
 	targettcpproxies targettcpproxiesInterface
+	operations       operationsInterface
 }
 
-var _ cloud.Reconciler = &Service{}
+var _ cloud.ReconcilerWithResult = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
@@ -97,5 +103,6 @@ func New(scope Scope) *Service {
 		healthchecks:     scope.Cloud().HealthChecks(),
 		instancegroups:   scope.Cloud().InstanceGroups(),
 		targettcpproxies: scope.Cloud().TargetTcpProxies(),
+		operations:       operations.NewClient(scope.ComputeService(), scope.Project()),
 	}
 }
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetypes
+
+import (
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/clock"
+)
+
+// DefaultTTL is the period during which a machine type is served from the cache.
+const DefaultTTL = time.Hour
+
+type key struct {
+	zone        string
+	machineType string
+}
+
+// Cache remembers the machine types per zone, so that templates with the same instance type don't look it up again.
+// It is safe for concurrent use.
+type Cache struct {
+	ttl     time.Duration
+	entries *cache.Expiring
+}
+
+// NewCache returns an empty Cache whose entries expire after the ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return newCache(ttl, clock.RealClock{})
+}
+
+func newCache(ttl time.Duration, clock clock.Clock) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: cache.NewExpiringWithClock(clock),
+	}
+}
+
+// Get returns the machine type in the zone, if it is in the cache and has not expired.
+func (c *Cache) Get(zone, machineType string) (*compute.MachineType, bool) {
+	v, ok := c.entries.Get(key{zone: zone, machineType: machineType})
+	if !ok {
+		return nil, false
+	}
+
+	return v.(*compute.MachineType), true
+}
+
+// Add records the machine type in the zone.
+func (c *Cache) Add(zone string, machineType *compute.MachineType) {
+	c.entries.Set(key{zone: zone, machineType: machineType.Name}, machineType, c.ttl)
+}
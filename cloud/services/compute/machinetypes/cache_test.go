@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetypes
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestCache(t *testing.T) {
+	g := NewWithT(t)
+
+	clock := testingclock.NewFakeClock(time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC))
+	c := newCache(time.Hour, clock)
+
+	_, ok := c.Get("us-central1-a", "n1-standard-2")
+	g.Expect(ok).To(BeFalse())
+
+	c.Add("us-central1-a", &compute.MachineType{Name: "n1-standard-2", GuestCpus: 2})
+	mt, ok := c.Get("us-central1-a", "n1-standard-2")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(mt.GuestCpus).To(Equal(int64(2)))
+	_, ok = c.Get("us-central1-b", "n1-standard-2")
+	g.Expect(ok).To(BeFalse())
+
+	clock.Step(time.Hour)
+	_, ok = c.Get("us-central1-a", "n1-standard-2")
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetypes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ResourceNvidiaGPU is the extended resource of the NVIDIA GPUs, as advertised by the NVIDIA device plugin.
+	ResourceNvidiaGPU corev1.ResourceName = "nvidia.com/gpu"
+
+	// defaultRootDeviceSize is the size of the root volume in GB, when the GCPMachine doesn't specify it.
+	defaultRootDeviceSize = 30
+	// localSsdSize is the size of a local SSD in GB.
+	localSsdSize = 375
+	gib          = 1024 * 1024 * 1024
+)
+
+// armMachineFamilies are the machine families with Arm CPUs.
+var armMachineFamilies = []string{"t2a", "c4a"}
+
+// Reconcile looks up the instance type of the machine template and reports the resources of its machines, so that
+// the cluster autoscaler can scale node groups from zero.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	zone := s.scope.Zone()
+	if zone == "" {
+		log.V(4).Info("Cluster has no failure domains yet, skipping capacity")
+		return nil
+	}
+
+	spec := s.scope.MachineSpec()
+	machineType, err := s.getMachineType(ctx, zone, spec.InstanceType)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get machine type %s in zone %s", spec.InstanceType, zone)
+	}
+
+	s.scope.SetCapacity(Capacity(machineType, spec))
+	s.scope.SetNodeInfo(&infrav1.NodeInfo{
+		Architecture:    architecture(machineType.Name),
+		OperatingSystem: "linux",
+	})
+	return nil
+}
+
+// Delete does nothing, the capacity is only reported in the status.
+func (s *Service) Delete(_ context.Context) error {
+	return nil
+}
+
+// getMachineType returns the machine type in the zone, from the cache if it has it.
+func (s *Service) getMachineType(ctx context.Context, zone, name string) (*compute.MachineType, error) {
+	if s.cache != nil {
+		if machineType, ok := s.cache.Get(zone, name); ok {
+			return machineType, nil
+		}
+	}
+
+	log.FromContext(ctx).V(2).Info("Looking up machine type", "zone", zone, "name", name)
+	machineType, err := s.machinetypes.Get(ctx, s.scope.Project(), zone, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.cache != nil {
+		s.cache.Add(zone, machineType)
+	}
+	return machineType, nil
+}
+
+// Capacity returns the resources of the machines of the machine type created with the spec: the CPUs and memory
+// of the machine type, its NVIDIA GPUs, and the root volume and local SSDs as ephemeral storage.
+func Capacity(machineType *compute.MachineType, spec *infrav1.GCPMachineSpec) corev1.ResourceList {
+	capacity := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewQuantity(machineType.GuestCpus, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(machineType.MemoryMb*1024*1024, resource.BinarySI),
+	}
+
+	var gpus int64
+	for _, accelerator := range machineType.Accelerators {
+		if strings.HasPrefix(accelerator.GuestAcceleratorType, "nvidia-") {
+			gpus += accelerator.GuestAcceleratorCount
+		}
+	}
+	if gpus > 0 {
+		capacity[ResourceNvidiaGPU] = *resource.NewQuantity(gpus, resource.DecimalSI)
+	}
+
+	storage := spec.RootDeviceSize
+	if storage == 0 {
+		storage = defaultRootDeviceSize
+	}
+	for _, disk := range spec.AdditionalDisks {
+		if disk.DeviceType != nil && *disk.DeviceType == infrav1.LocalSsdDiskType {
+			storage += localSsdSize
+		}
+	}
+	// Machine types such as c3-standard-4-lssd come with local SSDs.
+	for _, disk := range machineType.ScratchDisks {
+		storage += disk.DiskGb
+	}
+	capacity[corev1.ResourceEphemeralStorage] = *resource.NewQuantity(storage*gib, resource.BinarySI)
+
+	return capacity
+}
+
+// architecture returns the CPU architecture of the machine type, which depends on its machine family.
+func architecture(machineType string) infrav1.Architecture {
+	family, _, _ := strings.Cut(machineType, "-")
+	for _, arm := range armMachineFamilies {
+		if family == arm {
+			return infrav1.ArchitectureArm64
+		}
+	}
+	return infrav1.ArchitectureAmd64
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetypes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+type fakeScope struct {
+	zone     string
+	spec     infrav1.GCPMachineSpec
+	capacity corev1.ResourceList
+	nodeInfo *infrav1.NodeInfo
+}
+
+func (f *fakeScope) ComputeService() *compute.Service { return nil }
+
+func (f *fakeScope) Project() string { return "my-project" }
+
+func (f *fakeScope) Zone() string { return f.zone }
+
+func (f *fakeScope) MachineSpec() *infrav1.GCPMachineSpec { return &f.spec }
+
+func (f *fakeScope) SetCapacity(capacity corev1.ResourceList) { f.capacity = capacity }
+
+func (f *fakeScope) SetNodeInfo(nodeInfo *infrav1.NodeInfo) { f.nodeInfo = nodeInfo }
+
+// fakeMachineTypes answers with the machine types it has, per zone.
+type fakeMachineTypes struct {
+	machineTypes map[string][]*compute.MachineType
+	calls        int
+}
+
+func (f *fakeMachineTypes) Get(_ context.Context, _, zone, name string) (*compute.MachineType, error) {
+	f.calls++
+	for _, mt := range f.machineTypes[zone] {
+		if mt.Name == name {
+			return mt, nil
+		}
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func newFakeMachineTypes() *fakeMachineTypes {
+	return &fakeMachineTypes{
+		machineTypes: map[string][]*compute.MachineType{
+			"us-central1-a": {
+				{Name: "n1-standard-2", GuestCpus: 2, MemoryMb: 7680},
+				{Name: "t2a-standard-4", GuestCpus: 4, MemoryMb: 16384},
+				{
+					Name:         "a2-highgpu-2g",
+					GuestCpus:    24,
+					MemoryMb:     174080,
+					Accelerators: []*compute.MachineTypeAccelerators{{GuestAcceleratorType: "nvidia-tesla-a100", GuestAcceleratorCount: 2}},
+				},
+				{Name: "c3-standard-4-lssd", GuestCpus: 4, MemoryMb: 16384, ScratchDisks: []*compute.MachineTypeScratchDisks{{DiskGb: 375}}},
+			},
+		},
+	}
+}
+
+func TestService_Reconcile(t *testing.T) {
+	localSsd := infrav1.LocalSsdDiskType
+	pdSsd := infrav1.PdSsdDiskType
+	tests := []struct {
+		name         string
+		zone         string
+		spec         infrav1.GCPMachineSpec
+		wantCapacity corev1.ResourceList
+		wantNodeInfo *infrav1.NodeInfo
+		wantErr      bool
+	}{
+		{
+			name: "general purpose machine type",
+			zone: "us-central1-a",
+			spec: infrav1.GCPMachineSpec{InstanceType: "n1-standard-2"},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("2"),
+				corev1.ResourceMemory:           resource.MustParse("7680Mi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("30Gi"),
+			},
+			wantNodeInfo: &infrav1.NodeInfo{Architecture: infrav1.ArchitectureAmd64, OperatingSystem: "linux"},
+		},
+		{
+			name: "arm machine type",
+			zone: "us-central1-a",
+			spec: infrav1.GCPMachineSpec{InstanceType: "t2a-standard-4", RootDeviceSize: 100},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("4"),
+				corev1.ResourceMemory:           resource.MustParse("16Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+			},
+			wantNodeInfo: &infrav1.NodeInfo{Architecture: infrav1.ArchitectureArm64, OperatingSystem: "linux"},
+		},
+		{
+			name: "machine type with GPUs",
+			zone: "us-central1-a",
+			spec: infrav1.GCPMachineSpec{InstanceType: "a2-highgpu-2g"},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("24"),
+				corev1.ResourceMemory:           resource.MustParse("170Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("30Gi"),
+				ResourceNvidiaGPU:               resource.MustParse("2"),
+			},
+			wantNodeInfo: &infrav1.NodeInfo{Architecture: infrav1.ArchitectureAmd64, OperatingSystem: "linux"},
+		},
+		{
+			name: "local SSDs",
+			zone: "us-central1-a",
+			spec: infrav1.GCPMachineSpec{
+				InstanceType: "c3-standard-4-lssd",
+				AdditionalDisks: []infrav1.AttachedDiskSpec{
+					{DeviceType: &localSsd},
+					{DeviceType: &pdSsd, Size: pointer.Int64(500)},
+				},
+			},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("4"),
+				corev1.ResourceMemory:           resource.MustParse("16Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("780Gi"),
+			},
+			wantNodeInfo: &infrav1.NodeInfo{Architecture: infrav1.ArchitectureAmd64, OperatingSystem: "linux"},
+		},
+		{
+			name: "no zone yet",
+			spec: infrav1.GCPMachineSpec{InstanceType: "n1-standard-2"},
+		},
+		{
+			name:    "unknown machine type",
+			zone:    "us-central1-a",
+			spec:    infrav1.GCPMachineSpec{InstanceType: "n1-unknown-2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scope := &fakeScope{zone: tt.zone, spec: tt.spec}
+			s := New(scope, nil)
+			s.machinetypes = newFakeMachineTypes()
+
+			err := s.Reconcile(context.TODO())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(scope.capacity).To(BeNil())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(scope.capacity).To(HaveLen(len(tt.wantCapacity)))
+			for name, want := range tt.wantCapacity {
+				g.Expect(scope.capacity).To(HaveKey(name))
+				got := scope.capacity[name]
+				g.Expect(got.Cmp(want)).To(BeZero(), "%s: got %s, want %s", name, got.String(), want.String())
+			}
+			g.Expect(scope.nodeInfo).To(Equal(tt.wantNodeInfo))
+		})
+	}
+}
+
+func TestService_ReconcileCache(t *testing.T) {
+	g := NewWithT(t)
+
+	machineTypes := newFakeMachineTypes()
+	cache := NewCache(DefaultTTL)
+	for i := 0; i < 2; i++ {
+		s := New(&fakeScope{zone: "us-central1-a", spec: infrav1.GCPMachineSpec{InstanceType: "n1-standard-2"}}, cache)
+		s.machinetypes = machineTypes
+		g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+	}
+	g.Expect(machineTypes.calls).To(Equal(1))
+
+	// Lookups that fail are not cached.
+	for i := 0; i < 2; i++ {
+		s := New(&fakeScope{zone: "us-central1-a", spec: infrav1.GCPMachineSpec{InstanceType: "n1-unknown-2"}}, cache)
+		s.machinetypes = machineTypes
+		g.Expect(s.Reconcile(context.TODO())).NotTo(Succeed())
+	}
+	g.Expect(machineTypes.calls).To(Equal(3))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetypes
+
+import (
+	"context"
+
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type machinetypesInterface interface {
+	Get(ctx context.Context, project, zone, machineType string) (*compute.MachineType, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	ComputeService() *compute.Service
+	Project() string
+	Zone() string
+	MachineSpec() *infrav1.GCPMachineSpec
+	SetCapacity(capacity corev1.ResourceList)
+	SetNodeInfo(nodeInfo *infrav1.NodeInfo)
+}
+
+// Service implements machine template capacity reconciler.
+type Service struct {
+	scope        Scope
+	machinetypes machinetypesInterface
+	cache        *Cache
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope. The cache is optional.
+func New(scope Scope, cache *Cache) *Service {
+	return &Service{
+		scope:        scope,
+		machinetypes: &client{compute: scope.ComputeService()},
+		cache:        cache,
+	}
+}
+
+// client looks up machine types with the GCE API.
+type client struct {
+	compute *compute.Service
+}
+
+// Get returns the machine type in the zone.
+func (c *client) Get(ctx context.Context, project, zone, machineType string) (*compute.MachineType, error) {
+	return c.compute.MachineTypes.Get(project, zone, machineType).Context(ctx).Do()
+}
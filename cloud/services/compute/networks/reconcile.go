@@ -24,77 +24,124 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Reconcile reconcile cluster network components.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling network resources")
 	network, err := s.createOrGetNetwork(ctx)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
+	}
+	if network == nil {
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
 	if network.Description == infrav1.ClusterTagKey(s.scope.Name()) {
 		router, err := s.createOrGetRouter(ctx, network)
 		if err != nil {
-			return err
+			return ctrl.Result{}, err
+		}
+		if router == nil {
+			return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 		}
 
 		s.scope.Network().Router = pointer.String(router.SelfLink)
 	}
 
 	s.scope.Network().SelfLink = pointer.String(network.SelfLink)
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // Delete delete cluster network components.
-func (s *Service) Delete(ctx context.Context) error {
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Deleting network resources")
 	networkKey := meta.GlobalKey(s.scope.NetworkName())
-	log.V(2).Info("Looking for network before deleting", "name", networkKey)
-	network, err := s.networks.Get(ctx, networkKey)
-	if err != nil {
-		return gcperrors.IgnoreNotFound(err)
-	}
+	networkResource := operations.ResourceName(s.scope.Project(), "networks", networkKey)
+	if s.scope.PendingOperation(networkResource) == nil {
+		log.V(2).Info("Looking for network before deleting", "name", networkKey)
+		network, err := s.networks.Get(ctx, networkKey)
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
 
-	if network.Description != infrav1.ClusterTagKey(s.scope.Name()) {
-		return nil
-	}
+			s.scope.Network().Router = nil
+			s.scope.Network().SelfLink = nil
+			return ctrl.Result{}, nil
+		}
 
-	log.V(2).Info("Found network created by capg", "name", s.scope.NetworkName())
+		if network.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+			return ctrl.Result{}, nil
+		}
 
-	routerSpec := s.scope.NatRouterSpec()
-	routerKey := meta.RegionalKey(routerSpec.Name, s.scope.Region())
-	log.V(2).Info("Looking for cloudnat router before deleting", "name", routerSpec.Name)
-	router, err := s.routers.Get(ctx, routerKey)
-	if err != nil && !gcperrors.IsNotFound(err) {
-		return err
-	}
+		log.V(2).Info("Found network created by capg", "name", s.scope.NetworkName())
+
+		routerSpec := s.scope.NatRouterSpec()
+		routerKey := meta.RegionalKey(routerSpec.Name, s.scope.Region())
+		routerResource := operations.ResourceName(s.scope.Project(), "routers", routerKey)
+		if s.scope.PendingOperation(routerResource) == nil {
+			log.V(2).Info("Looking for cloudnat router before deleting", "name", routerSpec.Name)
+			router, err := s.routers.Get(ctx, routerKey)
+			if err != nil && !gcperrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			if router == nil || router.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+				routerResource = ""
+			}
+		}
 
-	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
-		if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
-			return err
+		if routerResource != "" {
+			log.V(2).Info("Deleting a cloudnat router", "name", routerSpec.Name)
+			deleting, err := operations.Delete(ctx, s.operations, s.scope, routerResource, func() (*compute.Operation, error) {
+				return s.operations.DeleteRouter(ctx, routerKey)
+			})
+			if err != nil {
+				log.Error(err, "Error deleting a cloudnat router", "name", routerSpec.Name)
+				return ctrl.Result{}, err
+			}
+			if deleting {
+				return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
+			}
 		}
 	}
 
-	if err := s.networks.Delete(ctx, networkKey); err != nil {
+	log.V(2).Info("Deleting a network", "name", s.scope.NetworkName())
+	deleting, err := operations.Delete(ctx, s.operations, s.scope, networkResource, func() (*compute.Operation, error) {
+		return s.operations.DeleteNetwork(ctx, networkKey)
+	})
+	if err != nil {
 		log.Error(err, "Error deleting a network", "name", s.scope.NetworkName())
-		return err
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
 	s.scope.Network().Router = nil
 	s.scope.Network().SelfLink = nil
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // createOrGetNetwork creates a network if not exist otherwise return existing network.
+// It returns nil while the network is being created.
 func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, error) {
 	log := log.FromContext(ctx)
-	log.V(2).Info("Looking for network", "name", s.scope.NetworkName())
 	networkKey := meta.GlobalKey(s.scope.NetworkName())
+	networkResource := operations.ResourceName(s.scope.Project(), "networks", networkKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, networkResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a network", "name", s.scope.NetworkName())
+		}
+		return nil, err
+	}
+
+	log.V(2).Info("Looking for network", "name", s.scope.NetworkName())
 	network, err := s.networks.Get(ctx, networkKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
@@ -103,26 +150,33 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 		}
 
 		log.V(2).Info("Creating a network", "name", s.scope.NetworkName())
-		if err := s.networks.Insert(ctx, networkKey, s.scope.NetworkSpec()); err != nil {
+		op, err := s.operations.InsertNetwork(ctx, networkKey, s.scope.NetworkSpec())
+		if err != nil {
 			log.Error(err, "Error creating a network", "name", s.scope.NetworkName())
 			return nil, err
 		}
 
-		network, err = s.networks.Get(ctx, networkKey)
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, networkResource, op)
 	}
 
 	return network, nil
 }
 
 // createOrGetRouter creates a cloudnat router if not exist otherwise return the existing.
+// It returns nil while the router is being created.
 func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Network) (*compute.Router, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.NatRouterSpec()
-	log.V(2).Info("Looking for cloudnat router", "name", spec.Name)
 	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	routerResource := operations.ResourceName(s.scope.Project(), "routers", routerKey)
+	if pending, err := operations.Check(ctx, s.operations, s.scope, routerResource); err != nil || pending {
+		if err != nil {
+			log.Error(err, "Error creating a cloudnat router", "name", spec.Name)
+		}
+		return nil, err
+	}
+
+	log.V(2).Info("Looking for cloudnat router", "name", spec.Name)
 	router, err := s.routers.Get(ctx, routerKey)
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
@@ -133,15 +187,13 @@ func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Networ
 		spec.Network = network.SelfLink
 		spec.Description = infrav1.ClusterTagKey(s.scope.Name())
 		log.V(2).Info("Creating a cloudnat router", "name", spec.Name)
-		if err := s.routers.Insert(ctx, routerKey, spec); err != nil {
+		op, err := s.operations.InsertRouter(ctx, routerKey, spec)
+		if err != nil {
 			log.Error(err, "Error creating a cloudnat router", "name", spec.Name)
 			return nil, err
 		}
 
-		router, err = s.routers.Get(ctx, routerKey)
-		if err != nil {
-			return nil, err
-		}
+		return nil, operations.Track(s.scope, routerResource, op)
 	}
 
 	return router, nil
@@ -22,18 +22,23 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
 )
 
 type networksInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Network, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Network) error
-	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type routersInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Router, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Router) error
-	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertNetwork(ctx context.Context, key *meta.Key, obj *compute.Network) (*compute.Operation, error)
+	DeleteNetwork(ctx context.Context, key *meta.Key) (*compute.Operation, error)
+	InsertRouter(ctx context.Context, key *meta.Key, obj *compute.Router) (*compute.Operation, error)
+	DeleteRouter(ctx context.Context, key *meta.Key) (*compute.Operation, error)
 }
 
 // Scope is an interfaces that hold used methods.
@@ -45,18 +50,20 @@ type Scope interface {
 
 // Service implements networks reconciler.
 type Service struct {
-	scope    Scope
-	networks networksInterface
-	routers  routersInterface
+	scope      Scope
+	networks   networksInterface
+	routers    routersInterface
+	operations operationsInterface
 }
 
-var _ cloud.Reconciler = &Service{}
+var _ cloud.ReconcilerWithResult = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
-		scope:    scope,
-		networks: scope.Cloud().Networks(),
-		routers:  scope.Cloud().Routers(),
+		scope:      scope,
+		networks:   scope.Cloud().Networks(),
+		routers:    scope.Cloud().Routers(),
+		operations: operations.NewClient(scope.ComputeService(), scope.Project()),
 	}
 }
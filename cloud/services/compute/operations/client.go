@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+// Client starts GCE operations without waiting for them to complete.
+type Client struct {
+	compute *compute.Service
+	project string
+}
+
+// NewClient returns a Client starting operations in the project.
+func NewClient(computeSvc *compute.Service, project string) *Client {
+	return &Client{
+		compute: computeSvc,
+		project: project,
+	}
+}
+
+// Get returns the operation with the self link.
+func (c *Client) Get(ctx context.Context, selfLink string) (*compute.Operation, error) {
+	id, err := cloud.ParseResourceURL(selfLink)
+	if err != nil {
+		return nil, err
+	}
+
+	switch id.Key.Type() {
+	case meta.Zonal:
+		return c.compute.ZoneOperations.Get(id.ProjectID, id.Key.Zone, id.Key.Name).Context(ctx).Do()
+	case meta.Regional:
+		return c.compute.RegionOperations.Get(id.ProjectID, id.Key.Region, id.Key.Name).Context(ctx).Do()
+	default:
+		return c.compute.GlobalOperations.Get(id.ProjectID, id.Key.Name).Context(ctx).Do()
+	}
+}
+
+// InsertInstance starts creating an instance.
+func (c *Client) InsertInstance(ctx context.Context, key *meta.Key, obj *compute.Instance) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.Instances.Insert(c.project, key.Zone, obj).Context(ctx).Do()
+}
+
+// DeleteInstance starts deleting an instance.
+func (c *Client) DeleteInstance(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.Instances.Delete(c.project, key.Zone, key.Name).Context(ctx).Do()
+}
+
+// InsertInstanceGroup starts creating an instance group.
+func (c *Client) InsertInstanceGroup(ctx context.Context, key *meta.Key, obj *compute.InstanceGroup) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.InstanceGroups.Insert(c.project, key.Zone, obj).Context(ctx).Do()
+}
+
+// DeleteInstanceGroup starts deleting an instance group.
+func (c *Client) DeleteInstanceGroup(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.InstanceGroups.Delete(c.project, key.Zone, key.Name).Context(ctx).Do()
+}
+
+// AddInstances starts adding instances to an instance group.
+func (c *Client) AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) (*compute.Operation, error) {
+	return c.compute.InstanceGroups.AddInstances(c.project, key.Zone, key.Name, req).Context(ctx).Do()
+}
+
+// RemoveInstances starts removing instances from an instance group.
+func (c *Client) RemoveInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) (*compute.Operation, error) {
+	return c.compute.InstanceGroups.RemoveInstances(c.project, key.Zone, key.Name, req).Context(ctx).Do()
+}
+
+// InsertInstanceTemplate starts creating an instance template.
+func (c *Client) InsertInstanceTemplate(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.InstanceTemplates.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteInstanceTemplate starts deleting an instance template.
+func (c *Client) DeleteInstanceTemplate(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.InstanceTemplates.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertInstanceGroupManager starts creating a zonal or regional managed instance group.
+func (c *Client) InsertInstanceGroupManager(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error) {
+	obj.Name = key.Name
+	if key.Type() == meta.Regional {
+		return c.compute.RegionInstanceGroupManagers.Insert(c.project, key.Region, obj).Context(ctx).Do()
+	}
+	return c.compute.InstanceGroupManagers.Insert(c.project, key.Zone, obj).Context(ctx).Do()
+}
+
+// PatchInstanceGroupManager starts updating a zonal or regional managed instance group with the fields set in obj.
+func (c *Client) PatchInstanceGroupManager(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) (*compute.Operation, error) {
+	if key.Type() == meta.Regional {
+		return c.compute.RegionInstanceGroupManagers.Patch(c.project, key.Region, key.Name, obj).Context(ctx).Do()
+	}
+	return c.compute.InstanceGroupManagers.Patch(c.project, key.Zone, key.Name, obj).Context(ctx).Do()
+}
+
+// DeleteInstanceGroupManager starts deleting a zonal or regional managed instance group, with its instances.
+func (c *Client) DeleteInstanceGroupManager(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	if key.Type() == meta.Regional {
+		return c.compute.RegionInstanceGroupManagers.Delete(c.project, key.Region, key.Name).Context(ctx).Do()
+	}
+	return c.compute.InstanceGroupManagers.Delete(c.project, key.Zone, key.Name).Context(ctx).Do()
+}
+
+// InsertHealthCheck starts creating a health check.
+func (c *Client) InsertHealthCheck(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.HealthChecks.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteHealthCheck starts deleting a health check.
+func (c *Client) DeleteHealthCheck(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.HealthChecks.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertBackendService starts creating a backend service.
+func (c *Client) InsertBackendService(ctx context.Context, key *meta.Key, obj *compute.BackendService) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.BackendServices.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// UpdateBackendService starts updating a backend service.
+func (c *Client) UpdateBackendService(ctx context.Context, key *meta.Key, obj *compute.BackendService) (*compute.Operation, error) {
+	return c.compute.BackendServices.Update(c.project, key.Name, obj).Context(ctx).Do()
+}
+
+// DeleteBackendService starts deleting a backend service.
+func (c *Client) DeleteBackendService(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.BackendServices.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertTargetTCPProxy starts creating a target TCP proxy.
+func (c *Client) InsertTargetTCPProxy(ctx context.Context, key *meta.Key, obj *compute.TargetTcpProxy) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.TargetTcpProxies.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteTargetTCPProxy starts deleting a target TCP proxy.
+func (c *Client) DeleteTargetTCPProxy(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.TargetTcpProxies.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertGlobalAddress starts creating a global address.
+func (c *Client) InsertGlobalAddress(ctx context.Context, key *meta.Key, obj *compute.Address) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.GlobalAddresses.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteGlobalAddress starts deleting a global address.
+func (c *Client) DeleteGlobalAddress(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.GlobalAddresses.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertGlobalForwardingRule starts creating a global forwarding rule.
+func (c *Client) InsertGlobalForwardingRule(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.GlobalForwardingRules.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteGlobalForwardingRule starts deleting a global forwarding rule.
+func (c *Client) DeleteGlobalForwardingRule(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.GlobalForwardingRules.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertNetwork starts creating a network.
+func (c *Client) InsertNetwork(ctx context.Context, key *meta.Key, obj *compute.Network) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.Networks.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteNetwork starts deleting a network.
+func (c *Client) DeleteNetwork(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.Networks.Delete(c.project, key.Name).Context(ctx).Do()
+}
+
+// InsertRouter starts creating a router.
+func (c *Client) InsertRouter(ctx context.Context, key *meta.Key, obj *compute.Router) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.Routers.Insert(c.project, key.Region, obj).Context(ctx).Do()
+}
+
+// DeleteRouter starts deleting a router.
+func (c *Client) DeleteRouter(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.Routers.Delete(c.project, key.Region, key.Name).Context(ctx).Do()
+}
+
+// InsertSubnetwork starts creating a subnetwork.
+func (c *Client) InsertSubnetwork(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.Subnetworks.Insert(c.project, key.Region, obj).Context(ctx).Do()
+}
+
+// DeleteSubnetwork starts deleting a subnetwork.
+func (c *Client) DeleteSubnetwork(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.Subnetworks.Delete(c.project, key.Region, key.Name).Context(ctx).Do()
+}
+
+// InsertFirewall starts creating a firewall rule.
+func (c *Client) InsertFirewall(ctx context.Context, key *meta.Key, obj *compute.Firewall) (*compute.Operation, error) {
+	obj.Name = key.Name
+	return c.compute.Firewalls.Insert(c.project, obj).Context(ctx).Do()
+}
+
+// DeleteFirewall starts deleting a firewall rule.
+func (c *Client) DeleteFirewall(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	return c.compute.Firewalls.Delete(c.project, key.Name).Context(ctx).Do()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+// PollInterval is the interval at which the GCE operations in progress are polled.
+const PollInterval = 5 * time.Second
+
+// Getter is an interface which can get GCE operations.
+type Getter interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+}
+
+// ResourceName returns the relative name of a resource, under which the operations on it are tracked,
+// e.g. projects/my-project/zones/us-central1-a/instances/my-instance.
+func ResourceName(project, resource string, key *meta.Key) string {
+	return k8scloud.RelativeResourceName(project, resource, key)
+}
+
+// Check polls the operation in progress on the resource. It returns true while the operation is running, in which
+// case no other operation may be started on the resource. A completed operation is no longer tracked, and its error
+// is returned if it failed.
+func Check(ctx context.Context, getter Getter, tracker cloud.OperationTracker, resource string) (bool, error) {
+	pending := tracker.PendingOperation(resource)
+	if pending == nil {
+		return false, nil
+	}
+
+	op, err := getter.Get(ctx, pending.SelfLink)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			// The operation was garbage collected, the state of the resource tells how it ended.
+			tracker.DeletePendingOperation(resource)
+			return false, nil
+		}
+		return false, err
+	}
+	if op.Status != "DONE" {
+		return true, nil
+	}
+
+	tracker.DeletePendingOperation(resource)
+	return false, Error(op)
+}
+
+// Track records an operation started on the resource. An operation that is already done isn't recorded, and its
+// error is returned if it failed.
+func Track(tracker cloud.OperationTracker, resource string, op *compute.Operation) error {
+	if op.Status == "DONE" {
+		return Error(op)
+	}
+
+	startTime := metav1.Now()
+	if t, err := time.Parse(time.RFC3339, op.InsertTime); err == nil {
+		startTime = metav1.NewTime(t)
+	}
+	tracker.SetPendingOperation(infrav1.GCEOperation{
+		Resource:  resource,
+		SelfLink:  op.SelfLink,
+		Type:      op.OperationType,
+		StartTime: &startTime,
+	})
+
+	return nil
+}
+
+// Error returns the error of a completed operation, or nil if it succeeded. The error is a *googleapi.Error with the
+// HTTP status code of the operation and the codes of its errors as reasons, so that it can be classified like the
+// error of an API call.
+func Error(op *compute.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	items := make([]googleapi.ErrorItem, 0, len(op.Error.Errors))
+	for _, e := range op.Error.Errors {
+		items = append(items, googleapi.ErrorItem{Reason: e.Code, Message: e.Message})
+	}
+
+	return &googleapi.Error{
+		Code:    int(op.HttpErrorStatusCode),
+		Message: fmt.Sprintf("%s operation failed: %s: %s", op.OperationType, op.Error.Errors[0].Code, op.Error.Errors[0].Message),
+		Errors:  items,
+	}
+}
+
+// Delete starts deleting the resource with the delete function, unless an operation is already in progress on it.
+// It returns true until the resource is deleted.
+func Delete(ctx context.Context, getter Getter, tracker cloud.OperationTracker, resource string, deleteFn func() (*compute.Operation, error)) (bool, error) {
+	pending, err := Check(ctx, getter, tracker, resource)
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return false, err
+	}
+	if pending {
+		return true, nil
+	}
+
+	op, err := deleteFn()
+	if err != nil {
+		return false, gcperrors.IgnoreNotFound(err)
+	}
+	if err := Track(tracker, resource, op); err != nil {
+		return false, gcperrors.IgnoreNotFound(err)
+	}
+
+	return op.Status != "DONE", nil
+}
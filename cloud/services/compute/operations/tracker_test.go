@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+const fakeResource = "projects/my-proj/zones/us-central1-a/instances/my-machine"
+
+type fakeTracker struct {
+	ops map[string]infrav1.GCEOperation
+}
+
+func (f *fakeTracker) PendingOperation(resource string) *infrav1.GCEOperation {
+	if op, ok := f.ops[resource]; ok {
+		return &op
+	}
+	return nil
+}
+
+func (f *fakeTracker) SetPendingOperation(op infrav1.GCEOperation) {
+	if f.ops == nil {
+		f.ops = map[string]infrav1.GCEOperation{}
+	}
+	f.ops[op.Resource] = op
+}
+
+func (f *fakeTracker) DeletePendingOperation(resource string) {
+	delete(f.ops, resource)
+}
+
+type fakeGetter struct {
+	op  *compute.Operation
+	err error
+}
+
+func (f *fakeGetter) Get(_ context.Context, _ string) (*compute.Operation, error) {
+	return f.op, f.err
+}
+
+var failedOperation = &compute.Operation{
+	OperationType:       "insert",
+	Status:              "DONE",
+	HttpErrorStatusCode: http.StatusServiceUnavailable,
+	Error: &compute.OperationError{
+		Errors: []*compute.OperationErrorErrors{
+			{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "zone does not have enough resources"},
+		},
+	},
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		pending     bool
+		getter      *fakeGetter
+		wantPending bool
+		wantErr     bool
+		wantTracked bool
+	}{
+		{
+			name: "no operation in progress",
+		},
+		{
+			name:        "operation running",
+			pending:     true,
+			getter:      &fakeGetter{op: &compute.Operation{Status: "RUNNING"}},
+			wantPending: true,
+			wantTracked: true,
+		},
+		{
+			name:    "operation succeeded",
+			pending: true,
+			getter:  &fakeGetter{op: &compute.Operation{Status: "DONE"}},
+		},
+		{
+			name:    "operation failed",
+			pending: true,
+			getter:  &fakeGetter{op: failedOperation},
+			wantErr: true,
+		},
+		{
+			name:    "operation garbage collected",
+			pending: true,
+			getter:  &fakeGetter{err: &googleapi.Error{Code: http.StatusNotFound}},
+		},
+		{
+			name:        "error polling the operation",
+			pending:     true,
+			getter:      &fakeGetter{err: &googleapi.Error{Code: http.StatusInternalServerError}},
+			wantErr:     true,
+			wantTracked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &fakeTracker{}
+			if tt.pending {
+				tracker.SetPendingOperation(infrav1.GCEOperation{Resource: fakeResource, SelfLink: "operations/operation-123"})
+			}
+			pending, err := Check(context.TODO(), tt.getter, tracker, fakeResource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if pending != tt.wantPending {
+				t.Errorf("Check() = %v, want %v", pending, tt.wantPending)
+			}
+			if tracked := tracker.PendingOperation(fakeResource) != nil; tracked != tt.wantTracked {
+				t.Errorf("operation tracked = %v, want %v", tracked, tt.wantTracked)
+			}
+		})
+	}
+}
+
+func TestTrack(t *testing.T) {
+	tracker := &fakeTracker{}
+	if err := Track(tracker, fakeResource, &compute.Operation{Status: "DONE"}); err != nil {
+		t.Errorf("Track() error = %v, want nil", err)
+	}
+	if err := Track(tracker, fakeResource, failedOperation); err == nil {
+		t.Error("Track() error = nil, want an error")
+	}
+	if op := tracker.PendingOperation(fakeResource); op != nil {
+		t.Fatalf("completed operation was tracked: %v", op)
+	}
+
+	if err := Track(tracker, fakeResource, &compute.Operation{
+		SelfLink:      "operations/operation-123",
+		OperationType: "insert",
+		Status:        "RUNNING",
+		InsertTime:    "2023-08-01T03:00:00.000-07:00",
+	}); err != nil {
+		t.Fatalf("Track() error = %v, want nil", err)
+	}
+	op := tracker.PendingOperation(fakeResource)
+	if op == nil {
+		t.Fatal("running operation was not tracked")
+	}
+	if op.SelfLink != "operations/operation-123" || op.Type != "insert" {
+		t.Errorf("tracked operation = %v", op)
+	}
+	if op.StartTime == nil || op.StartTime.UTC().Hour() != 10 {
+		t.Errorf("StartTime = %v, want 10:00 UTC", op.StartTime)
+	}
+}
+
+func TestError(t *testing.T) {
+	if err := Error(&compute.Operation{Status: "DONE"}); err != nil {
+		t.Errorf("Error() = %v, want nil", err)
+	}
+
+	err := Error(failedOperation)
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) || gerr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Error() = %v, want a googleapi.Error with code %d", err, http.StatusServiceUnavailable)
+	}
+	if want := "insert operation failed: ZONE_RESOURCE_POOL_EXHAUSTED: zone does not have enough resources"; gerr.Message != want {
+		t.Errorf("Error() message = %s, want %s", gerr.Message, want)
+	}
+	if !gcperrors.IsZoneResourceExhausted(err) {
+		t.Errorf("Error() = %v, want a zone resource exhausted error", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		pending      *compute.Operation
+		deleteOp     *compute.Operation
+		deleteErr    error
+		wantDeleting bool
+		wantErr      bool
+	}{
+		{
+			name:         "deletion started",
+			deleteOp:     &compute.Operation{SelfLink: "operations/operation-123", Status: "RUNNING"},
+			wantDeleting: true,
+		},
+		{
+			name:     "deletion completed immediately",
+			deleteOp: &compute.Operation{Status: "DONE"},
+		},
+		{
+			name:      "resource already deleted",
+			deleteErr: &googleapi.Error{Code: http.StatusNotFound},
+		},
+		{
+			name:      "deletion refused",
+			deleteErr: &googleapi.Error{Code: http.StatusBadRequest},
+			wantErr:   true,
+		},
+		{
+			name:         "deletion in progress",
+			pending:      &compute.Operation{Status: "RUNNING"},
+			wantDeleting: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &fakeTracker{}
+			if tt.pending != nil {
+				tracker.SetPendingOperation(infrav1.GCEOperation{Resource: fakeResource, SelfLink: "operations/operation-123"})
+			}
+			deleting, err := Delete(context.TODO(), &fakeGetter{op: tt.pending}, tracker, fakeResource, func() (*compute.Operation, error) {
+				return tt.deleteOp, tt.deleteErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if deleting != tt.wantDeleting {
+				t.Errorf("Delete() = %v, want %v", deleting, tt.wantDeleting)
+			}
+		})
+	}
+}
@@ -22,45 +22,73 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Reconcile reconcile cluster network components.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling subnetwork resources")
 
 	// reconcile subnets
-	if _, err := s.createOrGetSubnets(ctx); err != nil {
-		return err
+	subnets, err := s.createOrGetSubnets(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(subnets) != len(s.scope.SubnetSpecs()) {
+		logger.Info("Waiting for subnets to be created")
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // Delete deletes cluster subnetwork components.
-func (s *Service) Delete(ctx context.Context) error {
+func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
+	deleting := false
 	for _, subnetSpec := range s.scope.SubnetSpecs() {
 		logger.V(2).Info("Deleting a subnet", "name", subnetSpec.Name)
 		subnetKey := meta.RegionalKey(subnetSpec.Name, s.scope.Region())
-		err := s.subnets.Delete(ctx, subnetKey)
-		if err != nil && !gcperrors.IsNotFound(err) {
+		subnetResource := operations.ResourceName(s.scope.Project(), "subnetworks", subnetKey)
+		subnetDeleting, err := operations.Delete(ctx, s.operations, s.scope, subnetResource, func() (*compute.Operation, error) {
+			return s.operations.DeleteSubnetwork(ctx, subnetKey)
+		})
+		if err != nil {
 			logger.Error(err, "Error deleting subnet", "name", subnetSpec.Name)
-			return err
+			return ctrl.Result{}, err
 		}
+		deleting = deleting || subnetDeleting
+	}
+
+	if deleting {
+		logger.Info("Waiting for subnets to be deleted")
+		return ctrl.Result{RequeueAfter: operations.PollInterval}, nil
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // createOrGetSubnets creates the subnetworks if they don't exist otherwise return the existing ones.
+// The subnetworks that are being created are started together and aren't returned.
 func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork, error) {
 	logger := log.FromContext(ctx)
 	subnets := []*compute.Subnetwork{}
 	for _, subnetSpec := range s.scope.SubnetSpecs() {
-		logger.V(2).Info("Looking for subnet", "name", subnetSpec.Name)
 		subnetKey := meta.RegionalKey(subnetSpec.Name, s.scope.Region())
+		subnetResource := operations.ResourceName(s.scope.Project(), "subnetworks", subnetKey)
+		pending, err := operations.Check(ctx, s.operations, s.scope, subnetResource)
+		if err != nil {
+			logger.Error(err, "Error creating a subnet", "name", subnetSpec.Name)
+			return subnets, err
+		}
+		if pending {
+			continue
+		}
+
+		logger.V(2).Info("Looking for subnet", "name", subnetSpec.Name)
 		subnet, err := s.subnets.Get(ctx, subnetKey)
 		if err != nil {
 			if !gcperrors.IsNotFound(err) {
@@ -70,16 +98,17 @@ func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork
 
 			// Subnet was not found, let's create it
 			logger.V(2).Info("Creating a subnet", "name", subnetSpec.Name)
-			if err := s.subnets.Insert(ctx, subnetKey, subnetSpec); err != nil {
+			op, err := s.operations.InsertSubnetwork(ctx, subnetKey, subnetSpec)
+			if err != nil {
 				logger.Error(err, "Error creating a subnet", "name", subnetSpec.Name)
 				return subnets, err
 			}
-
-			subnet, err = s.subnets.Get(ctx, subnetKey)
-			if err != nil {
-				logger.Error(err, "Error getting existing subnet", "name", subnetSpec.Name)
+			if err := operations.Track(s.scope, subnetResource, op); err != nil {
+				logger.Error(err, "Error creating a subnet", "name", subnetSpec.Name)
 				return subnets, err
 			}
+
+			continue
 		}
 		subnets = append(subnets, subnet)
 	}
@@ -69,11 +69,37 @@ var fakeGCPCluster = &infrav1.GCPCluster{
 	},
 }
 
+// fakeOperations starts the subnetwork operations on the mock, reporting them as running until they are polled.
+type fakeOperations struct {
+	subnets *cloud.MockSubnetworks
+}
+
+func (f *fakeOperations) Get(_ context.Context, selfLink string) (*compute.Operation, error) {
+	return &compute.Operation{SelfLink: selfLink, Status: "DONE"}, nil
+}
+
+func (f *fakeOperations) InsertSubnetwork(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) (*compute.Operation, error) {
+	if err := f.subnets.Insert(ctx, key, obj); err != nil {
+		return nil, err
+	}
+
+	return &compute.Operation{SelfLink: "operations/insert-" + key.Name, Status: "RUNNING"}, nil
+}
+
+func (f *fakeOperations) DeleteSubnetwork(ctx context.Context, key *meta.Key) (*compute.Operation, error) {
+	if err := f.subnets.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &compute.Operation{SelfLink: "operations/delete-" + key.Name, Status: "RUNNING"}, nil
+}
+
 type testCase struct {
 	name            string
 	scope           func() Scope
 	mockSubnetworks *cloud.MockSubnetworks
 	wantErr         bool
+	wantRequeue     bool
 	assert          func(ctx context.Context, t testCase) error
 }
 
@@ -124,6 +150,7 @@ func TestService_Reconcile(t *testing.T) {
 				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
 				Objects:       map[meta.Key]*cloud.MockSubnetworksObj{},
 			},
+			wantRequeue: true,
 			assert: func(ctx context.Context, t testCase) error {
 				key := meta.RegionalKey(fakeGCPCluster.Spec.Network.Subnets[0].Name, fakeGCPCluster.Spec.Region)
 				subnet, err := t.mockSubnetworks.Get(ctx, key)
@@ -158,11 +185,16 @@ func TestService_Reconcile(t *testing.T) {
 			ctx := context.TODO()
 			s := New(tt.scope())
 			s.subnets = tt.mockSubnetworks
-			err := s.Reconcile(ctx)
+			s.operations = &fakeOperations{subnets: tt.mockSubnetworks}
+			res, err := s.Reconcile(ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if (res.RequeueAfter > 0) != tt.wantRequeue {
+				t.Errorf("Service.Reconcile() result = %v, wantRequeue %v", res, tt.wantRequeue)
+				return
+			}
 			if tt.assert != nil {
 				err = tt.assert(ctx, tt)
 				if err != nil {
@@ -213,17 +245,47 @@ func TestService_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "subnet exists, should start deleting it",
+			scope: func() Scope { return clusterScope },
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey(fakeGCPCluster.Spec.Network.Subnets[0].Name, fakeGCPCluster.Spec.Region): {},
+				},
+			},
+			wantRequeue: true,
+			assert: func(ctx context.Context, t testCase) error {
+				resource := "projects/my-proj/regions/us-central1/subnetworks/workers"
+				if clusterScope.PendingOperation(resource) == nil {
+					return errors.New("subnet deletion was not tracked")
+				}
+
+				return nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.TODO()
 			s := New(tt.scope())
 			s.subnets = tt.mockSubnetworks
-			err := s.Delete(ctx)
+			s.operations = &fakeOperations{subnets: tt.mockSubnetworks}
+			res, err := s.Delete(ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if (res.RequeueAfter > 0) != tt.wantRequeue {
+				t.Errorf("Service.Delete() result = %v, wantRequeue %v", res, tt.wantRequeue)
+				return
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("subnet was not deleted as expected: %v", err)
+					return
+				}
+			}
 		})
 	}
 }
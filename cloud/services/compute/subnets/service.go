@@ -22,12 +22,17 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
 )
 
 type subnetsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Subnetwork, error)
-	Insert(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) error
-	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type operationsInterface interface {
+	Get(ctx context.Context, selfLink string) (*compute.Operation, error)
+	InsertSubnetwork(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) (*compute.Operation, error)
+	DeleteSubnetwork(ctx context.Context, key *meta.Key) (*compute.Operation, error)
 }
 
 // Scope is an interfaces that hold used methods.
@@ -38,16 +43,18 @@ type Scope interface {
 
 // Service implements subnets reconciler.
 type Service struct {
-	scope   Scope
-	subnets subnetsInterface
+	scope      Scope
+	subnets    subnetsInterface
+	operations operationsInterface
 }
 
-var _ cloud.Reconciler = &Service{}
+var _ cloud.ReconcilerWithResult = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
-		scope:   scope,
-		subnets: scope.Cloud().Subnetworks(),
+		scope:      scope,
+		subnets:    scope.Cloud().Subnetworks(),
+		operations: operations.NewClient(scope.ComputeService(), scope.Project()),
 	}
 }
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// convertToSdkAddonsConfig converts the AddonsConfig defined in CRs to the SDK version.
+// Only the addons that are specified are set, the others are left to the GKE defaults.
+func convertToSdkAddonsConfig(config *infrav1exp.AddonsConfig) *containerpb.AddonsConfig {
+	if config == nil {
+		return nil
+	}
+
+	addonsConfig := &containerpb.AddonsConfig{}
+	if config.HTTPLoadBalancing != nil {
+		addonsConfig.HttpLoadBalancing = &containerpb.HttpLoadBalancing{
+			Disabled: !*config.HTTPLoadBalancing,
+		}
+	}
+	if config.HorizontalPodAutoscaling != nil {
+		addonsConfig.HorizontalPodAutoscaling = &containerpb.HorizontalPodAutoscaling{
+			Disabled: !*config.HorizontalPodAutoscaling,
+		}
+	}
+	if config.NetworkPolicy != nil {
+		addonsConfig.NetworkPolicyConfig = &containerpb.NetworkPolicyConfig{
+			Disabled: !*config.NetworkPolicy,
+		}
+	}
+	if config.GcePersistentDiskCsiDriver != nil {
+		addonsConfig.GcePersistentDiskCsiDriverConfig = &containerpb.GcePersistentDiskCsiDriverConfig{
+			Enabled: *config.GcePersistentDiskCsiDriver,
+		}
+	}
+	if config.GcpFilestoreCsiDriver != nil {
+		addonsConfig.GcpFilestoreCsiDriverConfig = &containerpb.GcpFilestoreCsiDriverConfig{
+			Enabled: *config.GcpFilestoreCsiDriver,
+		}
+	}
+	if config.GcsFuseCsiDriver != nil {
+		addonsConfig.GcsFuseCsiDriverConfig = &containerpb.GcsFuseCsiDriverConfig{
+			Enabled: *config.GcsFuseCsiDriver,
+		}
+	}
+	if config.DNSCache != nil {
+		addonsConfig.DnsCacheConfig = &containerpb.DnsCacheConfig{
+			Enabled: *config.DNSCache,
+		}
+	}
+	if config.ConfigConnector != nil {
+		addonsConfig.ConfigConnectorConfig = &containerpb.ConfigConnectorConfig{
+			Enabled: *config.ConfigConnector,
+		}
+	}
+
+	return addonsConfig
+}
+
+// convertToSdkGatewayAPIConfig converts the Gateway API channel defined in CRs to the SDK version.
+func convertToSdkGatewayAPIConfig(config *infrav1exp.AddonsConfig) *containerpb.GatewayAPIConfig {
+	if config == nil || config.GatewayAPIChannel == nil {
+		return nil
+	}
+
+	channel := containerpb.GatewayAPIConfig_CHANNEL_UNSPECIFIED
+	switch *config.GatewayAPIChannel {
+	case infrav1exp.GatewayAPIChannelDisabled:
+		channel = containerpb.GatewayAPIConfig_CHANNEL_DISABLED
+	case infrav1exp.GatewayAPIChannelExperimental:
+		channel = containerpb.GatewayAPIConfig_CHANNEL_EXPERIMENTAL
+	case infrav1exp.GatewayAPIChannelStandard:
+		channel = containerpb.GatewayAPIConfig_CHANNEL_STANDARD
+	}
+
+	return &containerpb.GatewayAPIConfig{
+		Channel: channel,
+	}
+}
+
+// compareAddonsConfig returns true if all the addons specified in desired match the existing ones.
+func compareAddonsConfig(desired, existing *containerpb.AddonsConfig) bool {
+	if desired == nil {
+		return true
+	}
+
+	if desired.HttpLoadBalancing != nil && desired.GetHttpLoadBalancing().GetDisabled() != existing.GetHttpLoadBalancing().GetDisabled() {
+		return false
+	}
+	if desired.HorizontalPodAutoscaling != nil && desired.GetHorizontalPodAutoscaling().GetDisabled() != existing.GetHorizontalPodAutoscaling().GetDisabled() {
+		return false
+	}
+	if desired.NetworkPolicyConfig != nil && desired.GetNetworkPolicyConfig().GetDisabled() != existing.GetNetworkPolicyConfig().GetDisabled() {
+		return false
+	}
+	if desired.GcePersistentDiskCsiDriverConfig != nil && desired.GetGcePersistentDiskCsiDriverConfig().GetEnabled() != existing.GetGcePersistentDiskCsiDriverConfig().GetEnabled() {
+		return false
+	}
+	if desired.GcpFilestoreCsiDriverConfig != nil && desired.GetGcpFilestoreCsiDriverConfig().GetEnabled() != existing.GetGcpFilestoreCsiDriverConfig().GetEnabled() {
+		return false
+	}
+	if desired.GcsFuseCsiDriverConfig != nil && desired.GetGcsFuseCsiDriverConfig().GetEnabled() != existing.GetGcsFuseCsiDriverConfig().GetEnabled() {
+		return false
+	}
+	if desired.DnsCacheConfig != nil && desired.GetDnsCacheConfig().GetEnabled() != existing.GetDnsCacheConfig().GetEnabled() {
+		return false
+	}
+	if desired.ConfigConnectorConfig != nil && desired.GetConfigConnectorConfig().GetEnabled() != existing.GetConfigConnectorConfig().GetEnabled() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestCompareAddonsConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		addons   *infrav1exp.AddonsConfig
+		existing *containerpb.AddonsConfig
+		want     bool
+	}{
+		{
+			name:     "no addons specified",
+			addons:   nil,
+			existing: &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}},
+			want:     true,
+		},
+		{
+			name:   "http load balancing enabled matches default",
+			addons: &infrav1exp.AddonsConfig{HTTPLoadBalancing: pointer.Bool(true)},
+			existing: &containerpb.AddonsConfig{
+				HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: false},
+			},
+			want: true,
+		},
+		{
+			name:   "http load balancing disabled differs",
+			addons: &infrav1exp.AddonsConfig{HTTPLoadBalancing: pointer.Bool(false)},
+			existing: &containerpb.AddonsConfig{
+				HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: false},
+			},
+			want: false,
+		},
+		{
+			name:     "csi driver enabled but missing on cluster",
+			addons:   &infrav1exp.AddonsConfig{GcsFuseCsiDriver: pointer.Bool(true)},
+			existing: &containerpb.AddonsConfig{},
+			want:     false,
+		},
+		{
+			name:   "unspecified addons are ignored",
+			addons: &infrav1exp.AddonsConfig{DNSCache: pointer.Bool(true)},
+			existing: &containerpb.AddonsConfig{
+				DnsCacheConfig:        &containerpb.DnsCacheConfig{Enabled: true},
+				ConfigConnectorConfig: &containerpb.ConfigConnectorConfig{Enabled: true},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareAddonsConfig(convertToSdkAddonsConfig(tt.addons), tt.existing); got != tt.want {
+				t.Errorf("compareAddonsConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
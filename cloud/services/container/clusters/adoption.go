@@ -0,0 +1,213 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// isClusterManaged returns true if the GKE cluster was created or already adopted by the provider.
+// Clusters created by the provider are labelled as owned by the cluster, older clusters are recognized
+// by the creating condition recorded when the provider first reconciled them, until the label is applied.
+func (s *Service) isClusterManaged(existingCluster *containerpb.Cluster) bool {
+	if infrav1.Labels(existingCluster.ResourceLabels).HasOwned(s.scope.Cluster.Name) {
+		return true
+	}
+	return conditions.Has(s.scope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneCreatingCondition)
+}
+
+// adoptCluster prepares an existing GKE cluster that isn't managed by the provider for adoption.
+// Spec fields that aren't set are filled from the live cluster, and the remaining differences have to be
+// acknowledged in the adoption policy before the cluster is adopted. It returns false if the cluster
+// can't be adopted yet, in which case the reason is reported in the conditions.
+// The adoption is recorded in the status, so that the spec is only filled once while the ownership label is applied.
+func (s *Service) adoptCluster(existingCluster *containerpb.Cluster, log *logr.Logger) bool {
+	if s.scope.GCPManagedControlPlane.Status.Adopted {
+		return true
+	}
+
+	adoption := s.scope.GCPManagedControlPlane.Spec.Adoption
+	if adoption == nil || !adoption.Enabled {
+		msg := fmt.Sprintf("GKE cluster %s was not created by the provider, enable adoption to manage it", existingCluster.Name)
+		log.Info(msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneAdoptionRequiredReason, clusterv1.ConditionSeverityWarning, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneAdoptionRequiredReason, clusterv1.ConditionSeverityWarning, msg)
+		return false
+	}
+
+	s.fillSpecFromCluster(existingCluster)
+
+	if immutableDiffs := s.immutableAdoptionDiffs(existingCluster); len(immutableDiffs) > 0 {
+		msg := fmt.Sprintf("GKE cluster %s can't be adopted, immutable fields differ: %s", existingCluster.Name, strings.Join(immutableDiffs, ", "))
+		log.Info(msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneAdoptionBlockedReason, clusterv1.ConditionSeverityError, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneAdoptionBlockedReason, clusterv1.ConditionSeverityError, msg)
+		return false
+	}
+
+	diffs := s.adoptionDiffs(existingCluster)
+	if unacknowledged := sets.List(sets.New(diffs...).Delete(adoption.AcknowledgedDiffs...)); len(unacknowledged) > 0 {
+		msg := fmt.Sprintf("GKE cluster %s differs from the spec, acknowledge the differences to adopt it: %s", existingCluster.Name, strings.Join(unacknowledged, ", "))
+		log.Info(msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneAdoptionBlockedReason, clusterv1.ConditionSeverityWarning, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneAdoptionBlockedReason, clusterv1.ConditionSeverityWarning, msg)
+		return false
+	}
+
+	log.Info("Adopting GKE cluster", "name", existingCluster.Name, "acknowledgedDiffs", diffs)
+	s.scope.GCPManagedControlPlane.Status.Adopted = true
+	return true
+}
+
+// fillSpecFromCluster sets the spec fields that aren't specified to the values of the live cluster.
+func (s *Service) fillSpecFromCluster(existingCluster *containerpb.Cluster) {
+	spec := &s.scope.GCPManagedControlPlane.Spec
+
+	if spec.ControlPlaneVersion == nil {
+		// Only the minor version is pinned so that patch upgrades done by GKE aren't reverted.
+		if current, err := version.ParseGeneric(existingCluster.CurrentMasterVersion); err == nil {
+			minorVersion := fmt.Sprintf("%d.%d", current.Major(), current.Minor())
+			spec.ControlPlaneVersion = &minorVersion
+		}
+	}
+	if spec.ReleaseChannel == nil {
+		spec.ReleaseChannel = convertFromSdkReleaseChannel(existingCluster.GetReleaseChannel().GetChannel())
+	}
+	if spec.MasterAuthorizedNetworksConfig == nil {
+		spec.MasterAuthorizedNetworksConfig = convertFromSdkMasterAuthorizedNetworksConfig(existingCluster.MasterAuthorizedNetworksConfig)
+	}
+}
+
+// immutableAdoptionDiffs returns the fields that differ between the spec and the live cluster and can't be updated.
+func (s *Service) immutableAdoptionDiffs(existingCluster *containerpb.Cluster) []string {
+	diffs := []string{}
+	if network := s.scope.GCPManagedCluster.Spec.Network.Name; network != nil && *network != existingCluster.Network {
+		diffs = append(diffs, "network")
+	}
+	if s.scope.GCPManagedControlPlane.Spec.EnableAutopilot != existingCluster.GetAutopilot().GetEnabled() {
+		diffs = append(diffs, "enableAutopilot")
+	}
+	if networking := s.scope.GCPManagedControlPlane.Spec.Networking; networking != nil &&
+		!compareDatapathProvider(convertToSdkDatapathProvider(networking.DatapathProvider), existingCluster.GetNetworkConfig().GetDatapathProvider()) {
+		diffs = append(diffs, "networking.datapathProvider")
+	}
+	return diffs
+}
+
+// adoptionDiffs returns the spec fields whose values would be applied to the live cluster once it's adopted.
+func (s *Service) adoptionDiffs(existingCluster *containerpb.Cluster) []string {
+	spec := s.scope.GCPManagedControlPlane.Spec
+	diffs := []string{}
+
+	if spec.ControlPlaneVersion != nil && !shared.VersionSatisfies(existingCluster.CurrentMasterVersion, *spec.ControlPlaneVersion) {
+		diffs = append(diffs, "controlPlaneVersion")
+	}
+	if convertToSdkReleaseChannel(spec.ReleaseChannel) != existingCluster.GetReleaseChannel().GetChannel() {
+		diffs = append(diffs, "releaseChannel")
+	}
+	if !compareMasterAuthorizedNetworksConfig(convertToSdkMasterAuthorizedNetworksConfig(spec.MasterAuthorizedNetworksConfig), existingCluster.MasterAuthorizedNetworksConfig) {
+		diffs = append(diffs, "masterAuthorizedNetworksConfig")
+	}
+	gatewayAPIConfig := convertToSdkGatewayAPIConfig(spec.Addons)
+	if !compareAddonsConfig(convertToSdkAddonsConfig(spec.Addons), existingCluster.AddonsConfig) ||
+		(gatewayAPIConfig != nil && gatewayAPIConfig.Channel != existingCluster.GetNetworkConfig().GetGatewayApiConfig().GetChannel()) {
+		diffs = append(diffs, "addons")
+	}
+	if maintenancePolicy := convertToSdkMaintenancePolicy(spec.MaintenancePolicy); maintenancePolicy != nil && !compareMaintenanceWindow(maintenancePolicy.Window, existingCluster.GetMaintenancePolicy().GetWindow()) {
+		diffs = append(diffs, "maintenancePolicy")
+	}
+	if !compareLoggingConfig(convertToSdkLoggingConfig(spec.LoggingConfig), existingCluster.LoggingConfig) {
+		diffs = append(diffs, "loggingConfig")
+	}
+	if !compareMonitoringConfig(convertToSdkMonitoringConfig(spec.MonitoringConfig), existingCluster.MonitoringConfig) {
+		diffs = append(diffs, "monitoringConfig")
+	}
+	if !compareClusterAutoscaling(convertToSdkClusterAutoscaling(spec.ClusterAutoscaling), existingCluster.Autoscaling) {
+		diffs = append(diffs, "clusterAutoscaling")
+	}
+	if !compareShieldedNodes(convertToSdkShieldedNodes(spec.EnableShieldedNodes), existingCluster.ShieldedNodes) {
+		diffs = append(diffs, "enableShieldedNodes")
+	}
+	if !compareBinaryAuthorization(convertToSdkBinaryAuthorization(spec.BinaryAuthorizationEvaluationMode), existingCluster.BinaryAuthorization) {
+		diffs = append(diffs, "binaryAuthorizationEvaluationMode")
+	}
+	if !compareDatabaseEncryption(convertToSdkDatabaseEncryption(spec.DatabaseEncryption), existingCluster.DatabaseEncryption) {
+		diffs = append(diffs, "databaseEncryption")
+	}
+	if !compareAuthenticatorGroupsConfig(convertToSdkAuthenticatorGroupsConfig(spec.AuthenticatorGroupsConfig), existingCluster.AuthenticatorGroupsConfig) {
+		diffs = append(diffs, "authenticatorGroupsConfig")
+	}
+	if len(networkingUpdates(spec.Networking, existingCluster.GetNetworkConfig())) > 0 {
+		diffs = append(diffs, "networking")
+	}
+	if networkPolicyUpdate(spec.Networking, existingCluster.GetNetworkPolicy()) != nil {
+		diffs = append(diffs, "networking.enableNetworkPolicy")
+	}
+	// The ownership label is always added on adoption, other labels of the cluster are replaced.
+	existingLabels := infrav1.Labels{infrav1.ClusterTagKey(s.scope.Cluster.Name): string(infrav1.ResourceLifecycleOwned)}
+	existingLabels = existingLabels.AddLabels(existingCluster.ResourceLabels)
+	if !s.scope.ResourceLabels().Equals(existingLabels) {
+		diffs = append(diffs, "additionalLabels")
+	}
+
+	return diffs
+}
+
+func convertFromSdkReleaseChannel(channel containerpb.ReleaseChannel_Channel) *infrav1exp.ReleaseChannel {
+	var releaseChannel infrav1exp.ReleaseChannel
+	switch channel {
+	case containerpb.ReleaseChannel_RAPID:
+		releaseChannel = infrav1exp.Rapid
+	case containerpb.ReleaseChannel_REGULAR:
+		releaseChannel = infrav1exp.Regular
+	case containerpb.ReleaseChannel_STABLE:
+		releaseChannel = infrav1exp.Stable
+	default:
+		return nil
+	}
+	return &releaseChannel
+}
+
+func convertFromSdkMasterAuthorizedNetworksConfig(config *containerpb.MasterAuthorizedNetworksConfig) *infrav1exp.MasterAuthorizedNetworksConfig {
+	if !config.GetEnabled() {
+		return nil
+	}
+
+	cidrBlocks := make([]*infrav1exp.MasterAuthorizedNetworksConfigCidrBlock, len(config.CidrBlocks))
+	for i, cidrBlock := range config.CidrBlocks {
+		cidrBlocks[i] = &infrav1exp.MasterAuthorizedNetworksConfigCidrBlock{
+			CidrBlock:   cidrBlock.CidrBlock,
+			DisplayName: cidrBlock.DisplayName,
+		}
+	}
+
+	return &infrav1exp.MasterAuthorizedNetworksConfig{
+		CidrBlocks:                  cidrBlocks,
+		GcpPublicCidrsAccessEnabled: config.GcpPublicCidrsAccessEnabled,
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAdoptCluster(t *testing.T) {
+	existingCluster := func() *containerpb.Cluster {
+		return &containerpb.Cluster{
+			Name:                 "gke",
+			Network:              "default",
+			CurrentMasterVersion: "1.27.3-gke.100",
+			ReleaseChannel:       &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+			MasterAuthorizedNetworksConfig: &containerpb.MasterAuthorizedNetworksConfig{
+				Enabled: true,
+				CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{
+					{CidrBlock: "10.0.0.0/8", DisplayName: "internal"},
+				},
+			},
+			ResourceLabels: map[string]string{"team": "platform"},
+		}
+	}
+	stable := infrav1exp.Stable
+
+	tests := []struct {
+		name      string
+		spec      infrav1exp.GCPManagedControlPlaneSpec
+		labels    infrav1.Labels
+		wantAdopt bool
+		wantMsg   string
+	}{
+		{
+			name:    "adoption not enabled",
+			spec:    infrav1exp.GCPManagedControlPlaneSpec{},
+			wantMsg: "GKE cluster gke was not created by the provider, enable adoption to manage it",
+		},
+		{
+			name:      "spec filled from the live cluster",
+			spec:      infrav1exp.GCPManagedControlPlaneSpec{Adoption: &infrav1exp.AdoptionPolicy{Enabled: true}},
+			labels:    infrav1.Labels{"team": "platform"},
+			wantAdopt: true,
+		},
+		{
+			name: "unacknowledged differences",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ReleaseChannel: &stable,
+				Adoption:       &infrav1exp.AdoptionPolicy{Enabled: true},
+			},
+			wantMsg: "GKE cluster gke differs from the spec, acknowledge the differences to adopt it: additionalLabels, releaseChannel",
+		},
+		{
+			name: "acknowledged differences",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ReleaseChannel: &stable,
+				Adoption:       &infrav1exp.AdoptionPolicy{Enabled: true, AcknowledgedDiffs: []string{"releaseChannel", "additionalLabels"}},
+			},
+			wantAdopt: true,
+		},
+		{
+			name: "immutable differences",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				EnableAutopilot: true,
+				Adoption:        &infrav1exp.AdoptionPolicy{Enabled: true},
+			},
+			labels:  infrav1.Labels{"team": "platform"},
+			wantMsg: "GKE cluster gke can't be adopted, immutable fields differ: enableAutopilot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedControlPlaneScope{
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-cluster"}},
+				GCPManagedCluster: &infrav1exp.GCPManagedCluster{
+					Spec: infrav1exp.GCPManagedClusterSpec{
+						Network:          infrav1.NetworkSpec{Name: pointer.String("default")},
+						AdditionalLabels: tt.labels,
+					},
+				},
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{Spec: tt.spec},
+			})
+			log := logr.Discard()
+
+			if s.isClusterManaged(existingCluster()) {
+				t.Fatalf("isClusterManaged() = true, want false")
+			}
+			if got := s.adoptCluster(existingCluster(), &log); got != tt.wantAdopt {
+				t.Errorf("adoptCluster() = %v, want %v", got, tt.wantAdopt)
+			}
+			if got := s.scope.GCPManagedControlPlane.Status.Adopted; got != tt.wantAdopt {
+				t.Errorf("adoptCluster() status adopted = %v, want %v", got, tt.wantAdopt)
+			}
+			if msg := conditions.GetMessage(s.scope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneReadyCondition); msg != tt.wantMsg {
+				t.Errorf("adoptCluster() condition message = %q, want %q", msg, tt.wantMsg)
+			}
+			if !tt.wantAdopt {
+				return
+			}
+			spec := s.scope.GCPManagedControlPlane.Spec
+			if spec.ControlPlaneVersion == nil || *spec.ControlPlaneVersion != "1.27" {
+				t.Errorf("adoptCluster() control plane version = %v, want 1.27", spec.ControlPlaneVersion)
+			}
+			if spec.MasterAuthorizedNetworksConfig == nil || len(spec.MasterAuthorizedNetworksConfig.CidrBlocks) != 1 {
+				t.Errorf("adoptCluster() master authorized networks config = %v, want the live config", spec.MasterAuthorizedNetworksConfig)
+			}
+		})
+	}
+}
+
+func TestIsClusterManaged(t *testing.T) {
+	s := New(&scope.ManagedControlPlaneScope{
+		Cluster:                &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-cluster"}},
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{},
+	})
+	owned := &containerpb.Cluster{ResourceLabels: map[string]string{infrav1.ClusterTagKey("capi-cluster"): "owned"}}
+	if !s.isClusterManaged(owned) {
+		t.Errorf("isClusterManaged() = false for a cluster labelled as owned")
+	}
+
+	if s.isClusterManaged(&containerpb.Cluster{}) {
+		t.Errorf("isClusterManaged() = true for a cluster without the ownership label")
+	}
+
+	conditions.MarkFalse(s.scope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneCreatedReason, clusterv1.ConditionSeverityInfo, "")
+	if !s.isClusterManaged(&containerpb.Cluster{}) {
+		t.Errorf("isClusterManaged() = false for a cluster created before ownership labels were added")
+	}
+}
+
+func TestAdoptClusterOnce(t *testing.T) {
+	s := New(&scope.ManagedControlPlaneScope{
+		Cluster:           &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-cluster"}},
+		GCPManagedCluster: &infrav1exp.GCPManagedCluster{},
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{
+				Adoption: &infrav1exp.AdoptionPolicy{Enabled: true, AcknowledgedDiffs: []string{"masterAuthorizedNetworksConfig"}},
+			},
+		},
+	})
+	log := logr.Discard()
+
+	if !s.adoptCluster(&containerpb.Cluster{Name: "gke", CurrentMasterVersion: "1.27.3-gke.100"}, &log) {
+		t.Fatalf("adoptCluster() = false, want true")
+	}
+
+	// The live cluster is upgraded before the ownership label is applied, the spec is kept.
+	if !s.adoptCluster(&containerpb.Cluster{Name: "gke", CurrentMasterVersion: "1.28.1-gke.200"}, &log) {
+		t.Errorf("adoptCluster() = false for an adopted cluster, want true")
+	}
+	if version := s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion; version == nil || *version != "1.27" {
+		t.Errorf("adoptCluster() control plane version = %v, want 1.27", version)
+	}
+}
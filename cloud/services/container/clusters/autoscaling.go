@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// convertToSdkClusterAutoscaling converts the ClusterAutoscaling defined in CRs to the SDK version.
+func convertToSdkClusterAutoscaling(autoscaling *infrav1exp.ClusterAutoscaling) *containerpb.ClusterAutoscaling {
+	if autoscaling == nil {
+		return nil
+	}
+
+	clusterAutoscaling := &containerpb.ClusterAutoscaling{
+		EnableNodeAutoprovisioning: autoscaling.EnableNodeAutoprovisioning,
+		ResourceLimits:             make([]*containerpb.ResourceLimit, len(autoscaling.ResourceLimits)),
+		AutoscalingProfile:         convertToSdkAutoscalingProfile(autoscaling.AutoscalingProfile),
+	}
+	for i, limit := range autoscaling.ResourceLimits {
+		clusterAutoscaling.ResourceLimits[i] = &containerpb.ResourceLimit{
+			ResourceType: limit.ResourceType,
+			Minimum:      limit.Minimum,
+			Maximum:      limit.Maximum,
+		}
+	}
+	if defaults := autoscaling.AutoprovisioningNodePoolDefaults; defaults != nil {
+		clusterAutoscaling.AutoprovisioningNodePoolDefaults = &containerpb.AutoprovisioningNodePoolDefaults{
+			OauthScopes: defaults.OauthScopes,
+		}
+		if defaults.ServiceAccount != nil {
+			clusterAutoscaling.AutoprovisioningNodePoolDefaults.ServiceAccount = *defaults.ServiceAccount
+		}
+		if defaults.BootDiskKMSKey != nil {
+			clusterAutoscaling.AutoprovisioningNodePoolDefaults.BootDiskKmsKey = *defaults.BootDiskKMSKey
+		}
+	}
+
+	return clusterAutoscaling
+}
+
+func convertToSdkAutoscalingProfile(profile *infrav1exp.AutoscalingProfile) containerpb.ClusterAutoscaling_AutoscalingProfile {
+	if profile == nil {
+		return containerpb.ClusterAutoscaling_PROFILE_UNSPECIFIED
+	}
+
+	switch *profile {
+	case infrav1exp.BalancedAutoscalingProfile:
+		return containerpb.ClusterAutoscaling_BALANCED
+	case infrav1exp.OptimizeUtilizationAutoscalingProfile:
+		return containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION
+	default:
+		return containerpb.ClusterAutoscaling_PROFILE_UNSPECIFIED
+	}
+}
+
+// compareClusterAutoscaling returns true if the cluster autoscaling settings specified in desired match the existing ones.
+func compareClusterAutoscaling(desired, existing *containerpb.ClusterAutoscaling) bool {
+	if desired == nil {
+		return true
+	}
+
+	if desired.EnableNodeAutoprovisioning != existing.GetEnableNodeAutoprovisioning() {
+		return false
+	}
+	if !compareResourceLimits(desired.ResourceLimits, existing.GetResourceLimits()) {
+		return false
+	}
+	if desired.AutoscalingProfile != containerpb.ClusterAutoscaling_PROFILE_UNSPECIFIED && desired.AutoscalingProfile != existing.GetAutoscalingProfile() {
+		return false
+	}
+
+	if desiredDefaults := desired.AutoprovisioningNodePoolDefaults; desiredDefaults != nil {
+		existingDefaults := existing.GetAutoprovisioningNodePoolDefaults()
+		if desiredDefaults.ServiceAccount != "" && desiredDefaults.ServiceAccount != existingDefaults.GetServiceAccount() {
+			return false
+		}
+		if len(desiredDefaults.OauthScopes) > 0 && !sets.New(desiredDefaults.OauthScopes...).Equal(sets.New(existingDefaults.GetOauthScopes()...)) {
+			return false
+		}
+		if desiredDefaults.BootDiskKmsKey != "" && desiredDefaults.BootDiskKmsKey != existingDefaults.GetBootDiskKmsKey() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareResourceLimits(desired, existing []*containerpb.ResourceLimit) bool {
+	if len(desired) != len(existing) {
+		return false
+	}
+
+	existingLimits := map[string]*containerpb.ResourceLimit{}
+	for _, limit := range existing {
+		existingLimits[limit.GetResourceType()] = limit
+	}
+	for _, limit := range desired {
+		existingLimit, ok := existingLimits[limit.GetResourceType()]
+		if !ok || existingLimit.GetMinimum() != limit.GetMinimum() || existingLimit.GetMaximum() != limit.GetMaximum() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestCompareClusterAutoscaling(t *testing.T) {
+	balanced := infrav1exp.BalancedAutoscalingProfile
+	optimizeUtilization := infrav1exp.OptimizeUtilizationAutoscalingProfile
+	limits := []infrav1exp.ResourceLimit{
+		{ResourceType: "cpu", Maximum: 100},
+		{ResourceType: "memory", Minimum: 1, Maximum: 400},
+	}
+	existing := &containerpb.ClusterAutoscaling{
+		EnableNodeAutoprovisioning: true,
+		ResourceLimits: []*containerpb.ResourceLimit{
+			{ResourceType: "memory", Minimum: 1, Maximum: 400},
+			{ResourceType: "cpu", Maximum: 100},
+		},
+		AutoscalingProfile: containerpb.ClusterAutoscaling_BALANCED,
+		AutoprovisioningNodePoolDefaults: &containerpb.AutoprovisioningNodePoolDefaults{
+			ServiceAccount: "nodes@my-project.iam.gserviceaccount.com",
+			OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		autoscaling *infrav1exp.ClusterAutoscaling
+		want        bool
+	}{
+		{
+			name:        "not specified",
+			autoscaling: nil,
+			want:        true,
+		},
+		{
+			name: "matches without profile and defaults",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             limits,
+			},
+			want: true,
+		},
+		{
+			name: "matches with profile and defaults",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             limits,
+				AutoscalingProfile:         &balanced,
+				AutoprovisioningNodePoolDefaults: &infrav1exp.AutoprovisioningNodePoolDefaults{
+					ServiceAccount: pointer.String("nodes@my-project.iam.gserviceaccount.com"),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node auto-provisioning disabled",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				ResourceLimits: limits,
+			},
+			want: false,
+		},
+		{
+			name: "resource limit changed",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []infrav1exp.ResourceLimit{
+					{ResourceType: "cpu", Maximum: 200},
+					{ResourceType: "memory", Minimum: 1, Maximum: 400},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "profile changed",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             limits,
+				AutoscalingProfile:         &optimizeUtilization,
+			},
+			want: false,
+		},
+		{
+			name: "boot disk kms key added",
+			autoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             limits,
+				AutoprovisioningNodePoolDefaults: &infrav1exp.AutoprovisioningNodePoolDefaults{
+					BootDiskKMSKey: pointer.String("projects/my-project/locations/us-central1/keyRings/ring/cryptoKeys/key"),
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareClusterAutoscaling(convertToSdkClusterAutoscaling(tt.autoscaling), existing); got != tt.want {
+				t.Errorf("compareClusterAutoscaling() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
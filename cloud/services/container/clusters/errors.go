@@ -25,6 +25,8 @@ import (
 var (
 	// ErrAutopilotClusterMachinePoolsNotAllowed is used when there are machine pools specified for an autopilot enabled cluster.
 	ErrAutopilotClusterMachinePoolsNotAllowed = errors.New("cannot use machine pools with an autopilot enabled cluster")
+	// ErrInvalidControlPlaneVersion is used when the requested control plane version can't be used for the cluster.
+	ErrInvalidControlPlaneVersion = errors.New("invalid control plane version")
 )
 
 // NewErrUnexpectedClusterStatus creates a new error for an unexpected cluster status.
@@ -17,9 +17,11 @@ limitations under the License.
 package clusters
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
@@ -32,16 +34,28 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
 	// GkeScope is the scope to request when generating access token.
 	GkeScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// KubeconfigTokenExpiryAnnotation records the expiry time of the access token embedded in the kubeconfig secret used by Cluster API.
+	KubeconfigTokenExpiryAnnotation = "infrastructure.cluster.x-k8s.io/kubeconfig-token-expiry"
+	// KubeconfigTokenServiceAccountAnnotation records the service account the access token embedded in the kubeconfig secret was generated for.
+	KubeconfigTokenServiceAccountAnnotation = "infrastructure.cluster.x-k8s.io/kubeconfig-token-service-account"
+
+	// kubeconfigTokenRefreshBuffer is how long before its expiry the access token is refreshed.
+	kubeconfigTokenRefreshBuffer = 10 * time.Minute
 )
 
-func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) error {
+// reconcileKubeconfig creates or refreshes the kubeconfig secret used by Cluster API and returns the
+// expiry time of the access token it contains.
+func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) (time.Time, error) {
 	log.Info("Reconciling kubeconfig")
 	clusterRef := types.NamespacedName{
 		Name:      s.scope.Cluster.Name,
@@ -52,25 +66,71 @@ func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			log.Error(err, "getting kubeconfig secret", "name", clusterRef)
-			return fmt.Errorf("getting kubeconfig secret %s: %w", clusterRef, err)
+			return time.Time{}, fmt.Errorf("getting kubeconfig secret %s: %w", clusterRef, err)
 		}
 		log.Info("kubeconfig secret not found, creating")
 
-		if createErr := s.createCAPIKubeconfigSecret(
+		expiry, createErr := s.createCAPIKubeconfigSecret(
 			ctx,
 			cluster,
 			&clusterRef,
 			log,
-		); createErr != nil {
-			return fmt.Errorf("creating kubeconfig secret: %w", createErr)
+		)
+		if createErr != nil {
+			return time.Time{}, fmt.Errorf("creating kubeconfig secret: %w", createErr)
 		}
-	} else if updateErr := s.updateCAPIKubeconfigSecret(ctx, configSecret); updateErr != nil {
-		return fmt.Errorf("updating kubeconfig secret: %w", err)
+		return expiry, nil
 	}
 
-	return nil
+	if expiry, ok := s.kubeconfigTokenValid(configSecret, time.Now()); ok {
+		log.V(4).Info("kubeconfig token still valid", "expiry", expiry)
+		return expiry, nil
+	}
+	log.Info("kubeconfig token expiring, refreshing")
+	expiry, updateErr := s.updateCAPIKubeconfigSecret(ctx, configSecret)
+	if updateErr != nil {
+		return time.Time{}, fmt.Errorf("updating kubeconfig secret: %w", updateErr)
+	}
+
+	return expiry, nil
+}
+
+// kubeconfigTokenValid returns the expiry of the access token recorded on the kubeconfig secret and whether
+// the token can still be used, i.e. it isn't about to expire and was generated for the expected service account.
+func (s *Service) kubeconfigTokenValid(configSecret *corev1.Secret, now time.Time) (time.Time, bool) {
+	if configSecret.Annotations[KubeconfigTokenServiceAccountAnnotation] != s.kubeconfigServiceAccount() {
+		return time.Time{}, false
+	}
+	expiry, err := time.Parse(time.RFC3339, configSecret.Annotations[KubeconfigTokenExpiryAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiry, now.Before(expiry.Add(-kubeconfigTokenRefreshBuffer))
+}
+
+// kubeconfigTokenRefreshAfter returns the time to wait before refreshing an access token expiring at expiry.
+func kubeconfigTokenRefreshAfter(expiry, now time.Time) time.Duration {
+	refreshAfter := expiry.Add(-kubeconfigTokenRefreshBuffer).Sub(now)
+	if refreshAfter < reconciler.DefaultRetryTime {
+		return reconciler.DefaultRetryTime
+	}
+	return refreshAfter
+}
+
+// refreshKubeconfig keeps the access token of the CAPI kubeconfig valid while the cluster isn't reconciled any
+// further, e.g. while it is degraded or its adoption is blocked, and returns when to reconcile it again.
+func (s *Service) refreshKubeconfig(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) ctrl.Result {
+	tokenExpiry, err := s.reconcileKubeconfig(ctx, cluster, log)
+	if err != nil {
+		log.Error(err, "Failed to reconcile CAPI kubeconfig")
+		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}
+	}
+
+	return ctrl.Result{RequeueAfter: kubeconfigTokenRefreshAfter(tokenExpiry, time.Now())}
 }
 
+// reconcileAdditionalKubeconfigs creates the kubeconfig secret for users of the cluster and keeps it in
+// sync with the endpoint and CA of the cluster and the configured authentication method.
 func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) error {
 	log.Info("Reconciling additional kubeconfig")
 	clusterRef := types.NamespacedName{
@@ -78,62 +138,126 @@ func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *c
 		Namespace: s.scope.Cluster.Namespace,
 	}
 
-	// Create the additional kubeconfig for users. This doesn't need updating on every sync
-	_, err := secret.GetFromNamespacedName(ctx, s.scope.Client(), clusterRef, secret.Kubeconfig)
+	out, err := s.generateUserKubeconfig(cluster)
+	if err != nil {
+		return fmt.Errorf("generating additional kubeconfig: %w", err)
+	}
+
+	configSecret, err := secret.GetFromNamespacedName(ctx, s.scope.Client(), clusterRef, secret.Kubeconfig)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("getting kubeconfig (user) secret %s: %w", clusterRef, err)
 		}
 
-		createErr := s.createUserKubeconfigSecret(
-			ctx,
-			cluster,
-			&clusterRef,
-		)
-		if createErr != nil {
+		controllerOwnerRef := *metav1.NewControllerRef(s.scope.GCPManagedControlPlane, infrav1exp.GroupVersion.WithKind("GCPManagedControlPlane"))
+		kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(clusterRef, out, controllerOwnerRef)
+		if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
 			return fmt.Errorf("creating additional kubeconfig secret: %w", err)
 		}
+
+		return nil
+	}
+
+	if bytes.Equal(configSecret.Data[secret.KubeconfigDataName], out) {
+		return nil
+	}
+
+	log.Info("Updating additional kubeconfig")
+	if configSecret.Data == nil {
+		configSecret.Data = map[string][]byte{}
+	}
+	configSecret.Data[secret.KubeconfigDataName] = out
+	if err := s.scope.Client().Update(ctx, configSecret); err != nil {
+		return fmt.Errorf("updating additional kubeconfig secret: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName) error {
-	controllerOwnerRef := *metav1.NewControllerRef(s.scope.GCPManagedControlPlane, infrav1exp.GroupVersion.WithKind("GCPManagedControlPlane"))
-
+// generateUserKubeconfig returns the kubeconfig for users of the cluster.
+func (s *Service) generateUserKubeconfig(cluster *containerpb.Cluster) ([]byte, error) {
 	contextName := s.getKubeConfigContextName(false)
+	userKubeconfig := s.scope.GCPManagedControlPlane.Spec.UserKubeconfig
+	if userKubeconfig == nil {
+		userKubeconfig = &infrav1exp.UserKubeconfig{}
+	}
 
 	cfg, err := s.createBaseKubeConfig(contextName, cluster)
 	if err != nil {
-		return fmt.Errorf("creating base kubeconfig: %w", err)
+		return nil, fmt.Errorf("creating base kubeconfig: %w", err)
 	}
 
-	execConfig := &api.ExecConfig{
-		APIVersion:         "client.authentication.k8s.io/v1beta1",
-		Command:            "gke-gcloud-auth-plugin",
-		InstallHint:        "Install gke-gcloud-auth-plugin for use with kubectl by following\n		https://cloud.google.com/blog/products/containers-kubernetes/kubectl-auth-changes-in-gke",
-		ProvideClusterInfo: true,
+	switch userKubeconfig.EndpointType {
+	case infrav1exp.PrivateEndpoint:
+		privateEndpoint := cluster.GetPrivateClusterConfig().GetPrivateEndpoint()
+		if privateEndpoint == "" {
+			return nil, errors.Errorf("cluster %s has no private endpoint", cluster.Name)
+		}
+		cfg.Clusters[contextName].Server = fmt.Sprintf("https://%s", privateEndpoint)
+	case infrav1exp.DNSEndpoint:
+		cfg.Clusters[contextName].Server = fmt.Sprintf("https://%s", userKubeconfig.DNSName)
 	}
-	cfg.AuthInfos = map[string]*api.AuthInfo{
-		contextName: {
-			Exec: execConfig,
-		},
+
+	switch userKubeconfig.AuthMethod {
+	case infrav1exp.NoAuth:
+		cfg.AuthInfos = map[string]*api.AuthInfo{
+			contextName: {},
+		}
+	case infrav1exp.ExecAuth:
+		if userKubeconfig.Exec == nil {
+			return nil, errors.New("exec config is required for the Exec auth method")
+		}
+		cfg.AuthInfos = map[string]*api.AuthInfo{
+			contextName: {
+				Exec: convertToExecConfig(userKubeconfig.Exec),
+			},
+		}
+	default:
+		cfg.AuthInfos = map[string]*api.AuthInfo{
+			contextName: {
+				Exec: &api.ExecConfig{
+					APIVersion:         "client.authentication.k8s.io/v1beta1",
+					Command:            "gke-gcloud-auth-plugin",
+					InstallHint:        "Install gke-gcloud-auth-plugin for use with kubectl by following\n		https://cloud.google.com/blog/products/containers-kubernetes/kubectl-auth-changes-in-gke",
+					ProvideClusterInfo: true,
+				},
+			},
+		}
 	}
 
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
-		return fmt.Errorf("serialize kubeconfig to yaml: %w", err)
+		return nil, fmt.Errorf("serialize kubeconfig to yaml: %w", err)
 	}
 
-	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
-	if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
-		return fmt.Errorf("creating secret: %w", err)
+	return out, nil
+}
+
+func convertToExecConfig(execConfig *infrav1exp.KubeconfigExecConfig) *api.ExecConfig {
+	apiVersion := execConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = "client.authentication.k8s.io/v1beta1"
 	}
 
-	return nil
+	env := make([]api.ExecEnvVar, len(execConfig.Env))
+	for i, envVar := range execConfig.Env {
+		env[i] = api.ExecEnvVar{
+			Name:  envVar.Name,
+			Value: envVar.Value,
+		}
+	}
+
+	return &api.ExecConfig{
+		APIVersion:         apiVersion,
+		Command:            execConfig.Command,
+		Args:               execConfig.Args,
+		Env:                env,
+		InstallHint:        execConfig.InstallHint,
+		ProvideClusterInfo: execConfig.ProvideClusterInfo,
+	}
 }
 
-func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName, log *logr.Logger) error {
+func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName, log *logr.Logger) (time.Time, error) {
 	controllerOwnerRef := *metav1.NewControllerRef(s.scope.GCPManagedControlPlane, infrav1exp.GroupVersion.WithKind("GCPManagedControlPlane"))
 
 	contextName := s.getKubeConfigContextName(false)
@@ -141,13 +265,13 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *conta
 	cfg, err := s.createBaseKubeConfig(contextName, cluster)
 	if err != nil {
 		log.Error(err, "failed creating base config")
-		return fmt.Errorf("creating base kubeconfig: %w", err)
+		return time.Time{}, fmt.Errorf("creating base kubeconfig: %w", err)
 	}
 
-	token, err := s.generateToken(ctx)
+	token, expiry, err := s.generateToken(ctx)
 	if err != nil {
 		log.Error(err, "failed generating token")
-		return err
+		return time.Time{}, err
 	}
 	cfg.AuthInfos = map[string]*api.AuthInfo{
 		contextName: {
@@ -158,50 +282,64 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *conta
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		log.Error(err, "failed serializing kubeconfig to yaml")
-		return fmt.Errorf("serialize kubeconfig to yaml: %w", err)
+		return time.Time{}, fmt.Errorf("serialize kubeconfig to yaml: %w", err)
 	}
 
 	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
+	s.setKubeconfigTokenAnnotations(kubeconfigSecret, expiry)
 	if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
 		log.Error(err, "failed creating secret")
-		return fmt.Errorf("creating secret: %w", err)
+		return time.Time{}, fmt.Errorf("creating secret: %w", err)
 	}
 
-	return nil
+	return expiry, nil
 }
 
-func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *corev1.Secret) error {
+func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *corev1.Secret) (time.Time, error) {
 	data, ok := configSecret.Data[secret.KubeconfigDataName]
 	if !ok {
-		return errors.Errorf("missing key %q in secret data", secret.KubeconfigDataName)
+		return time.Time{}, errors.Errorf("missing key %q in secret data", secret.KubeconfigDataName)
 	}
 
 	config, err := clientcmd.Load(data)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+		return time.Time{}, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
 	}
 
-	token, err := s.generateToken(ctx)
+	token, expiry, err := s.generateToken(ctx)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	contextName := s.getKubeConfigContextName(false)
-	config.AuthInfos[contextName].Token = token
+	authInfo, ok := config.AuthInfos[contextName]
+	if !ok {
+		return time.Time{}, errors.Errorf("missing user %q in kubeconfig", contextName)
+	}
+	authInfo.Token = token
 
 	out, err := clientcmd.Write(*config)
 	if err != nil {
-		return errors.Wrap(err, "failed to serialize config to yaml")
+		return time.Time{}, errors.Wrap(err, "failed to serialize config to yaml")
 	}
 
 	configSecret.Data[secret.KubeconfigDataName] = out
+	s.setKubeconfigTokenAnnotations(configSecret, expiry)
 
 	err = s.scope.Client().Update(ctx, configSecret)
 	if err != nil {
-		return fmt.Errorf("updating kubeconfig secret: %w", err)
+		return time.Time{}, fmt.Errorf("updating kubeconfig secret: %w", err)
 	}
 
-	return nil
+	return expiry, nil
+}
+
+func (s *Service) setKubeconfigTokenAnnotations(configSecret *corev1.Secret, expiry time.Time) {
+	if configSecret.Annotations == nil {
+		configSecret.Annotations = map[string]string{}
+	}
+	configSecret.Annotations[KubeconfigTokenExpiryAnnotation] = expiry.UTC().Format(time.RFC3339)
+	configSecret.Annotations[KubeconfigTokenServiceAccountAnnotation] = s.kubeconfigServiceAccount()
 }
 
 func (s *Service) getKubeConfigContextName(isUser bool) string {
@@ -237,16 +375,24 @@ func (s *Service) createBaseKubeConfig(contextName string, cluster *containerpb.
 	return cfg, nil
 }
 
-func (s *Service) generateToken(ctx context.Context) (string, error) {
+// kubeconfigServiceAccount returns the service account the access token of the kubeconfig used by Cluster API is generated for.
+func (s *Service) kubeconfigServiceAccount() string {
+	if serviceAccount := s.scope.GCPManagedControlPlane.Spec.KubeconfigServiceAccount; serviceAccount != nil {
+		return *serviceAccount
+	}
+	return s.scope.GetCredential().ClientEmail
+}
+
+func (s *Service) generateToken(ctx context.Context) (string, time.Time, error) {
 	req := &credentialspb.GenerateAccessTokenRequest{
-		Name: fmt.Sprintf("projects/-/serviceAccounts/%s", s.scope.GetCredential().ClientEmail),
+		Name: fmt.Sprintf("projects/-/serviceAccounts/%s", s.kubeconfigServiceAccount()),
 		Scope: []string{
 			GkeScope,
 		},
 	}
 	resp, err := s.scope.CredentialsClient().GenerateAccessToken(ctx, req)
 	if err != nil {
-		return "", errors.Errorf("error generating access token: %v", err)
+		return "", time.Time{}, errors.Errorf("error generating access token: %v", err)
 	}
-	return resp.AccessToken, nil
+	return resp.AccessToken, resp.GetExpireTime().AsTime(), nil
 }
@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+func TestKubeconfigTokenValid(t *testing.T) {
+	now := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+	serviceAccount := "capi-kubeconfig@my-project.iam.gserviceaccount.com"
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "token valid",
+			annotations: map[string]string{
+				KubeconfigTokenExpiryAnnotation:         "2023-11-01T10:30:00Z",
+				KubeconfigTokenServiceAccountAnnotation: serviceAccount,
+			},
+			want: true,
+		},
+		{
+			name: "token about to expire",
+			annotations: map[string]string{
+				KubeconfigTokenExpiryAnnotation:         "2023-11-01T10:05:00Z",
+				KubeconfigTokenServiceAccountAnnotation: serviceAccount,
+			},
+			want: false,
+		},
+		{
+			name: "token generated for another service account",
+			annotations: map[string]string{
+				KubeconfigTokenExpiryAnnotation:         "2023-11-01T10:30:00Z",
+				KubeconfigTokenServiceAccountAnnotation: "capg@my-project.iam.gserviceaccount.com",
+			},
+			want: false,
+		},
+		{
+			name: "expiry not recorded",
+			annotations: map[string]string{
+				KubeconfigTokenServiceAccountAnnotation: serviceAccount,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedControlPlaneScope{
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+					Spec: infrav1exp.GCPManagedControlPlaneSpec{KubeconfigServiceAccount: pointer.String(serviceAccount)},
+				},
+			})
+			configSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if _, got := s.kubeconfigTokenValid(configSecret, now); got != tt.want {
+				t.Errorf("kubeconfigTokenValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeconfigTokenRefreshAfter(t *testing.T) {
+	now := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	if got := kubeconfigTokenRefreshAfter(now.Add(time.Hour), now); got != 50*time.Minute {
+		t.Errorf("kubeconfigTokenRefreshAfter() = %v, want %v", got, 50*time.Minute)
+	}
+	if got := kubeconfigTokenRefreshAfter(now.Add(5*time.Minute), now); got != reconciler.DefaultRetryTime {
+		t.Errorf("kubeconfigTokenRefreshAfter() = %v, want %v", got, reconciler.DefaultRetryTime)
+	}
+}
+
+func TestGenerateUserKubeconfig(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:       "my-cluster",
+		Endpoint:   "34.1.2.3",
+		MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: base64.StdEncoding.EncodeToString([]byte("ca"))},
+		PrivateClusterConfig: &containerpb.PrivateClusterConfig{
+			PrivateEndpoint: "10.0.0.2",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		userKubeconfig *infrav1exp.UserKubeconfig
+		cluster        *containerpb.Cluster
+		wantServer     string
+		wantCommand    string
+		wantErr        bool
+	}{
+		{
+			name:        "defaults",
+			cluster:     cluster,
+			wantServer:  "https://34.1.2.3",
+			wantCommand: "gke-gcloud-auth-plugin",
+		},
+		{
+			name:           "private endpoint",
+			userKubeconfig: &infrav1exp.UserKubeconfig{EndpointType: infrav1exp.PrivateEndpoint},
+			cluster:        cluster,
+			wantServer:     "https://10.0.0.2",
+			wantCommand:    "gke-gcloud-auth-plugin",
+		},
+		{
+			name:           "private endpoint of a public cluster",
+			userKubeconfig: &infrav1exp.UserKubeconfig{EndpointType: infrav1exp.PrivateEndpoint},
+			cluster:        &containerpb.Cluster{Name: "my-cluster", Endpoint: "34.1.2.3", MasterAuth: cluster.MasterAuth},
+			wantErr:        true,
+		},
+		{
+			name: "dns endpoint with exec",
+			userKubeconfig: &infrav1exp.UserKubeconfig{
+				EndpointType: infrav1exp.DNSEndpoint,
+				DNSName:      "my-cluster.example.com",
+				AuthMethod:   infrav1exp.ExecAuth,
+				Exec:         &infrav1exp.KubeconfigExecConfig{Command: "my-plugin", Args: []string{"token"}},
+			},
+			cluster:     cluster,
+			wantServer:  "https://my-cluster.example.com",
+			wantCommand: "my-plugin",
+		},
+		{
+			name:           "no auth",
+			userKubeconfig: &infrav1exp.UserKubeconfig{AuthMethod: infrav1exp.NoAuth},
+			cluster:        cluster,
+			wantServer:     "https://34.1.2.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedControlPlaneScope{
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+					Spec: infrav1exp.GCPManagedControlPlaneSpec{
+						ClusterName:    "my-cluster",
+						Project:        "my-project",
+						Location:       "us-central1",
+						UserKubeconfig: tt.userKubeconfig,
+					},
+				},
+			})
+			out, err := s.generateUserKubeconfig(tt.cluster)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateUserKubeconfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			cfg, err := clientcmd.Load(out)
+			if err != nil {
+				t.Fatalf("loading kubeconfig: %v", err)
+			}
+			contextName := s.getKubeConfigContextName(false)
+			if got := cfg.Clusters[contextName].Server; got != tt.wantServer {
+				t.Errorf("server = %q, want %q", got, tt.wantServer)
+			}
+			gotCommand := ""
+			if exec := cfg.AuthInfos[contextName].Exec; exec != nil {
+				gotCommand = exec.Command
+			}
+			if gotCommand != tt.wantCommand {
+				t.Errorf("exec command = %q, want %q", gotCommand, tt.wantCommand)
+			}
+		})
+	}
+}
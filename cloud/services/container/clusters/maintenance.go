@@ -0,0 +1,269 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+const (
+	// maxMaintenanceLookahead is how far in the future the next maintenance window is searched for.
+	maxMaintenanceLookahead = 366 * 24 * time.Hour
+	// dailyMaintenanceWindowDuration is the duration GKE gives to daily maintenance windows.
+	dailyMaintenanceWindowDuration = 4 * time.Hour
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// convertToSdkMaintenancePolicy converts the MaintenancePolicy defined in CRs to the SDK version.
+func convertToSdkMaintenancePolicy(policy *infrav1exp.MaintenancePolicy) *containerpb.MaintenancePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	window := &containerpb.MaintenanceWindow{}
+	switch {
+	case policy.DailyMaintenanceWindow != nil:
+		window.Policy = &containerpb.MaintenanceWindow_DailyMaintenanceWindow{
+			DailyMaintenanceWindow: &containerpb.DailyMaintenanceWindow{
+				StartTime: policy.DailyMaintenanceWindow.StartTime,
+			},
+		}
+	case policy.RecurringMaintenanceWindow != nil:
+		window.Policy = &containerpb.MaintenanceWindow_RecurringWindow{
+			RecurringWindow: &containerpb.RecurringTimeWindow{
+				Window: &containerpb.TimeWindow{
+					StartTime: timestamppb.New(policy.RecurringMaintenanceWindow.StartTime.Time),
+					EndTime:   timestamppb.New(policy.RecurringMaintenanceWindow.EndTime.Time),
+				},
+				Recurrence: policy.RecurringMaintenanceWindow.Recurrence,
+			},
+		}
+	}
+
+	if len(policy.MaintenanceExclusions) > 0 {
+		window.MaintenanceExclusions = map[string]*containerpb.TimeWindow{}
+		for _, exclusion := range policy.MaintenanceExclusions {
+			window.MaintenanceExclusions[exclusion.Name] = &containerpb.TimeWindow{
+				StartTime: timestamppb.New(exclusion.StartTime.Time),
+				EndTime:   timestamppb.New(exclusion.EndTime.Time),
+				Options: &containerpb.TimeWindow_MaintenanceExclusionOptions{
+					MaintenanceExclusionOptions: &containerpb.MaintenanceExclusionOptions{
+						Scope: convertToSdkMaintenanceExclusionScope(exclusion.Scope),
+					},
+				},
+			}
+		}
+	}
+
+	return &containerpb.MaintenancePolicy{
+		Window: window,
+	}
+}
+
+func convertToSdkMaintenanceExclusionScope(scope *infrav1exp.MaintenanceExclusionScope) containerpb.MaintenanceExclusionOptions_Scope {
+	if scope == nil {
+		return containerpb.MaintenanceExclusionOptions_NO_UPGRADES
+	}
+	switch *scope {
+	case infrav1exp.NoMinorUpgrades:
+		return containerpb.MaintenanceExclusionOptions_NO_MINOR_UPGRADES
+	case infrav1exp.NoMinorOrNodeUpgrades:
+		return containerpb.MaintenanceExclusionOptions_NO_MINOR_OR_NODE_UPGRADES
+	default:
+		return containerpb.MaintenanceExclusionOptions_NO_UPGRADES
+	}
+}
+
+// compareMaintenanceWindow returns true if the two maintenance windows are equal, ignoring output only fields.
+func compareMaintenanceWindow(a, b *containerpb.MaintenanceWindow) bool {
+	normalize := func(w *containerpb.MaintenanceWindow) *containerpb.MaintenanceWindow {
+		if w == nil {
+			return &containerpb.MaintenanceWindow{}
+		}
+		w = proto.Clone(w).(*containerpb.MaintenanceWindow)
+		if daily := w.GetDailyMaintenanceWindow(); daily != nil {
+			daily.Duration = ""
+		}
+		if len(w.MaintenanceExclusions) == 0 {
+			w.MaintenanceExclusions = nil
+		}
+		return w
+	}
+
+	return proto.Equal(normalize(a), normalize(b))
+}
+
+func (s *Service) checkDiffAndPrepareMaintenancePolicy(existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.SetMaintenancePolicyRequest) {
+	desiredMaintenancePolicy := convertToSdkMaintenancePolicy(s.scope.GCPManagedControlPlane.Spec.MaintenancePolicy)
+	if desiredMaintenancePolicy == nil {
+		return false, nil
+	}
+
+	if compareMaintenanceWindow(desiredMaintenancePolicy.Window, existingCluster.GetMaintenancePolicy().GetWindow()) {
+		return false, nil
+	}
+	log.V(2).Info("Maintenance policy update required", "current", existingCluster.GetMaintenancePolicy().GetWindow(), "desired", desiredMaintenancePolicy.Window)
+
+	// The resource version is used by GKE for optimistic concurrency control.
+	desiredMaintenancePolicy.ResourceVersion = existingCluster.GetMaintenancePolicy().GetResourceVersion()
+
+	return true, &containerpb.SetMaintenancePolicyRequest{
+		Name:              s.scope.ClusterFullName(),
+		MaintenancePolicy: desiredMaintenancePolicy,
+	}
+}
+
+func (s *Service) setMaintenancePolicy(ctx context.Context, setMaintenancePolicyRequest *containerpb.SetMaintenancePolicyRequest, log *logr.Logger) error {
+	op, err := s.scope.ManagedControlPlaneClient().SetMaintenancePolicy(ctx, setMaintenancePolicyRequest)
+	if err != nil {
+		log.Error(err, "Error setting GKE cluster maintenance policy", "name", s.scope.ClusterName())
+		return err
+	}
+	s.setPendingOperation(op)
+
+	return nil
+}
+
+// nextMaintenanceWindow returns the start of the next maintenance window after now that doesn't
+// overlap a maintenance exclusion preventing all upgrades. It returns nil if there is no
+// window configured or if the recurrence rule isn't supported.
+func nextMaintenanceWindow(policy *infrav1exp.MaintenancePolicy, now time.Time) *metav1.Time {
+	if policy == nil {
+		return nil
+	}
+
+	var next func(after time.Time) (time.Time, bool)
+	var duration time.Duration
+	switch {
+	case policy.DailyMaintenanceWindow != nil:
+		duration = dailyMaintenanceWindowDuration
+		startTime, err := time.Parse("15:04", policy.DailyMaintenanceWindow.StartTime)
+		if err != nil {
+			return nil
+		}
+		next = func(after time.Time) (time.Time, bool) {
+			after = after.UTC()
+			candidate := time.Date(after.Year(), after.Month(), after.Day(), startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
+			if !candidate.After(after) {
+				candidate = candidate.AddDate(0, 0, 1)
+			}
+			return candidate, true
+		}
+	case policy.RecurringMaintenanceWindow != nil:
+		next = recurringWindowIterator(policy.RecurringMaintenanceWindow)
+		duration = policy.RecurringMaintenanceWindow.EndTime.Sub(policy.RecurringMaintenanceWindow.StartTime.Time)
+	default:
+		return nil
+	}
+
+	after := now
+	for after.Sub(now) < maxMaintenanceLookahead {
+		candidate, ok := next(after)
+		if !ok {
+			return nil
+		}
+		if !isExcluded(policy.MaintenanceExclusions, candidate, candidate.Add(duration)) {
+			return &metav1.Time{Time: candidate}
+		}
+		after = candidate
+	}
+
+	return nil
+}
+
+// recurringWindowIterator returns a function computing the next window start after a given time.
+// Only the DAILY and WEEKLY frequencies, optionally restricted with BYDAY, are supported.
+func recurringWindowIterator(window *infrav1exp.RecurringMaintenanceWindow) func(after time.Time) (time.Time, bool) {
+	var freq string
+	days := map[time.Weekday]bool{}
+	for _, part := range strings.Split(strings.TrimPrefix(window.Recurrence, "RRULE:"), ";") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "FREQ":
+			freq = value
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return func(time.Time) (time.Time, bool) { return time.Time{}, false }
+				}
+				days[weekday] = true
+			}
+		case "INTERVAL":
+			if value != "1" {
+				return func(time.Time) (time.Time, bool) { return time.Time{}, false }
+			}
+		}
+	}
+
+	start := window.StartTime.UTC()
+	switch freq {
+	case "DAILY":
+	case "WEEKLY":
+		if len(days) == 0 {
+			days[start.Weekday()] = true
+		}
+	default:
+		return func(time.Time) (time.Time, bool) { return time.Time{}, false }
+	}
+
+	return func(after time.Time) (time.Time, bool) {
+		candidate := start
+		if after.After(candidate) {
+			after = after.UTC()
+			candidate = time.Date(after.Year(), after.Month(), after.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+		}
+		for i := 0; i < 8; i++ {
+			if candidate.After(after) && (len(days) == 0 || days[candidate.Weekday()]) {
+				return candidate, true
+			}
+			candidate = candidate.AddDate(0, 0, 1)
+		}
+		return time.Time{}, false
+	}
+}
+
+// isExcluded returns true if the window from start to end overlaps a maintenance exclusion preventing all upgrades.
+func isExcluded(exclusions []infrav1exp.MaintenanceExclusion, start, end time.Time) bool {
+	for _, exclusion := range exclusions {
+		if exclusion.Scope != nil && *exclusion.Scope != infrav1exp.NoUpgrades {
+			continue
+		}
+		if start.Before(exclusion.EndTime.Time) && end.After(exclusion.StartTime.Time) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+	minorOnly := infrav1exp.NoMinorUpgrades
+
+	tests := []struct {
+		name   string
+		policy *infrav1exp.MaintenancePolicy
+		want   *time.Time
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+			want:   nil,
+		},
+		{
+			name: "daily window later today",
+			policy: &infrav1exp.MaintenancePolicy{
+				DailyMaintenanceWindow: &infrav1exp.DailyMaintenanceWindow{StartTime: "22:00"},
+			},
+			want: timePtr(time.Date(2023, 11, 1, 22, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "daily window already passed today",
+			policy: &infrav1exp.MaintenancePolicy{
+				DailyMaintenanceWindow: &infrav1exp.DailyMaintenanceWindow{StartTime: "03:00"},
+			},
+			want: timePtr(time.Date(2023, 11, 2, 3, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "daily window skips exclusion",
+			policy: &infrav1exp.MaintenancePolicy{
+				DailyMaintenanceWindow: &infrav1exp.DailyMaintenanceWindow{StartTime: "03:00"},
+				MaintenanceExclusions: []infrav1exp.MaintenanceExclusion{
+					{
+						Name:      "freeze",
+						StartTime: metav1.NewTime(time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)),
+						EndTime:   metav1.NewTime(time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			want: timePtr(time.Date(2023, 11, 4, 3, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "daily window skips exclusion starting during the window",
+			policy: &infrav1exp.MaintenancePolicy{
+				DailyMaintenanceWindow: &infrav1exp.DailyMaintenanceWindow{StartTime: "22:00"},
+				MaintenanceExclusions: []infrav1exp.MaintenanceExclusion{
+					{
+						Name:      "freeze",
+						StartTime: metav1.NewTime(time.Date(2023, 11, 2, 0, 0, 0, 0, time.UTC)),
+						EndTime:   metav1.NewTime(time.Date(2023, 11, 2, 12, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			want: timePtr(time.Date(2023, 11, 2, 22, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "recurring window skips exclusion ending during the window",
+			policy: &infrav1exp.MaintenancePolicy{
+				RecurringMaintenanceWindow: &infrav1exp.RecurringMaintenanceWindow{
+					StartTime:  metav1.NewTime(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)),
+					EndTime:    metav1.NewTime(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)),
+					Recurrence: "FREQ=DAILY",
+				},
+				MaintenanceExclusions: []infrav1exp.MaintenanceExclusion{
+					{
+						Name:      "freeze",
+						StartTime: metav1.NewTime(time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)),
+						EndTime:   metav1.NewTime(time.Date(2023, 11, 2, 6, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			want: timePtr(time.Date(2023, 11, 3, 5, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "minor upgrade exclusion does not block maintenance",
+			policy: &infrav1exp.MaintenancePolicy{
+				DailyMaintenanceWindow: &infrav1exp.DailyMaintenanceWindow{StartTime: "03:00"},
+				MaintenanceExclusions: []infrav1exp.MaintenanceExclusion{
+					{
+						Name:      "minor-freeze",
+						StartTime: metav1.NewTime(time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)),
+						EndTime:   metav1.NewTime(time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC)),
+						Scope:     &minorOnly,
+					},
+				},
+			},
+			want: timePtr(time.Date(2023, 11, 2, 3, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "weekly recurring window on weekends",
+			policy: &infrav1exp.MaintenancePolicy{
+				RecurringMaintenanceWindow: &infrav1exp.RecurringMaintenanceWindow{
+					StartTime:  metav1.NewTime(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)),
+					EndTime:    metav1.NewTime(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)),
+					Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+				},
+			},
+			want: timePtr(time.Date(2023, 11, 4, 5, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "recurring window starting in the future",
+			policy: &infrav1exp.MaintenancePolicy{
+				RecurringMaintenanceWindow: &infrav1exp.RecurringMaintenanceWindow{
+					StartTime:  metav1.NewTime(time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)),
+					EndTime:    metav1.NewTime(time.Date(2023, 12, 1, 9, 0, 0, 0, time.UTC)),
+					Recurrence: "FREQ=DAILY",
+				},
+			},
+			want: timePtr(time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "unsupported recurrence",
+			policy: &infrav1exp.MaintenancePolicy{
+				RecurringMaintenanceWindow: &infrav1exp.RecurringMaintenanceWindow{
+					StartTime:  metav1.NewTime(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)),
+					EndTime:    metav1.NewTime(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)),
+					Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1",
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextMaintenanceWindow(tt.policy, now)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("nextMaintenanceWindow() = %v, want nil", got)
+			case tt.want != nil && (got == nil || !got.Time.Equal(*tt.want)):
+				t.Errorf("nextMaintenanceWindow() = %v, want %v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// convertToSdkNetworkConfig converts the networking configuration and the Gateway API channel defined in CRs
+// to the SDK version of the network config of the cluster.
+func convertToSdkNetworkConfig(networking *infrav1exp.ClusterNetworking, addons *infrav1exp.AddonsConfig) *containerpb.NetworkConfig {
+	gatewayAPIConfig := convertToSdkGatewayAPIConfig(addons)
+	if networking == nil && gatewayAPIConfig == nil {
+		return nil
+	}
+
+	networkConfig := &containerpb.NetworkConfig{
+		GatewayApiConfig: gatewayAPIConfig,
+	}
+	if networking == nil {
+		return networkConfig
+	}
+
+	networkConfig.DatapathProvider = convertToSdkDatapathProvider(networking.DatapathProvider)
+	if networking.EnableIntraNodeVisibility != nil {
+		networkConfig.EnableIntraNodeVisibility = *networking.EnableIntraNodeVisibility
+	}
+	if networking.DisableDefaultSNAT != nil {
+		networkConfig.DefaultSnatStatus = &containerpb.DefaultSnatStatus{
+			Disabled: *networking.DisableDefaultSNAT,
+		}
+	}
+	networkConfig.DnsConfig = convertToSdkDNSConfig(networking.DNS)
+
+	return networkConfig
+}
+
+func convertToSdkDatapathProvider(provider *infrav1exp.DatapathProvider) containerpb.DatapathProvider {
+	if provider == nil {
+		return containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED
+	}
+
+	switch *provider {
+	case infrav1exp.LegacyDatapath:
+		return containerpb.DatapathProvider_LEGACY_DATAPATH
+	case infrav1exp.AdvancedDatapath:
+		return containerpb.DatapathProvider_ADVANCED_DATAPATH
+	default:
+		return containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED
+	}
+}
+
+// convertToSdkDNSConfig converts the ClusterDNS defined in CRs to the SDK version.
+func convertToSdkDNSConfig(dns *infrav1exp.ClusterDNS) *containerpb.DNSConfig {
+	if dns == nil {
+		return nil
+	}
+
+	dnsConfig := &containerpb.DNSConfig{
+		ClusterDnsDomain: dns.Domain,
+	}
+	switch dns.Provider {
+	case infrav1exp.PlatformDefaultDNS:
+		dnsConfig.ClusterDns = containerpb.DNSConfig_PLATFORM_DEFAULT
+	case infrav1exp.CloudDNS:
+		dnsConfig.ClusterDns = containerpb.DNSConfig_CLOUD_DNS
+	case infrav1exp.KubeDNS:
+		dnsConfig.ClusterDns = containerpb.DNSConfig_KUBE_DNS
+	}
+	if dns.Scope != nil {
+		switch *dns.Scope {
+		case infrav1exp.ClusterDNSScopeCluster:
+			dnsConfig.ClusterDnsScope = containerpb.DNSConfig_CLUSTER_SCOPE
+		case infrav1exp.ClusterDNSScopeVPC:
+			dnsConfig.ClusterDnsScope = containerpb.DNSConfig_VPC_SCOPE
+		}
+	}
+
+	return dnsConfig
+}
+
+// convertToSdkNetworkPolicy converts the network policy setting defined in CRs to the SDK version.
+func convertToSdkNetworkPolicy(networking *infrav1exp.ClusterNetworking) *containerpb.NetworkPolicy {
+	if networking == nil || networking.EnableNetworkPolicy == nil {
+		return nil
+	}
+
+	networkPolicy := &containerpb.NetworkPolicy{
+		Enabled: *networking.EnableNetworkPolicy,
+	}
+	if networkPolicy.Enabled {
+		networkPolicy.Provider = containerpb.NetworkPolicy_CALICO
+	}
+
+	return networkPolicy
+}
+
+// compareDNSConfig returns true if the DNS settings specified in desired match the existing ones.
+func compareDNSConfig(desired, existing *containerpb.DNSConfig) bool {
+	if desired == nil {
+		return true
+	}
+
+	existingProvider := existing.GetClusterDns()
+	if existingProvider == containerpb.DNSConfig_PROVIDER_UNSPECIFIED {
+		existingProvider = containerpb.DNSConfig_PLATFORM_DEFAULT
+	}
+	if desired.ClusterDns != existingProvider {
+		return false
+	}
+	if desired.ClusterDnsScope != containerpb.DNSConfig_DNS_SCOPE_UNSPECIFIED && desired.ClusterDnsScope != existing.GetClusterDnsScope() {
+		return false
+	}
+	if desired.ClusterDnsDomain != "" && desired.ClusterDnsDomain != existing.GetClusterDnsDomain() {
+		return false
+	}
+
+	return true
+}
+
+// compareDatapathProvider returns true if the datapath provider specified in desired matches the existing one.
+func compareDatapathProvider(desired, existing containerpb.DatapathProvider) bool {
+	if desired == containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED {
+		return true
+	}
+	// Clusters created without a datapath provider use the legacy datapath.
+	if existing == containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED {
+		existing = containerpb.DatapathProvider_LEGACY_DATAPATH
+	}
+
+	return desired == existing
+}
+
+// networkingUpdates returns the updates of the mutable networking settings that differ between the spec and the
+// network config of the cluster, each carrying a single desired setting.
+func networkingUpdates(networking *infrav1exp.ClusterNetworking, existing *containerpb.NetworkConfig) []*containerpb.ClusterUpdate {
+	if networking == nil {
+		return nil
+	}
+
+	clusterUpdates := []*containerpb.ClusterUpdate{}
+	if networking.EnableIntraNodeVisibility != nil && *networking.EnableIntraNodeVisibility != existing.GetEnableIntraNodeVisibility() {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredIntraNodeVisibilityConfig: &containerpb.IntraNodeVisibilityConfig{
+				Enabled: *networking.EnableIntraNodeVisibility,
+			},
+		})
+	}
+	if networking.DisableDefaultSNAT != nil && *networking.DisableDefaultSNAT != existing.GetDefaultSnatStatus().GetDisabled() {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredDefaultSnatStatus: &containerpb.DefaultSnatStatus{
+				Disabled: *networking.DisableDefaultSNAT,
+			},
+		})
+	}
+	if desiredDNSConfig := convertToSdkDNSConfig(networking.DNS); !compareDNSConfig(desiredDNSConfig, existing.GetDnsConfig()) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredDnsConfig: desiredDNSConfig,
+		})
+	}
+
+	return clusterUpdates
+}
+
+// networkPolicyUpdate returns the network policy to set when its enforcement differs between the spec and the cluster.
+func networkPolicyUpdate(networking *infrav1exp.ClusterNetworking, existing *containerpb.NetworkPolicy) *containerpb.NetworkPolicy {
+	desiredNetworkPolicy := convertToSdkNetworkPolicy(networking)
+	if desiredNetworkPolicy == nil || desiredNetworkPolicy.Enabled == existing.GetEnabled() {
+		return nil
+	}
+
+	return desiredNetworkPolicy
+}
+
+// networkPolicyAddonUpdate returns the update enabling the network policy addon when network policies have to be
+// enforced on a cluster without it, GKE rejects enabling Calico until the addon is enabled.
+func networkPolicyAddonUpdate(networking *infrav1exp.ClusterNetworking, existingCluster *containerpb.Cluster) *containerpb.ClusterUpdate {
+	desiredNetworkPolicy := networkPolicyUpdate(networking, existingCluster.GetNetworkPolicy())
+	if !desiredNetworkPolicy.GetEnabled() || !existingCluster.GetAddonsConfig().GetNetworkPolicyConfig().GetDisabled() {
+		return nil
+	}
+
+	return &containerpb.ClusterUpdate{
+		DesiredAddonsConfig: &containerpb.AddonsConfig{
+			NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: false},
+		},
+	}
+}
+
+// checkDiffAndPrepareNetworkPolicy checks whether the enforcement of network policies with Calico has to be updated.
+// Enabling it waits for the network policy addon, which is enabled by a cluster update first.
+func (s *Service) checkDiffAndPrepareNetworkPolicy(existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.SetNetworkPolicyRequest) {
+	desiredNetworkPolicy := networkPolicyUpdate(s.scope.GCPManagedControlPlane.Spec.Networking, existingCluster.GetNetworkPolicy())
+	if desiredNetworkPolicy == nil {
+		return false, nil
+	}
+	if desiredNetworkPolicy.Enabled && existingCluster.GetAddonsConfig().GetNetworkPolicyConfig().GetDisabled() {
+		log.V(2).Info("Network policy update waiting for the network policy addon to be enabled")
+		return false, nil
+	}
+	log.V(2).Info("Network policy update required", "current", existingCluster.GetNetworkPolicy(), "desired", desiredNetworkPolicy)
+
+	return true, &containerpb.SetNetworkPolicyRequest{
+		Name:          s.scope.ClusterFullName(),
+		NetworkPolicy: desiredNetworkPolicy,
+	}
+}
+
+func (s *Service) setNetworkPolicy(ctx context.Context, setNetworkPolicyRequest *containerpb.SetNetworkPolicyRequest, log *logr.Logger) error {
+	op, err := s.scope.ManagedControlPlaneClient().SetNetworkPolicy(ctx, setNetworkPolicyRequest)
+	if err != nil {
+		log.Error(err, "Error setting GKE cluster network policy", "name", s.scope.ClusterName())
+		return err
+	}
+	s.setPendingOperation(op)
+
+	return nil
+}
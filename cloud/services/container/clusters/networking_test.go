@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestConvertToSdkNetworkConfig(t *testing.T) {
+	advancedDatapath := infrav1exp.AdvancedDatapath
+	standardChannel := infrav1exp.GatewayAPIChannelStandard
+
+	if got := convertToSdkNetworkConfig(nil, nil); got != nil {
+		t.Errorf("convertToSdkNetworkConfig() = %v, want nil", got)
+	}
+
+	got := convertToSdkNetworkConfig(
+		&infrav1exp.ClusterNetworking{
+			DatapathProvider:   &advancedDatapath,
+			DisableDefaultSNAT: pointer.Bool(true),
+			DNS:                &infrav1exp.ClusterDNS{Provider: infrav1exp.CloudDNS},
+		},
+		&infrav1exp.AddonsConfig{GatewayAPIChannel: &standardChannel},
+	)
+	if got.DatapathProvider != containerpb.DatapathProvider_ADVANCED_DATAPATH {
+		t.Errorf("DatapathProvider = %v, want %v", got.DatapathProvider, containerpb.DatapathProvider_ADVANCED_DATAPATH)
+	}
+	if !got.GetDefaultSnatStatus().GetDisabled() {
+		t.Errorf("DefaultSnatStatus.Disabled = false, want true")
+	}
+	if got.GetDnsConfig().GetClusterDns() != containerpb.DNSConfig_CLOUD_DNS {
+		t.Errorf("DnsConfig.ClusterDns = %v, want %v", got.GetDnsConfig().GetClusterDns(), containerpb.DNSConfig_CLOUD_DNS)
+	}
+	if got.GetGatewayApiConfig().GetChannel() != containerpb.GatewayAPIConfig_CHANNEL_STANDARD {
+		t.Errorf("GatewayApiConfig.Channel = %v, want %v", got.GetGatewayApiConfig().GetChannel(), containerpb.GatewayAPIConfig_CHANNEL_STANDARD)
+	}
+}
+
+func TestNetworkingUpdates(t *testing.T) {
+	vpcScope := infrav1exp.ClusterDNSScopeVPC
+	existingCluster := &containerpb.Cluster{
+		NetworkConfig: &containerpb.NetworkConfig{
+			EnableIntraNodeVisibility: true,
+			DnsConfig:                 &containerpb.DNSConfig{ClusterDns: containerpb.DNSConfig_CLOUD_DNS, ClusterDnsScope: containerpb.DNSConfig_CLUSTER_SCOPE},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		networking *infrav1exp.ClusterNetworking
+		want       []string
+	}{
+		{
+			name:       "not specified",
+			networking: nil,
+			want:       []string{},
+		},
+		{
+			name: "matches",
+			networking: &infrav1exp.ClusterNetworking{
+				EnableIntraNodeVisibility: pointer.Bool(true),
+				DisableDefaultSNAT:        pointer.Bool(false),
+				DNS:                       &infrav1exp.ClusterDNS{Provider: infrav1exp.CloudDNS},
+			},
+			want: []string{},
+		},
+		{
+			name:       "intranode visibility disabled",
+			networking: &infrav1exp.ClusterNetworking{EnableIntraNodeVisibility: pointer.Bool(false)},
+			want:       []string{"desired_intra_node_visibility_config"},
+		},
+		{
+			name:       "default snat disabled",
+			networking: &infrav1exp.ClusterNetworking{DisableDefaultSNAT: pointer.Bool(true)},
+			want:       []string{"desired_default_snat_status"},
+		},
+		{
+			name:       "dns scope changed",
+			networking: &infrav1exp.ClusterNetworking{DNS: &infrav1exp.ClusterDNS{Provider: infrav1exp.CloudDNS, Scope: &vpcScope}},
+			want:       []string{"desired_dns_config"},
+		},
+		{
+			name: "several settings changed",
+			networking: &infrav1exp.ClusterNetworking{
+				EnableIntraNodeVisibility: pointer.Bool(false),
+				DisableDefaultSNAT:        pointer.Bool(true),
+			},
+			want: []string{"desired_intra_node_visibility_config", "desired_default_snat_status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, desiredSettings(networkingUpdates(tt.networking, existingCluster.NetworkConfig))); diff != "" {
+				t.Errorf("networkingUpdates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareDatapathProvider(t *testing.T) {
+	if !compareDatapathProvider(containerpb.DatapathProvider_LEGACY_DATAPATH, containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED) {
+		t.Errorf("compareDatapathProvider() = false for the legacy datapath of a cluster without datapath provider")
+	}
+	if compareDatapathProvider(containerpb.DatapathProvider_ADVANCED_DATAPATH, containerpb.DatapathProvider_LEGACY_DATAPATH) {
+		t.Errorf("compareDatapathProvider() = true for different datapath providers")
+	}
+}
+
+func TestEnableNetworkPolicy(t *testing.T) {
+	s := New(&scope.ManagedControlPlaneScope{
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ClusterName: "test-cluster",
+				Project:     "test-project",
+				Location:    "us-central1",
+				Networking:  &infrav1exp.ClusterNetworking{EnableNetworkPolicy: pointer.Bool(true)},
+			},
+		},
+	})
+	existingCluster := &containerpb.Cluster{
+		ReleaseChannel:                 &containerpb.ReleaseChannel{},
+		MasterAuthorizedNetworksConfig: convertToSdkMasterAuthorizedNetworksConfig(nil),
+		AddonsConfig:                   &containerpb.AddonsConfig{NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: true}},
+		NetworkPolicy:                  &containerpb.NetworkPolicy{Enabled: false},
+	}
+	log := logr.Discard()
+
+	// The network policy addon is enabled first.
+	if diff := cmp.Diff([]string{"desired_addons_config"}, desiredSettings(s.checkDiffAndPrepareClusterUpdates(existingCluster, &log))); diff != "" {
+		t.Errorf("checkDiffAndPrepareClusterUpdates() mismatch (-want +got):\n%s", diff)
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareNetworkPolicy(existingCluster, &log); needUpdate {
+		t.Errorf("checkDiffAndPrepareNetworkPolicy() = true while the network policy addon is disabled, want false")
+	}
+
+	// Network policies are enforced once the addon is enabled.
+	existingCluster.AddonsConfig.NetworkPolicyConfig.Disabled = false
+	if diff := cmp.Diff([]string{}, desiredSettings(s.checkDiffAndPrepareClusterUpdates(existingCluster, &log))); diff != "" {
+		t.Errorf("checkDiffAndPrepareClusterUpdates() mismatch (-want +got):\n%s", diff)
+	}
+	needUpdate, request := s.checkDiffAndPrepareNetworkPolicy(existingCluster, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareNetworkPolicy() = false once the network policy addon is enabled, want true")
+	}
+	if request.NetworkPolicy.Provider != containerpb.NetworkPolicy_CALICO || !request.NetworkPolicy.Enabled {
+		t.Errorf("NetworkPolicy = %v, want Calico enabled", request.NetworkPolicy)
+	}
+}
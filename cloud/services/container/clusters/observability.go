@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// convertToSdkLoggingConfig converts the LoggingConfig defined in CRs to the SDK version.
+func convertToSdkLoggingConfig(config *infrav1exp.LoggingConfig) *containerpb.LoggingConfig {
+	if config == nil {
+		return nil
+	}
+
+	components := make([]containerpb.LoggingComponentConfig_Component, 0, len(config.EnableComponents))
+	for _, component := range config.EnableComponents {
+		components = append(components, containerpb.LoggingComponentConfig_Component(containerpb.LoggingComponentConfig_Component_value[string(component)]))
+	}
+
+	return &containerpb.LoggingConfig{
+		ComponentConfig: &containerpb.LoggingComponentConfig{
+			EnableComponents: components,
+		},
+	}
+}
+
+// convertToSdkMonitoringConfig converts the MonitoringConfig defined in CRs to the SDK version.
+// Only the settings that are specified are set, the others are left unchanged.
+func convertToSdkMonitoringConfig(config *infrav1exp.MonitoringConfig) *containerpb.MonitoringConfig {
+	if config == nil {
+		return nil
+	}
+
+	monitoringConfig := &containerpb.MonitoringConfig{}
+	if len(config.EnableComponents) > 0 {
+		components := make([]containerpb.MonitoringComponentConfig_Component, 0, len(config.EnableComponents))
+		for _, component := range config.EnableComponents {
+			components = append(components, containerpb.MonitoringComponentConfig_Component(containerpb.MonitoringComponentConfig_Component_value[string(component)]))
+		}
+		monitoringConfig.ComponentConfig = &containerpb.MonitoringComponentConfig{
+			EnableComponents: components,
+		}
+	}
+	if config.ManagedPrometheus != nil {
+		monitoringConfig.ManagedPrometheusConfig = &containerpb.ManagedPrometheusConfig{
+			Enabled: *config.ManagedPrometheus,
+		}
+	}
+
+	return monitoringConfig
+}
+
+// compareLoggingConfig returns true if the logging components in desired match the existing ones.
+func compareLoggingConfig(desired, existing *containerpb.LoggingConfig) bool {
+	if desired == nil {
+		return true
+	}
+
+	return sets.New(desired.GetComponentConfig().GetEnableComponents()...).Equal(sets.New(existing.GetComponentConfig().GetEnableComponents()...))
+}
+
+// compareMonitoringConfig returns true if the monitoring settings specified in desired match the existing ones.
+func compareMonitoringConfig(desired, existing *containerpb.MonitoringConfig) bool {
+	if desired == nil {
+		return true
+	}
+
+	if desired.ComponentConfig != nil && !sets.New(desired.GetComponentConfig().GetEnableComponents()...).Equal(sets.New(existing.GetComponentConfig().GetEnableComponents()...)) {
+		return false
+	}
+	if desired.ManagedPrometheusConfig != nil && desired.GetManagedPrometheusConfig().GetEnabled() != existing.GetManagedPrometheusConfig().GetEnabled() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestCompareLoggingConfig(t *testing.T) {
+	existing := &containerpb.LoggingConfig{
+		ComponentConfig: &containerpb.LoggingComponentConfig{
+			EnableComponents: []containerpb.LoggingComponentConfig_Component{
+				containerpb.LoggingComponentConfig_SYSTEM_COMPONENTS,
+				containerpb.LoggingComponentConfig_WORKLOADS,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		logging *infrav1exp.LoggingConfig
+		want    bool
+	}{
+		{
+			name:    "not specified",
+			logging: nil,
+			want:    true,
+		},
+		{
+			name: "same components in another order",
+			logging: &infrav1exp.LoggingConfig{
+				EnableComponents: []infrav1exp.LoggingComponent{infrav1exp.LoggingWorkloads, infrav1exp.LoggingSystemComponents},
+			},
+			want: true,
+		},
+		{
+			name: "additional component",
+			logging: &infrav1exp.LoggingConfig{
+				EnableComponents: []infrav1exp.LoggingComponent{infrav1exp.LoggingSystemComponents, infrav1exp.LoggingWorkloads, infrav1exp.LoggingAPIServer},
+			},
+			want: false,
+		},
+		{
+			name:    "logging disabled",
+			logging: &infrav1exp.LoggingConfig{},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareLoggingConfig(convertToSdkLoggingConfig(tt.logging), existing); got != tt.want {
+				t.Errorf("compareLoggingConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareMonitoringConfig(t *testing.T) {
+	existing := &containerpb.MonitoringConfig{
+		ComponentConfig: &containerpb.MonitoringComponentConfig{
+			EnableComponents: []containerpb.MonitoringComponentConfig_Component{
+				containerpb.MonitoringComponentConfig_SYSTEM_COMPONENTS,
+			},
+		},
+		ManagedPrometheusConfig: &containerpb.ManagedPrometheusConfig{Enabled: true},
+	}
+
+	tests := []struct {
+		name       string
+		monitoring *infrav1exp.MonitoringConfig
+		want       bool
+	}{
+		{
+			name:       "not specified",
+			monitoring: nil,
+			want:       true,
+		},
+		{
+			name:       "managed prometheus only",
+			monitoring: &infrav1exp.MonitoringConfig{ManagedPrometheus: pointer.Bool(true)},
+			want:       true,
+		},
+		{
+			name:       "managed prometheus disabled",
+			monitoring: &infrav1exp.MonitoringConfig{ManagedPrometheus: pointer.Bool(false)},
+			want:       false,
+		},
+		{
+			name: "additional component",
+			monitoring: &infrav1exp.MonitoringConfig{
+				EnableComponents: []infrav1exp.MonitoringComponent{infrav1exp.MonitoringSystemComponents, infrav1exp.MonitoringPod},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareMonitoringConfig(convertToSdkMonitoringConfig(tt.monitoring), existing); got != tt.want {
+				t.Errorf("compareMonitoringConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// checkPendingOperation polls the GKE operation started on the cluster by an earlier reconciliation. It returns
+// true while the operation is running, in which case no other operation may be started on the cluster. The result
+// of a completed operation is reported in the GKEControlPlaneOperationSucceeded condition.
+func (s *Service) checkPendingOperation(ctx context.Context, log *logr.Logger) (bool, error) {
+	pending := s.scope.GCPManagedControlPlane.Status.PendingOperation
+	if pending == nil {
+		return false, nil
+	}
+
+	op, err := shared.GetGKEOperation(ctx, s.scope.ManagedControlPlaneClient(), pending.Name)
+	if err != nil {
+		log.Error(err, "Error getting GKE operation", "operation", pending.Name)
+		return false, err
+	}
+	if op == nil {
+		log.Info("GKE operation not found, forgetting it", "operation", pending.Name)
+		s.scope.GCPManagedControlPlane.Status.PendingOperation = nil
+		return false, nil
+	}
+	if op.GetStatus() != containerpb.Operation_DONE {
+		log.V(2).Info("GKE operation in progress", "operation", pending.Name, "type", pending.Type)
+		return true, nil
+	}
+
+	s.scope.GCPManagedControlPlane.Status.PendingOperation = nil
+	if opErr := shared.GKEOperationError(op); opErr != nil {
+		log.Error(opErr, "GKE operation failed", "operation", pending.Name, "type", pending.Type)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneOperationSucceededCondition, infrav1exp.GKEControlPlaneOperationFailedReason, clusterv1.ConditionSeverityError, "%s operation failed: %v", pending.Type, opErr)
+		return false, nil
+	}
+	log.V(2).Info("GKE operation completed", "operation", pending.Name, "type", pending.Type)
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneOperationSucceededCondition)
+
+	return false, nil
+}
+
+// setPendingOperation records the GKE operation started on the cluster.
+func (s *Service) setPendingOperation(op *containerpb.Operation) {
+	s.scope.GCPManagedControlPlane.Status.PendingOperation = shared.NewGKEOperation(op, s.scope.ClusterLocation())
+}
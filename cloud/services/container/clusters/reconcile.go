@@ -19,6 +19,7 @@ package clusters
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
@@ -26,9 +27,9 @@ import (
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -53,6 +54,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Cluster not found, creating")
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
 		s.scope.GCPManagedControlPlane.Status.Ready = false
+		s.scope.GCPManagedControlPlane.Status.Adopted = false
 
 		nodePools, _, err := s.scope.GetAllNodePools(ctx)
 		if err != nil {
@@ -81,21 +83,32 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 
 		if err = s.createCluster(ctx, &log); err != nil {
 			log.Error(err, "failed creating cluster")
-			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			severity := shared.ErrorConditionSeverity(err)
+			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, severity, err.Error())
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, severity, err.Error())
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, severity, err.Error())
 			return ctrl.Result{}, err
 		}
 		log.Info("Cluster created provisioning in progress")
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
 	}
 
 	log.V(2).Info("gke cluster found", "status", cluster.Status)
+	if !s.isClusterManaged(cluster) && !s.adoptCluster(cluster, &log) {
+		s.scope.GCPManagedControlPlane.Status.Initialized = false
+		s.scope.GCPManagedControlPlane.Status.Ready = false
+		return s.refreshKubeconfig(ctx, cluster, &log), nil
+	}
 	s.scope.GCPManagedControlPlane.Status.CurrentVersion = cluster.CurrentMasterVersion
 
+	operationPending, err := s.checkPendingOperation(ctx, &log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	switch cluster.Status {
 	case containerpb.Cluster_PROVISIONING:
 		log.Info("Cluster provisioning in progress")
@@ -107,9 +120,17 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	case containerpb.Cluster_RECONCILING:
 		log.Info("Cluster reconciling in progress")
+		// Updates such as upgrades can outlive the access token of the kubeconfig used by Cluster API.
+		if _, err := s.reconcileKubeconfig(ctx, cluster, &log); err != nil {
+			log.Error(err, "Failed to reconcile CAPI kubeconfig")
+			return ctrl.Result{}, err
+		}
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = true
 		s.scope.GCPManagedControlPlane.Status.Ready = true
+		if operationPending {
+			return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+		}
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	case containerpb.Cluster_STOPPING:
 		log.Info("Cluster stopping in progress")
@@ -128,7 +149,8 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneErrorReason, clusterv1.ConditionSeverityError, "")
 		s.scope.GCPManagedControlPlane.Status.Ready = false
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
-		return ctrl.Result{}, nil
+		// A degraded cluster keeps serving, the token used by Cluster API is refreshed until it recovers.
+		return s.refreshKubeconfig(ctx, cluster, &log), nil
 	case containerpb.Cluster_RUNNING:
 		log.Info("Cluster running")
 	default:
@@ -137,6 +159,39 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, statusErr
 	}
 
+	// The upgrade status is refreshed first so that an invalid version reported below isn't overwritten.
+	if err := s.reconcileUpgradeStatus(ctx, cluster); err != nil {
+		log.Error(err, "Failed to reconcile upgrade status")
+		return ctrl.Result{}, err
+	}
+
+	// GKE rejects operations on the cluster while another one is running.
+	if operationPending {
+		log.Info("Waiting for GKE operation to complete", "operation", s.scope.GCPManagedControlPlane.Status.PendingOperation.Name)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpgrade, upgradeClusterRequest, err := s.checkDiffAndPrepareUpgrade(ctx, cluster, &log)
+	switch {
+	case errors.Is(err, ErrInvalidControlPlaneVersion):
+		log.Error(err, "Control plane version can't be used, skipping upgrade")
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition, infrav1exp.GKEControlPlaneInvalidVersionReason, clusterv1.ConditionSeverityError, err.Error())
+	case err != nil:
+		return ctrl.Result{}, err
+	case needUpgrade:
+		log.Info("Upgrade required")
+		err = s.updateCluster(ctx, upgradeClusterRequest, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Cluster upgrading in progress")
+		s.startUpgrade(upgradeClusterRequest.Update.DesiredMasterVersion)
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+		s.scope.GCPManagedControlPlane.Status.Initialized = true
+		s.scope.GCPManagedControlPlane.Status.Ready = true
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
 	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(cluster, &log)
 	if needUpdate {
 		log.Info("Update required")
@@ -148,12 +203,55 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = true
 		s.scope.GCPManagedControlPlane.Status.Ready = true
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateLabels, setLabelsRequest := s.checkDiffAndPrepareLabels(cluster, &log)
+	if needUpdateLabels {
+		log.Info("Labels update required")
+		err = s.setLabels(ctx, setLabelsRequest, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Cluster labels updating in progress")
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+		s.scope.GCPManagedControlPlane.Status.Initialized = true
+		s.scope.GCPManagedControlPlane.Status.Ready = true
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateMaintenancePolicy, setMaintenancePolicyRequest := s.checkDiffAndPrepareMaintenancePolicy(cluster, &log)
+	if needUpdateMaintenancePolicy {
+		log.Info("Maintenance policy update required")
+		err = s.setMaintenancePolicy(ctx, setMaintenancePolicyRequest, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Cluster maintenance policy updating in progress")
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+		s.scope.GCPManagedControlPlane.Status.Initialized = true
+		s.scope.GCPManagedControlPlane.Status.Ready = true
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateNetworkPolicy, setNetworkPolicyRequest := s.checkDiffAndPrepareNetworkPolicy(cluster, &log)
+	if needUpdateNetworkPolicy {
+		log.Info("Network policy update required")
+		err = s.setNetworkPolicy(ctx, setNetworkPolicyRequest, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Cluster network policy updating in progress")
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+		s.scope.GCPManagedControlPlane.Status.Initialized = true
+		s.scope.GCPManagedControlPlane.Status.Ready = true
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
 	}
 	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition, infrav1exp.GKEControlPlaneUpdatedReason, clusterv1.ConditionSeverityInfo, "")
+	s.scope.GCPManagedControlPlane.Status.NextMaintenanceWindow = nextMaintenanceWindow(s.scope.GCPManagedControlPlane.Spec.MaintenancePolicy, time.Now())
 
 	// Reconcile kubeconfig
-	err = s.reconcileKubeconfig(ctx, cluster, &log)
+	tokenExpiry, err := s.reconcileKubeconfig(ctx, cluster, &log)
 	if err != nil {
 		log.Error(err, "Failed to reconcile CAPI kubeconfig")
 		return ctrl.Result{}, err
@@ -173,7 +271,13 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 
 	log.Info("Cluster reconciled")
 
-	return ctrl.Result{}, nil
+	if upgrade := s.scope.GCPManagedControlPlane.Status.Upgrade; upgrade != nil && upgrade.Phase != infrav1exp.UpgradePhaseCompleted {
+		// Node pools are upgraded by their own controller, keep track of their progress.
+		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+	}
+
+	// The access token in the kubeconfig used by Cluster API is refreshed before it expires.
+	return ctrl.Result{RequeueAfter: kubeconfigTokenRefreshAfter(tokenExpiry, time.Now())}, nil
 }
 
 // Delete delete GKE cluster.
@@ -187,6 +291,13 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	}
 	if cluster == nil {
 		log.Info("Cluster already deleted")
+		s.scope.GCPManagedControlPlane.Status.PendingOperation = nil
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneDeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	if !s.isClusterManaged(cluster) {
+		log.Info("Cluster was not created by the provider, skipping deletion", "name", cluster.Name)
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneDeletedReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
@@ -207,6 +318,15 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		break
 	}
 
+	operationPending, err := s.checkPendingOperation(ctx, &log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if operationPending {
+		log.Info("Waiting for GKE operation to complete before deleting the cluster", "operation", s.scope.GCPManagedControlPlane.Status.PendingOperation.Name)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
 	if err = s.deleteCluster(ctx, &log); err != nil {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
@@ -227,11 +347,8 @@ func (s *Service) describeCluster(ctx context.Context, log *logr.Logger) (*conta
 	}
 	cluster, err := s.scope.ManagedControlPlaneClient().GetCluster(ctx, getClusterRequest)
 	if err != nil {
-		var e *apierror.APIError
-		if ok := errors.As(err, &e); ok {
-			if e.GRPCStatus().Code() == codes.NotFound {
-				return nil, nil
-			}
+		if gcperrors.IsNotFound(err) {
+			return nil, nil
 		}
 		log.Error(err, "Error getting GKE cluster", "name", s.scope.ClusterName())
 		return nil, err
@@ -248,8 +365,6 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 		return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
 	}
 
-	isRegional := shared.IsRegional(s.scope.Region())
-
 	cluster := &containerpb.Cluster{
 		Name:    s.scope.ClusterName(),
 		Network: *s.scope.GCPManagedCluster.Spec.Network.Name,
@@ -260,12 +375,32 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 			Channel: convertToSdkReleaseChannel(s.scope.GCPManagedControlPlane.Spec.ReleaseChannel),
 		},
 		MasterAuthorizedNetworksConfig: convertToSdkMasterAuthorizedNetworksConfig(s.scope.GCPManagedControlPlane.Spec.MasterAuthorizedNetworksConfig),
+		AddonsConfig:                   convertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.Addons),
+		ResourceLabels:                 s.scope.ResourceLabels(),
+		LoggingConfig:                  convertToSdkLoggingConfig(s.scope.GCPManagedControlPlane.Spec.LoggingConfig),
+		MonitoringConfig:               convertToSdkMonitoringConfig(s.scope.GCPManagedControlPlane.Spec.MonitoringConfig),
+		Autoscaling:                    convertToSdkClusterAutoscaling(s.scope.GCPManagedControlPlane.Spec.ClusterAutoscaling),
+		ShieldedNodes:                  convertToSdkShieldedNodes(s.scope.GCPManagedControlPlane.Spec.EnableShieldedNodes),
+		BinaryAuthorization:            convertToSdkBinaryAuthorization(s.scope.GCPManagedControlPlane.Spec.BinaryAuthorizationEvaluationMode),
+		DatabaseEncryption:             convertToSdkDatabaseEncryption(s.scope.GCPManagedControlPlane.Spec.DatabaseEncryption),
+		AuthenticatorGroupsConfig:      convertToSdkAuthenticatorGroupsConfig(s.scope.GCPManagedControlPlane.Spec.AuthenticatorGroupsConfig),
+	}
+	cluster.NetworkConfig = convertToSdkNetworkConfig(s.scope.GCPManagedControlPlane.Spec.Networking, s.scope.GCPManagedControlPlane.Spec.Addons)
+	if networkPolicy := convertToSdkNetworkPolicy(s.scope.GCPManagedControlPlane.Spec.Networking); networkPolicy != nil {
+		cluster.NetworkPolicy = networkPolicy
+		// Calico requires the network policy addon, which is enabled unless the addons say otherwise.
+		if networkPolicy.Enabled && cluster.GetAddonsConfig().GetNetworkPolicyConfig() == nil {
+			if cluster.AddonsConfig == nil {
+				cluster.AddonsConfig = &containerpb.AddonsConfig{}
+			}
+			cluster.AddonsConfig.NetworkPolicyConfig = &containerpb.NetworkPolicyConfig{Disabled: false}
+		}
 	}
 	if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion != nil {
 		cluster.InitialClusterVersion = *s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion
 	}
 	if !s.scope.IsAutopilotCluster() {
-		cluster.NodePools = scope.ConvertToSdkNodePools(nodePools, machinePools, isRegional)
+		cluster.NodePools = scope.ConvertToSdkNodePools(nodePools, machinePools, s.scope.Region())
 	}
 
 	createClusterRequest := &containerpb.CreateClusterRequest{
@@ -274,21 +409,34 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 	}
 
 	log.V(2).Info("Creating GKE cluster")
-	_, err := s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
+	op, err := s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
 	if err != nil {
 		log.Error(err, "Error creating GKE cluster", "name", s.scope.ClusterName())
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
 
 func (s *Service) updateCluster(ctx context.Context, updateClusterRequest *containerpb.UpdateClusterRequest, log *logr.Logger) error {
-	_, err := s.scope.ManagedControlPlaneClient().UpdateCluster(ctx, updateClusterRequest)
+	op, err := s.scope.ManagedControlPlaneClient().UpdateCluster(ctx, updateClusterRequest)
 	if err != nil {
 		log.Error(err, "Error updating GKE cluster", "name", s.scope.ClusterName())
 		return err
 	}
+	s.setPendingOperation(op)
+
+	return nil
+}
+
+func (s *Service) setLabels(ctx context.Context, setLabelsRequest *containerpb.SetLabelsRequest, log *logr.Logger) error {
+	op, err := s.scope.ManagedControlPlaneClient().SetLabels(ctx, setLabelsRequest)
+	if err != nil {
+		log.Error(err, "Error setting GKE cluster labels", "name", s.scope.ClusterName())
+		return err
+	}
+	s.setPendingOperation(op)
 
 	return nil
 }
@@ -297,11 +445,12 @@ func (s *Service) deleteCluster(ctx context.Context, log *logr.Logger) error {
 	deleteClusterRequest := &containerpb.DeleteClusterRequest{
 		Name: s.scope.ClusterFullName(),
 	}
-	_, err := s.scope.ManagedControlPlaneClient().DeleteCluster(ctx, deleteClusterRequest)
+	op, err := s.scope.ManagedControlPlaneClient().DeleteCluster(ctx, deleteClusterRequest)
 	if err != nil {
 		log.Error(err, "Error deleting GKE cluster", "name", s.scope.ClusterName())
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
@@ -349,36 +498,47 @@ func convertToSdkMasterAuthorizedNetworksConfig(config *infrav1exp.MasterAuthori
 	}
 }
 
+// checkDiffAndPrepareUpdate returns the request of the first update of the cluster settings that differ from the spec.
+// GKE accepts a single desired setting per update, the other differences are updated by the following reconciles
+// once the operation of this update has completed.
 func (s *Service) checkDiffAndPrepareUpdate(existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.UpdateClusterRequest) {
 	log.V(4).Info("Checking diff and preparing update.")
 
-	needUpdate := false
-	clusterUpdate := containerpb.ClusterUpdate{}
+	clusterUpdates := s.checkDiffAndPrepareClusterUpdates(existingCluster, log)
+	if len(clusterUpdates) == 0 {
+		return false, nil
+	}
+
+	updateClusterRequest := containerpb.UpdateClusterRequest{
+		Name:   s.scope.ClusterFullName(),
+		Update: clusterUpdates[0],
+	}
+	log.V(4).Info("Update cluster request. ", "remainingUpdates", len(clusterUpdates)-1, "updateClusterRequest", &updateClusterRequest)
+	return true, &updateClusterRequest
+}
+
+// checkDiffAndPrepareClusterUpdates returns the updates of the cluster settings that differ from the spec, in the
+// order they are applied.
+func (s *Service) checkDiffAndPrepareClusterUpdates(existingCluster *containerpb.Cluster, log *logr.Logger) []*containerpb.ClusterUpdate {
+	clusterUpdates := []*containerpb.ClusterUpdate{}
 	// Release channel
 	desiredReleaseChannel := convertToSdkReleaseChannel(s.scope.GCPManagedControlPlane.Spec.ReleaseChannel)
 	if desiredReleaseChannel != existingCluster.ReleaseChannel.Channel {
 		log.V(2).Info("Release channel update required", "current", existingCluster.ReleaseChannel.Channel, "desired", desiredReleaseChannel)
-		needUpdate = true
-		clusterUpdate.DesiredReleaseChannel = &containerpb.ReleaseChannel{
-			Channel: desiredReleaseChannel,
-		}
-	}
-	// Master version
-	if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion != nil {
-		desiredMasterVersion := *s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion
-		if desiredMasterVersion != existingCluster.InitialClusterVersion {
-			needUpdate = true
-			clusterUpdate.DesiredMasterVersion = desiredMasterVersion
-			log.V(2).Info("Master version update required", "current", existingCluster.InitialClusterVersion, "desired", desiredMasterVersion)
-		}
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredReleaseChannel: &containerpb.ReleaseChannel{
+				Channel: desiredReleaseChannel,
+			},
+		})
 	}
 
 	// DesiredMasterAuthorizedNetworksConfig
 	// When desiredMasterAuthorizedNetworksConfig is nil, it means that the user wants to disable the feature.
 	desiredMasterAuthorizedNetworksConfig := convertToSdkMasterAuthorizedNetworksConfig(s.scope.GCPManagedControlPlane.Spec.MasterAuthorizedNetworksConfig)
 	if !compareMasterAuthorizedNetworksConfig(desiredMasterAuthorizedNetworksConfig, existingCluster.MasterAuthorizedNetworksConfig) {
-		needUpdate = true
-		clusterUpdate.DesiredMasterAuthorizedNetworksConfig = desiredMasterAuthorizedNetworksConfig
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredMasterAuthorizedNetworksConfig: desiredMasterAuthorizedNetworksConfig,
+		})
 		log.V(2).Info("Master authorized networks config update required", "current", existingCluster.MasterAuthorizedNetworksConfig, "desired", desiredMasterAuthorizedNetworksConfig)
 	}
 	log.V(4).Info("Master authorized networks config update check", "current", existingCluster.MasterAuthorizedNetworksConfig)
@@ -386,12 +546,115 @@ func (s *Service) checkDiffAndPrepareUpdate(existingCluster *containerpb.Cluster
 		log.V(4).Info("Master authorized networks config update check", "desired", desiredMasterAuthorizedNetworksConfig)
 	}
 
-	updateClusterRequest := containerpb.UpdateClusterRequest{
-		Name:   s.scope.ClusterFullName(),
-		Update: &clusterUpdate,
+	// DesiredAddonsConfig
+	desiredAddonsConfig := convertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.Addons)
+	if !compareAddonsConfig(desiredAddonsConfig, existingCluster.AddonsConfig) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredAddonsConfig: desiredAddonsConfig,
+		})
+		log.V(2).Info("Addons config update required", "current", existingCluster.AddonsConfig, "desired", desiredAddonsConfig)
+	}
+
+	// Network policy addon, required to enforce network policies with Calico.
+	if addonUpdate := networkPolicyAddonUpdate(s.scope.GCPManagedControlPlane.Spec.Networking, existingCluster); addonUpdate != nil {
+		clusterUpdates = append(clusterUpdates, addonUpdate)
+		log.V(2).Info("Network policy addon update required", "current", existingCluster.GetAddonsConfig().GetNetworkPolicyConfig(), "desired", addonUpdate.DesiredAddonsConfig.NetworkPolicyConfig)
 	}
-	log.V(4).Info("Update cluster request. ", "needUpdate", needUpdate, "updateClusterRequest", &updateClusterRequest)
-	return needUpdate, &updateClusterRequest
+
+	// DesiredGatewayApiConfig
+	desiredGatewayAPIConfig := convertToSdkGatewayAPIConfig(s.scope.GCPManagedControlPlane.Spec.Addons)
+	if desiredGatewayAPIConfig != nil && desiredGatewayAPIConfig.Channel != existingCluster.GetNetworkConfig().GetGatewayApiConfig().GetChannel() {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredGatewayApiConfig: desiredGatewayAPIConfig,
+		})
+		log.V(2).Info("Gateway API config update required", "current", existingCluster.GetNetworkConfig().GetGatewayApiConfig(), "desired", desiredGatewayAPIConfig)
+	}
+
+	// Networking
+	if desiredNetworkingUpdates := networkingUpdates(s.scope.GCPManagedControlPlane.Spec.Networking, existingCluster.GetNetworkConfig()); len(desiredNetworkingUpdates) > 0 {
+		clusterUpdates = append(clusterUpdates, desiredNetworkingUpdates...)
+		log.V(2).Info("Networking update required", "current", existingCluster.GetNetworkConfig(), "desired", desiredNetworkingUpdates)
+	}
+
+	// DesiredLoggingConfig
+	desiredLoggingConfig := convertToSdkLoggingConfig(s.scope.GCPManagedControlPlane.Spec.LoggingConfig)
+	if !compareLoggingConfig(desiredLoggingConfig, existingCluster.LoggingConfig) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredLoggingConfig: desiredLoggingConfig,
+		})
+		log.V(2).Info("Logging config update required", "current", existingCluster.LoggingConfig, "desired", desiredLoggingConfig)
+	}
+
+	// DesiredMonitoringConfig
+	desiredMonitoringConfig := convertToSdkMonitoringConfig(s.scope.GCPManagedControlPlane.Spec.MonitoringConfig)
+	if !compareMonitoringConfig(desiredMonitoringConfig, existingCluster.MonitoringConfig) {
+		// Settings that aren't specified are kept so that the update doesn't reset them.
+		if desiredMonitoringConfig.ComponentConfig == nil {
+			desiredMonitoringConfig.ComponentConfig = existingCluster.GetMonitoringConfig().GetComponentConfig()
+		}
+		if desiredMonitoringConfig.ManagedPrometheusConfig == nil {
+			desiredMonitoringConfig.ManagedPrometheusConfig = existingCluster.GetMonitoringConfig().GetManagedPrometheusConfig()
+		}
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredMonitoringConfig: desiredMonitoringConfig,
+		})
+		log.V(2).Info("Monitoring config update required", "current", existingCluster.MonitoringConfig, "desired", desiredMonitoringConfig)
+	}
+
+	// DesiredClusterAutoscaling
+	desiredClusterAutoscaling := convertToSdkClusterAutoscaling(s.scope.GCPManagedControlPlane.Spec.ClusterAutoscaling)
+	if !compareClusterAutoscaling(desiredClusterAutoscaling, existingCluster.Autoscaling) {
+		// Settings that aren't specified are kept so that the update doesn't reset them.
+		if desiredClusterAutoscaling.AutoscalingProfile == containerpb.ClusterAutoscaling_PROFILE_UNSPECIFIED {
+			desiredClusterAutoscaling.AutoscalingProfile = existingCluster.GetAutoscaling().GetAutoscalingProfile()
+		}
+		if desiredClusterAutoscaling.AutoprovisioningNodePoolDefaults == nil {
+			desiredClusterAutoscaling.AutoprovisioningNodePoolDefaults = existingCluster.GetAutoscaling().GetAutoprovisioningNodePoolDefaults()
+		}
+		desiredClusterAutoscaling.AutoprovisioningLocations = existingCluster.GetAutoscaling().GetAutoprovisioningLocations()
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredClusterAutoscaling: desiredClusterAutoscaling,
+		})
+		log.V(2).Info("Cluster autoscaling update required", "current", existingCluster.Autoscaling, "desired", desiredClusterAutoscaling)
+	}
+
+	// DesiredShieldedNodes
+	desiredShieldedNodes := convertToSdkShieldedNodes(s.scope.GCPManagedControlPlane.Spec.EnableShieldedNodes)
+	if !compareShieldedNodes(desiredShieldedNodes, existingCluster.ShieldedNodes) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredShieldedNodes: desiredShieldedNodes,
+		})
+		log.V(2).Info("Shielded nodes update required", "current", existingCluster.ShieldedNodes, "desired", desiredShieldedNodes)
+	}
+
+	// DesiredBinaryAuthorization
+	desiredBinaryAuthorization := convertToSdkBinaryAuthorization(s.scope.GCPManagedControlPlane.Spec.BinaryAuthorizationEvaluationMode)
+	if !compareBinaryAuthorization(desiredBinaryAuthorization, existingCluster.BinaryAuthorization) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredBinaryAuthorization: desiredBinaryAuthorization,
+		})
+		log.V(2).Info("Binary authorization update required", "current", existingCluster.BinaryAuthorization, "desired", desiredBinaryAuthorization)
+	}
+
+	// DesiredDatabaseEncryption
+	desiredDatabaseEncryption := convertToSdkDatabaseEncryption(s.scope.GCPManagedControlPlane.Spec.DatabaseEncryption)
+	if !compareDatabaseEncryption(desiredDatabaseEncryption, existingCluster.DatabaseEncryption) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredDatabaseEncryption: desiredDatabaseEncryption,
+		})
+		log.V(2).Info("Database encryption update required", "current", existingCluster.DatabaseEncryption, "desired", desiredDatabaseEncryption)
+	}
+
+	// DesiredAuthenticatorGroupsConfig
+	desiredAuthenticatorGroupsConfig := convertToSdkAuthenticatorGroupsConfig(s.scope.GCPManagedControlPlane.Spec.AuthenticatorGroupsConfig)
+	if !compareAuthenticatorGroupsConfig(desiredAuthenticatorGroupsConfig, existingCluster.AuthenticatorGroupsConfig) {
+		clusterUpdates = append(clusterUpdates, &containerpb.ClusterUpdate{
+			DesiredAuthenticatorGroupsConfig: desiredAuthenticatorGroupsConfig,
+		})
+		log.V(2).Info("Authenticator groups config update required", "current", existingCluster.AuthenticatorGroupsConfig, "desired", desiredAuthenticatorGroupsConfig)
+	}
+
+	return clusterUpdates
 }
 
 // compare if two MasterAuthorizedNetworksConfig are equal.
@@ -416,8 +679,23 @@ func compareMasterAuthorizedNetworksConfig(a, b *containerpb.MasterAuthorizedNet
 	if (a.CidrBlocks == nil && b.CidrBlocks != nil && len(b.CidrBlocks) == 0) || (b.CidrBlocks == nil && a.CidrBlocks != nil && len(a.CidrBlocks) == 0) {
 		return true
 	}
-	if !cmp.Equal(a.CidrBlocks, b.CidrBlocks) {
+	if !cmp.Equal(a.CidrBlocks, b.CidrBlocks, protocmp.Transform()) {
 		return false
 	}
 	return true
 }
+
+func (s *Service) checkDiffAndPrepareLabels(existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.SetLabelsRequest) {
+	desiredLabels := s.scope.ResourceLabels()
+	if desiredLabels.Equals(existingCluster.ResourceLabels) {
+		return false, nil
+	}
+	log.V(2).Info("Labels update required", "current", existingCluster.ResourceLabels, "desired", desiredLabels)
+
+	return true, &containerpb.SetLabelsRequest{
+		Name:           s.scope.ClusterFullName(),
+		ResourceLabels: desiredLabels,
+		// The label fingerprint is used by GKE for optimistic concurrency control.
+		LabelFingerprint: existingCluster.LabelFingerprint,
+	}
+}
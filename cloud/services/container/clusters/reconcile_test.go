@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCheckDiffAndPrepareUpdate(t *testing.T) {
+	stable := infrav1exp.Stable
+	s := New(&scope.ManagedControlPlaneScope{
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ClusterName:    "test-cluster",
+				Project:        "test-project",
+				Location:       "us-central1",
+				ReleaseChannel: &stable,
+				Addons:         &infrav1exp.AddonsConfig{HTTPLoadBalancing: pointer.Bool(false)},
+			},
+		},
+	})
+	existingCluster := &containerpb.Cluster{
+		ReleaseChannel:                 &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+		MasterAuthorizedNetworksConfig: convertToSdkMasterAuthorizedNetworksConfig(nil),
+		AddonsConfig:                   &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: false}},
+	}
+	log := logr.Discard()
+
+	// The release channel and the addons differ, only the release channel is sent first.
+	needUpdate, request := s.checkDiffAndPrepareUpdate(existingCluster, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() = false, want true")
+	}
+	wantUpdate := &containerpb.ClusterUpdate{
+		DesiredReleaseChannel: &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_STABLE},
+	}
+	if diff := cmp.Diff(wantUpdate, request.Update, protocmp.Transform()); diff != "" {
+		t.Errorf("first update mismatch (-want +got):\n%s", diff)
+	}
+	if want := "projects/test-project/locations/us-central1/clusters/test-cluster"; request.Name != want {
+		t.Errorf("Name = %s, want %s", request.Name, want)
+	}
+
+	// Once the release channel is updated, the addons are sent by the next reconcile.
+	existingCluster.ReleaseChannel.Channel = containerpb.ReleaseChannel_STABLE
+	needUpdate, request = s.checkDiffAndPrepareUpdate(existingCluster, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() = false, want true")
+	}
+	wantUpdate = &containerpb.ClusterUpdate{
+		DesiredAddonsConfig: convertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.Addons),
+	}
+	if diff := cmp.Diff(wantUpdate, request.Update, protocmp.Transform()); diff != "" {
+		t.Errorf("second update mismatch (-want +got):\n%s", diff)
+	}
+
+	existingCluster.AddonsConfig = convertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.Addons)
+	if needUpdate, _ := s.checkDiffAndPrepareUpdate(existingCluster, &log); needUpdate {
+		t.Errorf("checkDiffAndPrepareUpdate() = true once the cluster matches the spec, want false")
+	}
+}
+
+func TestCheckDiffAndPrepareClusterUpdates(t *testing.T) {
+	optimizeUtilization := infrav1exp.OptimizeUtilizationAutoscalingProfile
+	projectSingletonPolicyEnforce := infrav1exp.BinaryAuthorizationProjectSingletonPolicyEnforce
+
+	tests := []struct {
+		name string
+		spec infrav1exp.GCPManagedControlPlaneSpec
+		want []string
+	}{
+		{
+			name: "no difference",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{},
+			want: []string{},
+		},
+		{
+			name: "logging and monitoring",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				LoggingConfig:    &infrav1exp.LoggingConfig{EnableComponents: []infrav1exp.LoggingComponent{infrav1exp.LoggingSystemComponents}},
+				MonitoringConfig: &infrav1exp.MonitoringConfig{ManagedPrometheus: pointer.Bool(true)},
+			},
+			want: []string{"desired_logging_config", "desired_monitoring_config"},
+		},
+		{
+			name: "monitoring and cluster autoscaling",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				MonitoringConfig:   &infrav1exp.MonitoringConfig{ManagedPrometheus: pointer.Bool(true)},
+				ClusterAutoscaling: &infrav1exp.ClusterAutoscaling{AutoscalingProfile: &optimizeUtilization},
+			},
+			want: []string{"desired_monitoring_config", "desired_cluster_autoscaling"},
+		},
+		{
+			name: "security settings",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				EnableShieldedNodes:               pointer.Bool(true),
+				BinaryAuthorizationEvaluationMode: &projectSingletonPolicyEnforce,
+				AuthenticatorGroupsConfig:         &infrav1exp.AuthenticatorGroupsConfig{SecurityGroup: "gke-security-groups@example.com"},
+			},
+			want: []string{"desired_shielded_nodes", "desired_binary_authorization", "desired_authenticator_groups_config"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedControlPlaneScope{
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{Spec: tt.spec},
+			})
+			existingCluster := &containerpb.Cluster{
+				ReleaseChannel:                 &containerpb.ReleaseChannel{},
+				MasterAuthorizedNetworksConfig: convertToSdkMasterAuthorizedNetworksConfig(nil),
+			}
+			log := logr.Discard()
+			if diff := cmp.Diff(tt.want, desiredSettings(s.checkDiffAndPrepareClusterUpdates(existingCluster, &log))); diff != "" {
+				t.Errorf("checkDiffAndPrepareClusterUpdates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareLabels(t *testing.T) {
+	s := New(&scope.ManagedControlPlaneScope{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-cluster"}},
+		GCPManagedCluster: &infrav1exp.GCPManagedCluster{
+			Spec: infrav1exp.GCPManagedClusterSpec{AdditionalLabels: infrav1.Labels{"team": "a", "env": "prod"}},
+		},
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{ClusterName: "test-cluster", Project: "test-project", Location: "us-central1"},
+		},
+	})
+	existingCluster := &containerpb.Cluster{
+		ResourceLabels:   map[string]string{"team": "b"},
+		LabelFingerprint: "42c0ffee",
+	}
+	log := logr.Discard()
+
+	// All the labels, including the ownership label, are sent in a single request.
+	needUpdate, request := s.checkDiffAndPrepareLabels(existingCluster, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareLabels() = false, want true")
+	}
+	want := &containerpb.SetLabelsRequest{
+		Name: "projects/test-project/locations/us-central1/clusters/test-cluster",
+		ResourceLabels: map[string]string{
+			infrav1.ClusterTagKey("capi-cluster"): string(infrav1.ResourceLifecycleOwned),
+			"team":                                "a",
+			"env":                                 "prod",
+		},
+		LabelFingerprint: "42c0ffee",
+	}
+	if diff := cmp.Diff(want, request, protocmp.Transform()); diff != "" {
+		t.Errorf("checkDiffAndPrepareLabels() mismatch (-want +got):\n%s", diff)
+	}
+
+	existingCluster.ResourceLabels = request.ResourceLabels
+	if needUpdate, _ := s.checkDiffAndPrepareLabels(existingCluster, &log); needUpdate {
+		t.Errorf("checkDiffAndPrepareLabels() = true once the labels match the spec, want false")
+	}
+}
+
+// desiredSettings returns the names of the fields set in each cluster update.
+func desiredSettings(clusterUpdates []*containerpb.ClusterUpdate) []string {
+	settings := []string{}
+	for _, clusterUpdate := range clusterUpdates {
+		fields := []string{}
+		clusterUpdate.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			fields = append(fields, string(fd.Name()))
+			return true
+		})
+		settings = append(settings, strings.Join(fields, ","))
+	}
+	return settings
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// convertToSdkShieldedNodes converts the shielded nodes setting defined in CRs to the SDK version.
+func convertToSdkShieldedNodes(enabled *bool) *containerpb.ShieldedNodes {
+	if enabled == nil {
+		return nil
+	}
+
+	return &containerpb.ShieldedNodes{
+		Enabled: *enabled,
+	}
+}
+
+// convertToSdkBinaryAuthorization converts the Binary Authorization evaluation mode defined in CRs to the SDK version.
+func convertToSdkBinaryAuthorization(mode *infrav1exp.BinaryAuthorizationEvaluationMode) *containerpb.BinaryAuthorization {
+	if mode == nil {
+		return nil
+	}
+
+	evaluationMode := containerpb.BinaryAuthorization_DISABLED
+	if *mode == infrav1exp.BinaryAuthorizationProjectSingletonPolicyEnforce {
+		evaluationMode = containerpb.BinaryAuthorization_PROJECT_SINGLETON_POLICY_ENFORCE
+	}
+
+	return &containerpb.BinaryAuthorization{
+		EvaluationMode: evaluationMode,
+	}
+}
+
+// convertToSdkDatabaseEncryption converts the DatabaseEncryption defined in CRs to the SDK version.
+func convertToSdkDatabaseEncryption(encryption *infrav1exp.DatabaseEncryption) *containerpb.DatabaseEncryption {
+	if encryption == nil {
+		return nil
+	}
+
+	if encryption.KeyName == "" {
+		return &containerpb.DatabaseEncryption{
+			State: containerpb.DatabaseEncryption_DECRYPTED,
+		}
+	}
+
+	return &containerpb.DatabaseEncryption{
+		KeyName: encryption.KeyName,
+		State:   containerpb.DatabaseEncryption_ENCRYPTED,
+	}
+}
+
+// convertToSdkAuthenticatorGroupsConfig converts the AuthenticatorGroupsConfig defined in CRs to the SDK version.
+func convertToSdkAuthenticatorGroupsConfig(config *infrav1exp.AuthenticatorGroupsConfig) *containerpb.AuthenticatorGroupsConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &containerpb.AuthenticatorGroupsConfig{
+		Enabled:       true,
+		SecurityGroup: config.SecurityGroup,
+	}
+}
+
+// compareShieldedNodes returns true if the shielded nodes setting in desired matches the existing one.
+func compareShieldedNodes(desired, existing *containerpb.ShieldedNodes) bool {
+	return desired == nil || desired.Enabled == existing.GetEnabled()
+}
+
+// compareBinaryAuthorization returns true if the evaluation mode in desired matches the existing one.
+func compareBinaryAuthorization(desired, existing *containerpb.BinaryAuthorization) bool {
+	if desired == nil {
+		return true
+	}
+
+	// Clusters which enabled Binary Authorization with the deprecated enabled field don't report an evaluation mode.
+	existingMode := existing.GetEvaluationMode()
+	if existingMode == containerpb.BinaryAuthorization_EVALUATION_MODE_UNSPECIFIED {
+		existingMode = containerpb.BinaryAuthorization_DISABLED
+		if existing.GetEnabled() {
+			existingMode = containerpb.BinaryAuthorization_PROJECT_SINGLETON_POLICY_ENFORCE
+		}
+	}
+
+	return desired.EvaluationMode == existingMode
+}
+
+// compareDatabaseEncryption returns true if the secrets encryption configuration in desired matches the existing one.
+func compareDatabaseEncryption(desired, existing *containerpb.DatabaseEncryption) bool {
+	if desired == nil {
+		return true
+	}
+
+	if desired.State == containerpb.DatabaseEncryption_DECRYPTED {
+		return existing.GetState() != containerpb.DatabaseEncryption_ENCRYPTED
+	}
+
+	return existing.GetState() == containerpb.DatabaseEncryption_ENCRYPTED && desired.KeyName == existing.GetKeyName()
+}
+
+// compareAuthenticatorGroupsConfig returns true if the Google Groups for RBAC configuration in desired matches the existing one.
+func compareAuthenticatorGroupsConfig(desired, existing *containerpb.AuthenticatorGroupsConfig) bool {
+	if desired == nil {
+		return true
+	}
+
+	return existing.GetEnabled() && desired.SecurityGroup == existing.GetSecurityGroup()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestCompareBinaryAuthorization(t *testing.T) {
+	enforce := infrav1exp.BinaryAuthorizationProjectSingletonPolicyEnforce
+	disabled := infrav1exp.BinaryAuthorizationDisabled
+
+	tests := []struct {
+		name     string
+		mode     *infrav1exp.BinaryAuthorizationEvaluationMode
+		existing *containerpb.BinaryAuthorization
+		want     bool
+	}{
+		{
+			name:     "not specified",
+			mode:     nil,
+			existing: &containerpb.BinaryAuthorization{EvaluationMode: containerpb.BinaryAuthorization_PROJECT_SINGLETON_POLICY_ENFORCE},
+			want:     true,
+		},
+		{
+			name:     "enforced",
+			mode:     &enforce,
+			existing: &containerpb.BinaryAuthorization{EvaluationMode: containerpb.BinaryAuthorization_PROJECT_SINGLETON_POLICY_ENFORCE},
+			want:     true,
+		},
+		{
+			name:     "enforced with the deprecated enabled field",
+			mode:     &enforce,
+			existing: &containerpb.BinaryAuthorization{Enabled: true},
+			want:     true,
+		},
+		{
+			name:     "disabled and not configured on the cluster",
+			mode:     &disabled,
+			existing: nil,
+			want:     true,
+		},
+		{
+			name:     "enforcement required",
+			mode:     &enforce,
+			existing: &containerpb.BinaryAuthorization{EvaluationMode: containerpb.BinaryAuthorization_DISABLED},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareBinaryAuthorization(convertToSdkBinaryAuthorization(tt.mode), tt.existing); got != tt.want {
+				t.Errorf("compareBinaryAuthorization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareDatabaseEncryption(t *testing.T) {
+	keyName := "projects/my-project/locations/us-central1/keyRings/gke/cryptoKeys/secrets"
+
+	tests := []struct {
+		name       string
+		encryption *infrav1exp.DatabaseEncryption
+		existing   *containerpb.DatabaseEncryption
+		want       bool
+	}{
+		{
+			name:       "not specified",
+			encryption: nil,
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: keyName},
+			want:       true,
+		},
+		{
+			name:       "encrypted with the same key",
+			encryption: &infrav1exp.DatabaseEncryption{KeyName: keyName},
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: keyName},
+			want:       true,
+		},
+		{
+			name:       "encrypted with another key",
+			encryption: &infrav1exp.DatabaseEncryption{KeyName: keyName + "-v2"},
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: keyName},
+			want:       false,
+		},
+		{
+			name:       "encryption required",
+			encryption: &infrav1exp.DatabaseEncryption{KeyName: keyName},
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_DECRYPTED},
+			want:       false,
+		},
+		{
+			name:       "decrypted",
+			encryption: &infrav1exp.DatabaseEncryption{},
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_DECRYPTED},
+			want:       true,
+		},
+		{
+			name:       "decryption required",
+			encryption: &infrav1exp.DatabaseEncryption{},
+			existing:   &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: keyName},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareDatabaseEncryption(convertToSdkDatabaseEncryption(tt.encryption), tt.existing); got != tt.want {
+				t.Errorf("compareDatabaseEncryption() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareAuthenticatorGroupsConfig(t *testing.T) {
+	config := &infrav1exp.AuthenticatorGroupsConfig{SecurityGroup: "gke-security-groups@example.com"}
+
+	if !compareAuthenticatorGroupsConfig(convertToSdkAuthenticatorGroupsConfig(config), &containerpb.AuthenticatorGroupsConfig{Enabled: true, SecurityGroup: "gke-security-groups@example.com"}) {
+		t.Errorf("compareAuthenticatorGroupsConfig() = false for the same security group")
+	}
+	if compareAuthenticatorGroupsConfig(convertToSdkAuthenticatorGroupsConfig(config), nil) {
+		t.Errorf("compareAuthenticatorGroupsConfig() = true for a cluster without Google Groups for RBAC")
+	}
+}
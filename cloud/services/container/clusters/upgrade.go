@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// checkDiffAndPrepareUpgrade checks whether the control plane has to be upgraded to the desired version
+// and validates the desired version against the versions GKE offers for the release channel of the cluster.
+// It returns an error wrapping ErrInvalidControlPlaneVersion if the desired version can't be used.
+func (s *Service) checkDiffAndPrepareUpgrade(ctx context.Context, existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.UpdateClusterRequest, error) {
+	if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion == nil {
+		return false, nil, nil
+	}
+	desiredMasterVersion := shared.NormalizeVersion(*s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion)
+	if shared.VersionSatisfies(existingCluster.CurrentMasterVersion, desiredMasterVersion) {
+		return false, nil, nil
+	}
+	log.V(2).Info("Master version update required", "current", existingCluster.CurrentMasterVersion, "desired", desiredMasterVersion)
+
+	getServerConfigRequest := &containerpb.GetServerConfigRequest{
+		Name: s.scope.ClusterLocation(),
+	}
+	serverConfig, err := s.scope.ManagedControlPlaneClient().GetServerConfig(ctx, getServerConfigRequest)
+	if err != nil {
+		log.Error(err, "Error getting GKE server config", "name", s.scope.ClusterName())
+		return false, nil, err
+	}
+	if err := validateMasterVersion(serverConfig, existingCluster.GetReleaseChannel().GetChannel(), existingCluster.CurrentMasterVersion, desiredMasterVersion); err != nil {
+		return false, nil, err
+	}
+
+	return true, &containerpb.UpdateClusterRequest{
+		Name: s.scope.ClusterFullName(),
+		Update: &containerpb.ClusterUpdate{
+			DesiredMasterVersion: desiredMasterVersion,
+		},
+	}, nil
+}
+
+// validateMasterVersion checks that the control plane can be upgraded from the current to the desired version.
+func validateMasterVersion(serverConfig *containerpb.ServerConfig, channel containerpb.ReleaseChannel_Channel, currentVersion, desiredVersion string) error {
+	if shared.IsVersionAlias(desiredVersion) {
+		return nil
+	}
+
+	validVersions := serverConfig.GetValidMasterVersions()
+	if channel != containerpb.ReleaseChannel_UNSPECIFIED {
+		validVersions = nil
+		for _, channelConfig := range serverConfig.GetChannels() {
+			if channelConfig.GetChannel() == channel {
+				validVersions = channelConfig.GetValidVersions()
+			}
+		}
+	}
+	valid := false
+	for _, validVersion := range validVersions {
+		if shared.VersionSatisfies(validVersion, desiredVersion) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("%w: version %s is not available in release channel %s", ErrInvalidControlPlaneVersion, desiredVersion, channel)
+	}
+
+	current, err := version.ParseGeneric(currentVersion)
+	if err != nil {
+		return errors.Wrapf(err, "parsing current control plane version %q", currentVersion)
+	}
+	desired, err := version.ParseGeneric(desiredVersion)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidControlPlaneVersion, err.Error())
+	}
+	if desired.Major() != current.Major() || desired.Minor() < current.Minor() {
+		return fmt.Errorf("%w: downgrading the control plane from %s to %s is not supported", ErrInvalidControlPlaneVersion, currentVersion, desiredVersion)
+	}
+	if desired.Minor() > current.Minor()+1 {
+		return fmt.Errorf("%w: the control plane can only be upgraded one minor version at a time, %s to %s requested", ErrInvalidControlPlaneVersion, currentVersion, desiredVersion)
+	}
+
+	return nil
+}
+
+// startUpgrade records the start of a control plane upgrade in the status.
+func (s *Service) startUpgrade(targetVersion string) {
+	s.scope.GCPManagedControlPlane.Status.Upgrade = &infrav1exp.UpgradeStatus{
+		TargetVersion: targetVersion,
+		Phase:         infrav1exp.UpgradePhaseControlPlane,
+		StartTime:     &metav1.Time{Time: time.Now()},
+	}
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition)
+}
+
+// reconcileUpgradeStatus updates the progress of the current upgrade once the control plane is running.
+// The upgrade moves on to the node pools once the control plane reached the target version and completes
+// when every node pool reached the version of its MachinePool.
+func (s *Service) reconcileUpgradeStatus(ctx context.Context, existingCluster *containerpb.Cluster) error {
+	upgrade := s.scope.GCPManagedControlPlane.Status.Upgrade
+	if upgrade == nil || upgrade.Phase == infrav1exp.UpgradePhaseCompleted {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition, infrav1exp.GKEControlPlaneUpgradedReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+	if !shared.VersionSatisfies(existingCluster.CurrentMasterVersion, upgrade.TargetVersion) {
+		upgrade.Phase = infrav1exp.UpgradePhaseControlPlane
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition)
+		return nil
+	}
+
+	nodePools, machinePools, err := s.scope.GetAllNodePools(ctx)
+	if err != nil {
+		return err
+	}
+	upgrade.PendingNodePools = pendingNodePoolUpgrades(existingCluster.GetNodePools(), nodePools, machinePools)
+	if len(upgrade.PendingNodePools) > 0 {
+		upgrade.Phase = infrav1exp.UpgradePhaseNodePools
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition)
+		return nil
+	}
+
+	upgrade.Phase = infrav1exp.UpgradePhaseCompleted
+	upgrade.CompletionTime = &metav1.Time{Time: time.Now()}
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpgradingCondition, infrav1exp.GKEControlPlaneUpgradedReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
+
+// pendingNodePoolUpgrades returns the names of the GKE node pools whose version doesn't match the version of their MachinePool.
+func pendingNodePoolUpgrades(existingNodePools []*containerpb.NodePool, nodePools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool) []string {
+	existingVersions := map[string]string{}
+	for _, existingNodePool := range existingNodePools {
+		existingVersions[existingNodePool.GetName()] = existingNodePool.GetVersion()
+	}
+
+	pending := []string{}
+	for i := range nodePools {
+		if i >= len(machinePools) || machinePools[i].Spec.Template.Spec.Version == nil {
+			continue
+		}
+		nodePoolName := nodePools[i].Spec.NodePoolName
+		if len(nodePoolName) == 0 {
+			nodePoolName = nodePools[i].Name
+		}
+		existingVersion, ok := existingVersions[nodePoolName]
+		if ok && !shared.VersionSatisfies(existingVersion, *machinePools[i].Spec.Template.Spec.Version) {
+			pending = append(pending, nodePoolName)
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestValidateMasterVersion(t *testing.T) {
+	serverConfig := &containerpb.ServerConfig{
+		ValidMasterVersions: []string{"1.28.2-gke.100", "1.27.5-gke.200", "1.27.3-gke.100", "1.26.8-gke.100"},
+		Channels: []*containerpb.ServerConfig_ReleaseChannelConfig{
+			{
+				Channel:       containerpb.ReleaseChannel_STABLE,
+				ValidVersions: []string{"1.27.3-gke.100", "1.26.8-gke.100"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		channel containerpb.ReleaseChannel_Channel
+		current string
+		desired string
+		wantErr bool
+	}{
+		{name: "patch upgrade", current: "1.27.3-gke.100", desired: "1.27.5-gke.200"},
+		{name: "minor upgrade with partial version", current: "1.27.3-gke.100", desired: "1.28"},
+		{name: "alias", current: "1.27.3-gke.100", desired: "latest"},
+		{name: "version not offered", current: "1.27.3-gke.100", desired: "1.27.4-gke.100", wantErr: true},
+		{name: "version not in release channel", channel: containerpb.ReleaseChannel_STABLE, current: "1.26.8-gke.100", desired: "1.27.5-gke.200", wantErr: true},
+		{name: "version in release channel", channel: containerpb.ReleaseChannel_STABLE, current: "1.26.8-gke.100", desired: "1.27"},
+		{name: "downgrade", current: "1.27.3-gke.100", desired: "1.26", wantErr: true},
+		{name: "skipping a minor version", current: "1.26.8-gke.100", desired: "1.28", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMasterVersion(serverConfig, tt.channel, tt.current, tt.desired)
+			if tt.wantErr != errors.Is(err, ErrInvalidControlPlaneVersion) {
+				t.Errorf("validateMasterVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPendingNodePoolUpgrades(t *testing.T) {
+	existing := []*containerpb.NodePool{
+		{Name: "upgraded", Version: "1.27.3-gke.100"},
+		{Name: "pending", Version: "1.26.8-gke.100"},
+		{Name: "unpinned", Version: "1.26.8-gke.100"},
+	}
+	nodePools := []infrav1exp.GCPManagedMachinePool{
+		{ObjectMeta: metav1.ObjectMeta{Name: "upgraded"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mmp"}, Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pending"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unpinned"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-created"}},
+	}
+	machinePools := make([]clusterv1exp.MachinePool, len(nodePools))
+	machinePools[0].Spec.Template.Spec.Version = pointer.String("v1.27.3")
+	machinePools[1].Spec.Template.Spec.Version = pointer.String("v1.27.3")
+	machinePools[3].Spec.Template.Spec.Version = pointer.String("v1.27.3")
+
+	want := []string{"pending"}
+	if got := pendingNodePoolUpgrades(existing, nodePools, machinePools); !reflect.DeepEqual(got, want) {
+		t.Errorf("pendingNodePoolUpgrades() = %v, want %v", got, want)
+	}
+}
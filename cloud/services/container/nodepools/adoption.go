@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// isNodePoolManaged returns true if the GKE node pool was created or already adopted by the provider.
+// Node pools created by the provider are labelled as owned by the cluster, older node pools are recognized
+// by the creating condition recorded when the provider first reconciled them.
+func (s *Service) isNodePoolManaged(existingNodePool *containerpb.NodePool) bool {
+	if infrav1.Labels(existingNodePool.GetConfig().GetResourceLabels()).HasOwned(s.scope.MachinePool.Spec.ClusterName) {
+		return true
+	}
+	return conditions.Has(s.scope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolCreatingCondition)
+}
+
+// adoptNodePool prepares an existing GKE node pool that isn't managed by the provider for adoption.
+// Spec fields that aren't set are filled from the live node pool, and the remaining differences have to be
+// acknowledged in the adoption policy before the node pool is adopted. It returns false if the node pool
+// can't be adopted yet, in which case the reason is reported in the conditions.
+func (s *Service) adoptNodePool(existingNodePool *containerpb.NodePool, log *logr.Logger) bool {
+	adoption := s.scope.GCPManagedMachinePool.Spec.Adoption
+	if adoption == nil || !adoption.Enabled {
+		msg := fmt.Sprintf("GKE node pool %s was not created by the provider, enable adoption to manage it", existingNodePool.Name)
+		log.Info(msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolAdoptionRequiredReason, clusterv1.ConditionSeverityWarning, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolAdoptionRequiredReason, clusterv1.ConditionSeverityWarning, msg)
+		return false
+	}
+
+	fillSpecFromNodePool(&s.scope.GCPManagedMachinePool.Spec, existingNodePool)
+
+	diffs := s.adoptionDiffs(existingNodePool)
+	if unacknowledged := sets.List(sets.New(diffs...).Delete(adoption.AcknowledgedDiffs...)); len(unacknowledged) > 0 {
+		msg := fmt.Sprintf("GKE node pool %s differs from the spec, acknowledge the differences to adopt it: %s", existingNodePool.Name, strings.Join(unacknowledged, ", "))
+		log.Info(msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolAdoptionBlockedReason, clusterv1.ConditionSeverityWarning, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolAdoptionBlockedReason, clusterv1.ConditionSeverityWarning, msg)
+		return false
+	}
+
+	log.Info("Adopting GKE node pool", "name", existingNodePool.Name, "acknowledgedDiffs", diffs)
+	return true
+}
+
+// fillSpecFromNodePool sets the spec fields that aren't specified to the values of the live node pool.
+func fillSpecFromNodePool(spec *infrav1exp.GCPManagedMachinePoolSpec, existingNodePool *containerpb.NodePool) {
+	if spec.Scaling == nil && existingNodePool.GetAutoscaling().GetEnabled() {
+		spec.Scaling = &infrav1exp.NodePoolAutoScaling{
+			MinCount: &existingNodePool.Autoscaling.MinNodeCount,
+			MaxCount: &existingNodePool.Autoscaling.MaxNodeCount,
+		}
+	}
+	if spec.KubernetesLabels == nil && len(existingNodePool.GetConfig().GetLabels()) > 0 {
+		spec.KubernetesLabels = infrav1.Labels{}.AddLabels(existingNodePool.Config.Labels)
+	}
+	if spec.KubernetesTaints == nil {
+		spec.KubernetesTaints = infrav1exp.ConvertFromSdkTaints(existingNodePool.GetConfig().GetTaints())
+	}
+	if spec.NetworkTags == nil && len(existingNodePool.GetConfig().GetTags()) > 0 {
+		spec.NetworkTags = append([]string{}, existingNodePool.Config.Tags...)
+	}
+	if spec.NodeLocations == nil && len(existingNodePool.GetLocations()) > 0 {
+		spec.NodeLocations = append([]string{}, existingNodePool.Locations...)
+	}
+	if spec.Management == nil && existingNodePool.Management != nil {
+		autoRepair := existingNodePool.Management.AutoRepair
+		autoUpgrade := existingNodePool.Management.AutoUpgrade
+		spec.Management = &infrav1exp.NodePoolManagement{
+			AutoRepair:  &autoRepair,
+			AutoUpgrade: &autoUpgrade,
+		}
+	}
+}
+
+// adoptionDiffs returns the spec fields whose values would be applied to the live node pool once it's adopted.
+func (s *Service) adoptionDiffs(existingNodePool *containerpb.NodePool) []string {
+	desiredNodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region())
+	diffs := []string{}
+
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateVersionOrImage(existingNodePool); needUpdate {
+		diffs = append(diffs, "version")
+	}
+	if !compareStringMaps(desiredNodePool.Config.Labels, existingNodePool.GetConfig().GetLabels()) {
+		diffs = append(diffs, "kubernetesLabels")
+	}
+	if !compareTaints(desiredNodePool.Config.Taints, existingNodePool.GetConfig().GetTaints()) {
+		diffs = append(diffs, "kubernetesTaints")
+	}
+	if !compareStringSets(desiredNodePool.Config.Tags, existingNodePool.GetConfig().GetTags()) {
+		diffs = append(diffs, "networkTags")
+	}
+	// The ownership label is always added on adoption, other labels of the node pool are replaced.
+	existingLabels := infrav1.Labels{infrav1.ClusterTagKey(s.scope.MachinePool.Spec.ClusterName): string(infrav1.ResourceLifecycleOwned)}
+	existingLabels = existingLabels.AddLabels(existingNodePool.GetConfig().GetResourceLabels())
+	if !infrav1.Labels(desiredNodePool.Config.ResourceLabels).Equals(existingLabels) {
+		diffs = append(diffs, "additionalLabels")
+	}
+	if desiredNodePool.Config.LinuxNodeConfig != nil && !compareLinuxNodeConfig(desiredNodePool.Config.LinuxNodeConfig, existingNodePool.GetConfig().GetLinuxNodeConfig()) {
+		diffs = append(diffs, "linuxNodeConfig")
+	}
+	if desiredNodePool.Config.KubeletConfig != nil && !compareKubeletConfig(desiredNodePool.Config.KubeletConfig, existingNodePool.GetConfig().GetKubeletConfig()) {
+		diffs = append(diffs, "kubeletConfig")
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateAutoscaling(existingNodePool); needUpdate {
+		diffs = append(diffs, "scaling")
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateManagement(existingNodePool); needUpdate {
+		diffs = append(diffs, "management")
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateUpgradeSettings(existingNodePool); needUpdate {
+		diffs = append(diffs, "upgradeSettings")
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateNetwork(existingNodePool); needUpdate {
+		diffs = append(diffs, "networkConfig")
+	}
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateSize(existingNodePool, nil); needUpdate && !s.replicasManagedExternally() {
+		diffs = append(diffs, "replicas")
+	}
+
+	return diffs
+}
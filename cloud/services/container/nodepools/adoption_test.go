@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestFillSpecFromNodePool(t *testing.T) {
+	existingNodePool := &containerpb.NodePool{
+		Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 3},
+		Config: &containerpb.NodeConfig{
+			Labels: map[string]string{"role": "worker"},
+			Taints: []*containerpb.NodeTaint{{Key: "dedicated", Value: "gpu", Effect: containerpb.NodeTaint_NO_EXECUTE}},
+			Tags:   []string{"worker"},
+		},
+		Management: &containerpb.NodeManagement{AutoRepair: true},
+	}
+
+	spec := infrav1exp.GCPManagedMachinePoolSpec{
+		NetworkTags: []string{"custom"},
+	}
+	fillSpecFromNodePool(&spec, existingNodePool)
+
+	want := infrav1exp.GCPManagedMachinePoolSpec{
+		Scaling:          &infrav1exp.NodePoolAutoScaling{MinCount: pointer.Int32(1), MaxCount: pointer.Int32(3)},
+		KubernetesLabels: infrav1.Labels{"role": "worker"},
+		KubernetesTaints: infrav1exp.Taints{{Key: "dedicated", Value: "gpu", Effect: "NoExecute"}},
+		NetworkTags:      []string{"custom"},
+		Management:       &infrav1exp.NodePoolManagement{AutoRepair: pointer.Bool(true), AutoUpgrade: pointer.Bool(false)},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("fillSpecFromNodePool() = %+v, want %+v", spec, want)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileMachinePoolMachines keeps a GCPManagedMachinePoolMachine for each instance of the node pool, which
+// Cluster API uses to create a Machine for each of them. The instances of GCPManagedMachinePoolMachines being
+// deleted, e.g. because their Machine was deleted, are deleted from their managed instance group, and the
+// Machines of instances that no longer exist are deleted. It returns true while instances are being deleted.
+func (s *Service) reconcileMachinePoolMachines(ctx context.Context, nodePool *containerpb.NodePool, instances []*computepb.ManagedInstance, log *logr.Logger) (bool, error) {
+	s.scope.GCPManagedMachinePool.Status.InfrastructureMachineKind = infrav1exp.GCPManagedMachinePoolMachineKind
+
+	machines, err := s.listMachinePoolMachines(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	instancesByProviderID := map[string]*computepb.ManagedInstance{}
+	for _, instance := range instances {
+		providerID, err := providerid.NewFromResourceURL(instance.GetInstance())
+		if err != nil {
+			return false, errors.Wrap(err, "error parsing instance url")
+		}
+		instancesByProviderID[providerID.String()] = instance
+	}
+
+	deleting := false
+	errs := []error{}
+	for i := range machines {
+		machine := &machines[i]
+		instance := instancesByProviderID[machine.Spec.ProviderID]
+		delete(instancesByProviderID, machine.Spec.ProviderID)
+
+		switch {
+		case !machine.DeletionTimestamp.IsZero():
+			deleting = true
+			if err := s.deleteMachinePoolMachineInstance(ctx, nodePool, machine, instance, log); err != nil {
+				errs = append(errs, err)
+			}
+		case instance == nil:
+			if err := s.deleteMachinePoolMachine(ctx, machine, log); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			if err := s.updateMachinePoolMachine(ctx, machine, instance); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for providerID, instance := range instancesByProviderID {
+		if err := s.createMachinePoolMachine(ctx, providerID, instance, log); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return deleting, kerrors.NewAggregate(errs)
+}
+
+// releaseMachinePoolMachines removes the finalizer of the GCPManagedMachinePoolMachines once the node pool is
+// deleted, so that they are garbage collected together with the GCPManagedMachinePool.
+func (s *Service) releaseMachinePoolMachines(ctx context.Context) error {
+	machines, err := s.listMachinePoolMachines(ctx)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for i := range machines {
+		if err := s.removeMachinePoolMachineFinalizer(ctx, &machines[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (s *Service) machinePoolMachineLabels() map[string]string {
+	return map[string]string{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(s.scope.MachinePool.Name),
+		clusterv1.ClusterNameLabel:     s.scope.MachinePool.Spec.ClusterName,
+	}
+}
+
+func (s *Service) listMachinePoolMachines(ctx context.Context) ([]infrav1exp.GCPManagedMachinePoolMachine, error) {
+	machineList := &infrav1exp.GCPManagedMachinePoolMachineList{}
+	if err := s.scope.Client().List(ctx, machineList, client.InNamespace(s.scope.GCPManagedMachinePool.Namespace), client.MatchingLabels(s.machinePoolMachineLabels())); err != nil {
+		return nil, errors.Wrap(err, "error listing GCPManagedMachinePoolMachines")
+	}
+
+	return machineList.Items, nil
+}
+
+func (s *Service) createMachinePoolMachine(ctx context.Context, providerID string, instance *computepb.ManagedInstance, log *logr.Logger) error {
+	resourceURL, err := resourceurl.Parse(instance.GetInstance())
+	if err != nil {
+		return errors.Wrap(err, "error parsing instance url")
+	}
+
+	pool := s.scope.GCPManagedMachinePool
+	machine := &infrav1exp.GCPManagedMachinePoolMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceURL.Name,
+			Namespace: pool.Namespace,
+			Labels:    s.machinePoolMachineLabels(),
+			// The Machine created by Cluster API becomes the controller of the GCPManagedMachinePoolMachine.
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: infrav1exp.GroupVersion.String(),
+				Kind:       "GCPManagedMachinePool",
+				Name:       pool.Name,
+				UID:        pool.UID,
+			}},
+			Finalizers: []string{infrav1exp.ManagedMachinePoolMachineFinalizer},
+		},
+		Spec: infrav1exp.GCPManagedMachinePoolMachineSpec{
+			ProviderID: providerID,
+		},
+	}
+	log.Info("Creating GCPManagedMachinePoolMachine", "name", machine.Name, "providerID", providerID)
+	if err := s.scope.Client().Create(ctx, machine); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// The GCPManagedMachinePoolMachine was created by an earlier reconciliation but isn't in the cache yet.
+			return nil
+		}
+		return errors.Wrapf(err, "error creating GCPManagedMachinePoolMachine %s", machine.Name)
+	}
+
+	return s.updateMachinePoolMachine(ctx, machine, instance)
+}
+
+func (s *Service) updateMachinePoolMachine(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine, instance *computepb.ManagedInstance) error {
+	helper, err := patch.NewHelper(machine, s.scope.Client())
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+
+	machine.Status.Ready = instanceReady(instance)
+	machine.Status.InstanceStatus = instance.GetInstanceStatus()
+	machine.Status.CurrentAction = instance.GetCurrentAction()
+
+	return helper.Patch(ctx, machine)
+}
+
+// deleteMachinePoolMachine deletes the Machine of an instance that no longer exists, or the
+// GCPManagedMachinePoolMachine itself if Cluster API hasn't created a Machine for it.
+func (s *Service) deleteMachinePoolMachine(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine, log *logr.Logger) error {
+	var obj client.Object = machine
+	if ref := metav1.GetControllerOf(machine); ref != nil && ref.Kind == "Machine" {
+		obj = &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: machine.Namespace}}
+	}
+
+	log.Info("Instance no longer exists, deleting its machine", "name", machine.Name, "providerID", machine.Spec.ProviderID)
+	if err := s.scope.Client().Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting machine of instance %s", machine.Spec.ProviderID)
+	}
+
+	return nil
+}
+
+// deleteMachinePoolMachineInstance deletes the instance of a GCPManagedMachinePoolMachine from its managed instance
+// group and removes the finalizer once the instance is gone and the deletion operation completed. Deleting the
+// instance reduces the size of the managed instance group, the node pool is resized to the replicas of the
+// MachinePool afterwards.
+func (s *Service) deleteMachinePoolMachineInstance(ctx context.Context, nodePool *containerpb.NodePool, machine *infrav1exp.GCPManagedMachinePoolMachine, instance *computepb.ManagedInstance, log *logr.Logger) error {
+	resource, err := instanceResource(machine.Spec.ProviderID)
+	if err != nil {
+		return err
+	}
+	if pending, err := s.checkInstanceOperation(ctx, resource); err != nil {
+		return errors.Wrapf(err, "error deleting instance %s", machine.Spec.ProviderID)
+	} else if pending {
+		log.V(4).Info("Instance deletion in progress", "name", machine.Name)
+		return nil
+	}
+
+	if instance == nil {
+		return s.removeMachinePoolMachineFinalizer(ctx, machine)
+	}
+	if instance.GetCurrentAction() == computepb.ManagedInstance_DELETING.String() {
+		log.V(4).Info("Instance deletion in progress", "name", machine.Name)
+		return nil
+	}
+
+	instanceURL, err := resourceurl.Parse(instance.GetInstance())
+	if err != nil {
+		return errors.Wrap(err, "error parsing instance url")
+	}
+	instanceGroupURL, err := instanceGroupForZone(nodePool, instanceURL.Location)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Deleting instance from managed instance group", "name", machine.Name, "instanceGroup", instanceGroupURL.Name)
+	deleteInstancesRequest := &computepb.DeleteInstancesInstanceGroupManagerRequest{
+		InstanceGroupManager: instanceGroupURL.Name,
+		Project:              instanceGroupURL.Project,
+		Zone:                 instanceGroupURL.Location,
+		InstanceGroupManagersDeleteInstancesRequestResource: &computepb.InstanceGroupManagersDeleteInstancesRequest{
+			Instances:                      []string{instance.GetInstance()},
+			SkipInstancesOnValidationError: pointer.Bool(true),
+		},
+	}
+	op, err := s.scope.InstanceGroupManagersClient().DeleteInstances(ctx, deleteInstancesRequest)
+	if err != nil {
+		return errors.Wrapf(err, "error deleting instance %s", machine.Spec.ProviderID)
+	}
+	if err := s.setInstanceOperation(resource, op.Proto()); err != nil {
+		return errors.Wrapf(err, "error deleting instance %s", machine.Spec.ProviderID)
+	}
+
+	return nil
+}
+
+// instanceResource returns the relative name of the instance with the provider ID, under which the operations on
+// it are tracked.
+func instanceResource(providerID string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(providerID, providerid.Prefix), "/")
+	if !strings.HasPrefix(providerID, providerid.Prefix) || len(parts) != 3 {
+		return "", errors.Errorf("invalid provider id %s", providerID)
+	}
+
+	return operations.ResourceName(parts[0], "instances", meta.ZonalKey(parts[2], parts[1])), nil
+}
+
+func (s *Service) removeMachinePoolMachineFinalizer(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine) error {
+	if !controllerutil.ContainsFinalizer(machine, infrav1exp.ManagedMachinePoolMachineFinalizer) {
+		return nil
+	}
+
+	helper, err := patch.NewHelper(machine, s.scope.Client())
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	controllerutil.RemoveFinalizer(machine, infrav1exp.ManagedMachinePoolMachineFinalizer)
+
+	return helper.Patch(ctx, machine)
+}
+
+// instanceGroupForZone returns the managed instance group of the node pool in the zone.
+func instanceGroupForZone(nodePool *containerpb.NodePool, zone string) (resourceurl.ResourceURL, error) {
+	for _, url := range nodePool.GetInstanceGroupUrls() {
+		resourceURL, err := resourceurl.Parse(url)
+		if err != nil {
+			return resourceurl.ResourceURL{}, errors.Wrap(err, "error parsing instance group url")
+		}
+		if resourceURL.Location == zone {
+			return resourceURL, nil
+		}
+	}
+
+	return resourceurl.ResourceURL{}, fmt.Errorf("node pool %s has no instance group in zone %s", nodePool.GetName(), zone)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestInstanceGroupForZone(t *testing.T) {
+	nodePool := &containerpb.NodePool{
+		Name: "pool",
+		InstanceGroupUrls: []string{
+			"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroupManagers/gke-pool-a",
+			"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-b/instanceGroupManagers/gke-pool-b",
+		},
+	}
+
+	instanceGroup, err := instanceGroupForZone(nodePool, "us-central1-b")
+	if err != nil {
+		t.Fatalf("instanceGroupForZone() error = %v", err)
+	}
+	if instanceGroup.Name != "gke-pool-b" || instanceGroup.Project != "my-project" {
+		t.Errorf("instanceGroupForZone() = %+v, want gke-pool-b in my-project", instanceGroup)
+	}
+
+	if _, err := instanceGroupForZone(nodePool, "us-central1-c"); err == nil {
+		t.Errorf("instanceGroupForZone() expected error for zone without instance group")
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateSize(t *testing.T) {
+	running := &computepb.ManagedInstance{CurrentAction: pointer.String("NONE")}
+	deleting := &computepb.ManagedInstance{CurrentAction: pointer.String("DELETING")}
+
+	tests := []struct {
+		name      string
+		scaling   *infrav1exp.NodePoolAutoScaling
+		instances []*computepb.ManagedInstance
+		want      bool
+	}{
+		{
+			name:      "size matches",
+			instances: []*computepb.ManagedInstance{running, running},
+			want:      false,
+		},
+		{
+			name:      "instance deleted",
+			instances: []*computepb.ManagedInstance{running, deleting},
+			want:      true,
+		},
+		{
+			name:      "instance deleted with cluster-autoscaler",
+			scaling:   &infrav1exp.NodePoolAutoScaling{MinCount: pointer.Int32(1), MaxCount: pointer.Int32(3), Autoscaler: infrav1exp.KubernetesClusterAutoscaler},
+			instances: []*computepb.ManagedInstance{running},
+			want:      true,
+		},
+		{
+			name: "instances unknown",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedMachinePoolScope{
+				MachinePool: &clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{Replicas: pointer.Int32(2)}},
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+					Spec: infrav1exp.GCPManagedControlPlaneSpec{Project: "my-project", Location: "us-central1", ClusterName: "my-cluster"},
+				},
+				GCPManagedMachinePool: &infrav1exp.GCPManagedMachinePool{
+					Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool", NodeLocations: []string{"us-central1-a"}, Scaling: tt.scaling},
+				},
+			})
+			got, req := s.checkDiffAndPrepareUpdateSize(&containerpb.NodePool{InitialNodeCount: 2}, tt.instances)
+			if got != tt.want {
+				t.Errorf("checkDiffAndPrepareUpdateSize() = %v, want %v", got, tt.want)
+			}
+			if got && req.NodeCount != 2 {
+				t.Errorf("checkDiffAndPrepareUpdateSize() node count = %d, want 2", req.NodeCount)
+			}
+		})
+	}
+}
+
+func TestInstanceResource(t *testing.T) {
+	resource, err := instanceResource("gce://my-project/us-central1-a/gke-pool-abcd")
+	if err != nil {
+		t.Fatalf("instanceResource() error = %v", err)
+	}
+	if want := "projects/my-project/zones/us-central1-a/instances/gke-pool-abcd"; resource != want {
+		t.Errorf("instanceResource() = %q, want %q", resource, want)
+	}
+
+	if _, err := instanceResource("gce://my-project/gke-pool-abcd"); err == nil {
+		t.Errorf("instanceResource() expected error for provider id without zone")
+	}
+}
+
+func TestComputeOperation(t *testing.T) {
+	running := computeOperation(&computepb.Operation{
+		Status:        computepb.Operation_RUNNING.Enum(),
+		SelfLink:      pointer.String("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/operations/operation-123"),
+		OperationType: pointer.String("deleteInstances"),
+	})
+	if running.Status != "RUNNING" || running.OperationType != "deleteInstances" || running.Error != nil {
+		t.Errorf("computeOperation() = %+v, want a running deleteInstances operation", running)
+	}
+
+	failed := computeOperation(&computepb.Operation{
+		Status:              computepb.Operation_DONE.Enum(),
+		HttpErrorStatusCode: pointer.Int32(400),
+		Error: &computepb.Error{
+			Errors: []*computepb.Errors{{Code: pointer.String("RESOURCE_NOT_READY"), Message: pointer.String("not ready")}},
+		},
+	})
+	if failed.Status != "DONE" || failed.HttpErrorStatusCode != 400 || failed.Error == nil || failed.Error.Errors[0].Code != "RESOURCE_NOT_READY" {
+		t.Errorf("computeOperation() = %+v, want a failed operation", failed)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/operations"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// checkPendingOperation polls the GKE operation started on the node pool by an earlier reconciliation. It returns
+// true while the operation is running, in which case no other operation may be started on the node pool. The result
+// of a completed operation is reported in the GKEMachinePoolOperationSucceeded condition.
+func (s *Service) checkPendingOperation(ctx context.Context, log *logr.Logger) (bool, error) {
+	pending := s.scope.GCPManagedMachinePool.Status.PendingOperation
+	if pending == nil {
+		return false, nil
+	}
+
+	op, err := shared.GetGKEOperation(ctx, s.scope.ManagedMachinePoolClient(), pending.Name)
+	if err != nil {
+		log.Error(err, "Error getting GKE operation", "operation", pending.Name)
+		return false, err
+	}
+	if op == nil {
+		log.Info("GKE operation not found, forgetting it", "operation", pending.Name)
+		s.scope.GCPManagedMachinePool.Status.PendingOperation = nil
+		return false, nil
+	}
+	if op.GetStatus() != containerpb.Operation_DONE {
+		log.V(2).Info("GKE operation in progress", "operation", pending.Name, "type", pending.Type)
+		return true, nil
+	}
+
+	s.scope.GCPManagedMachinePool.Status.PendingOperation = nil
+	if opErr := shared.GKEOperationError(op); opErr != nil {
+		log.Error(opErr, "GKE operation failed", "operation", pending.Name, "type", pending.Type)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolOperationSucceededCondition, infrav1exp.GKEMachinePoolOperationFailedReason, clusterv1.ConditionSeverityError, "%s operation failed: %v", pending.Type, opErr)
+		return false, nil
+	}
+	log.V(2).Info("GKE operation completed", "operation", pending.Name, "type", pending.Type)
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolOperationSucceededCondition)
+
+	return false, nil
+}
+
+// setPendingOperation records the GKE operation started on the node pool.
+func (s *Service) setPendingOperation(op *containerpb.Operation) {
+	location := fmt.Sprintf("projects/%s/locations/%s", s.scope.GCPManagedControlPlane.Spec.Project, s.scope.Region())
+	s.scope.GCPManagedMachinePool.Status.PendingOperation = shared.NewGKEOperation(op, location)
+}
+
+// checkInstanceOperation polls the GCE operation started on an instance of the node pool by an earlier
+// reconciliation. It returns true while the operation is running. A completed operation is no longer tracked,
+// and its error is returned if it failed.
+func (s *Service) checkInstanceOperation(ctx context.Context, resource string) (bool, error) {
+	return operations.Check(ctx, zoneOperationsGetter{s: s}, s.scope, resource)
+}
+
+// setInstanceOperation records the GCE operation started on an instance of the node pool. An operation that is
+// already done isn't recorded, and its error is returned if it failed.
+func (s *Service) setInstanceOperation(resource string, op *computepb.Operation) error {
+	return operations.Track(s.scope, resource, computeOperation(op))
+}
+
+// zoneOperationsGetter gets the GCE operations started on the instances of the node pool.
+type zoneOperationsGetter struct {
+	s *Service
+}
+
+// Get returns the operation with the self link.
+func (g zoneOperationsGetter) Get(ctx context.Context, selfLink string) (*compute.Operation, error) {
+	id, err := cloud.ParseResourceURL(selfLink)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := g.s.scope.ZoneOperationsClient().Get(ctx, &computepb.GetZoneOperationRequest{
+		Project:   id.ProjectID,
+		Zone:      id.Key.Zone,
+		Operation: id.Key.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return computeOperation(op), nil
+}
+
+// computeOperation converts an operation of the compute client of the node pool to the operation type the GCE
+// operations are tracked with.
+func computeOperation(op *computepb.Operation) *compute.Operation {
+	converted := &compute.Operation{
+		Status:              op.GetStatus().String(),
+		SelfLink:            op.GetSelfLink(),
+		OperationType:       op.GetOperationType(),
+		InsertTime:          op.GetInsertTime(),
+		HttpErrorStatusCode: int64(op.GetHttpErrorStatusCode()),
+	}
+	if errs := op.GetError().GetErrors(); len(errs) > 0 {
+		converted.Error = &compute.OperationError{}
+		for _, e := range errs {
+			converted.Error.Errors = append(converted.Error.Errors, &compute.OperationErrorErrors{Code: e.GetCode(), Message: e.GetMessage()})
+		}
+	}
+
+	return converted
+}
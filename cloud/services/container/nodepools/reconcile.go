@@ -21,23 +21,23 @@ import (
 	"fmt"
 	"reflect"
 
-	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
 
 	"google.golang.org/api/iterator"
-	"google.golang.org/grpc/codes"
 
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/go-logr/logr"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -58,18 +58,27 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool not found, creating", "cluster", s.scope.Cluster.Name)
 		s.scope.GCPManagedMachinePool.Status.Ready = false
 		if err = s.createNodePool(ctx, &log); err != nil {
-			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			severity := shared.ErrorConditionSeverity(err)
+			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, severity, err.Error())
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, severity, err.Error())
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, severity, err.Error())
+			if gcperrors.IsTerminal(err) {
+				// GKE rejected the node pool, it won't be created until its spec is fixed.
+				s.setFailure(capierrors.InvalidConfigurationMachinePoolError, err.Error())
+			}
 			return ctrl.Result{}, err
 		}
 		log.Info("Node pool provisioning in progress")
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
 	}
 	log.V(2).Info("Node pool found", "cluster", s.scope.Cluster.Name, "nodepool", nodePool.Name)
+	if !s.isNodePoolManaged(nodePool) && !s.adoptNodePool(nodePool, &log) {
+		s.scope.GCPManagedMachinePool.Status.Ready = false
+		return ctrl.Result{}, nil
+	}
 
 	instances, err := s.getInstances(ctx, nodePool)
 	if err != nil {
@@ -90,6 +99,18 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		providerIDList = append(providerIDList, providerID.String())
 	}
 	s.scope.GCPManagedMachinePool.Spec.ProviderIDList = providerIDList
+	s.setStatusFromNodePool(nodePool, instances)
+
+	deletingMachines, err := s.reconcileMachinePoolMachines(ctx, nodePool, instances, &log)
+	if err != nil {
+		log.Error(err, "Error reconciling GCPManagedMachinePoolMachines", "name", s.scope.GCPManagedMachinePool.Name)
+		return ctrl.Result{}, err
+	}
+
+	operationPending, err := s.checkPendingOperation(ctx, &log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
 	switch nodePool.Status {
 	case containerpb.NodePool_PROVISIONING:
@@ -103,6 +124,9 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool reconciling in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		if operationPending {
+			return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+		}
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	case containerpb.NodePool_STOPPING:
 		log.Info("Node pool stopping in progress")
@@ -111,25 +135,54 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolDeletingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition)
 		return ctrl.Result{}, nil
-	case containerpb.NodePool_ERROR, containerpb.NodePool_RUNNING_WITH_ERROR:
-		var msg string
-		if len(nodePool.Conditions) > 0 {
-			msg = nodePool.Conditions[0].GetMessage()
-		}
-		log.Error(errors.New("Node pool in error/degraded state"), msg, "name", s.scope.GCPManagedMachinePool.Name)
+	case containerpb.NodePool_ERROR:
+		msg := conditionsMessage(nodePool)
+		log.Error(errors.New("Node pool in error state"), msg, "name", s.scope.GCPManagedMachinePool.Name)
+		// GKE can recover the node pool, keep polling it rather than failing the MachinePool.
 		s.scope.GCPManagedMachinePool.Status.Ready = false
-		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolErrorReason, clusterv1.ConditionSeverityError, "")
-		return ctrl.Result{}, nil
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolErrorReason, clusterv1.ConditionSeverityError, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolErrorReason, clusterv1.ConditionSeverityError, msg)
+		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+	case containerpb.NodePool_RUNNING_WITH_ERROR:
+		msg := conditionsMessage(nodePool)
+		log.Error(errors.New("Node pool in degraded state"), msg, "name", s.scope.GCPManagedMachinePool.Name)
+		s.clearFailure()
+		s.scope.GCPManagedMachinePool.Status.Ready = false
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolDegradedReason, clusterv1.ConditionSeverityWarning, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolDegradedReason, clusterv1.ConditionSeverityWarning, msg)
+		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	case containerpb.NodePool_RUNNING:
 		log.Info("Node pool running")
+		s.clearFailure()
 	default:
 		log.Error(errors.New("Unhandled node pool status"), fmt.Sprintf("Unhandled node pool status %s", nodePool.Status), "name", s.scope.GCPManagedMachinePool.Name)
 		return ctrl.Result{}, nil
 	}
 
+	// GKE rejects operations on the node pool while another one is running.
+	if operationPending {
+		log.Info("Waiting for GKE operation to complete", "operation", s.scope.GCPManagedMachinePool.Status.PendingOperation.Name)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
 	needUpdateVersionOrImage, nodePoolUpdateVersionOrImage := s.checkDiffAndPrepareUpdateVersionOrImage(nodePool)
 	if needUpdateVersionOrImage {
 		log.Info("Version/image update required")
+		allowed, waitMessage, err := s.checkVersionUpgradeAllowed(ctx, nodePoolUpdateVersionOrImage.NodeVersion)
+		if errors.Is(err, shared.ErrUnsupportedVersionSkew) {
+			// Only a change of the MachinePool version can fix this, which triggers a new reconciliation.
+			log.Error(err, "Node pool version not supported by the control plane")
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolVersionSkewReason, clusterv1.ConditionSeverityError, err.Error())
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !allowed {
+			log.Info("Node pool version update pending", "reason", waitMessage)
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpgradePendingReason, clusterv1.ConditionSeverityInfo, waitMessage)
+			return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		}
 		err = s.updateNodePoolVersionOrImage(ctx, nodePoolUpdateVersionOrImage)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -137,7 +190,20 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool version/image updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateConfig, nodePoolUpdateConfig := s.checkDiffAndPrepareUpdateConfig(nodePool)
+	if needUpdateConfig {
+		log.Info("Node config update required")
+		err = s.updateNodePoolConfig(ctx, nodePoolUpdateConfig)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Node pool config updating in progress")
+		s.scope.GCPManagedMachinePool.Status.Ready = true
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
 	}
 
 	needUpdateAutoscaling, setNodePoolAutoscalingRequest := s.checkDiffAndPrepareUpdateAutoscaling(nodePool)
@@ -150,22 +216,75 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool auto scaling updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
 	}
 
-	needUpdateSize, setNodePoolSizeRequest := s.checkDiffAndPrepareUpdateSize(nodePool)
-	if needUpdateSize {
-		log.Info("Size update required")
-		err = s.updateNodePoolSize(ctx, setNodePoolSizeRequest)
+	needUpdateManagement, setNodePoolManagementRequest := s.checkDiffAndPrepareUpdateManagement(nodePool)
+	if needUpdateManagement {
+		log.Info("Node management update required")
+		err = s.updateNodePoolManagement(ctx, setNodePoolManagementRequest)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		log.Info("Node pool size updating in progress")
+		log.Info("Node pool node management updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateUpgradeSettings, nodePoolUpdateUpgradeSettings := s.checkDiffAndPrepareUpdateUpgradeSettings(nodePool)
+	if needUpdateUpgradeSettings {
+		log.Info("Upgrade settings update required")
+		err = s.updateNodePoolUpgradeSettings(ctx, nodePoolUpdateUpgradeSettings)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Node pool upgrade settings updating in progress")
+		s.scope.GCPManagedMachinePool.Status.Ready = true
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	needUpdateNetwork, nodePoolUpdateNetwork := s.checkDiffAndPrepareUpdateNetwork(nodePool)
+	if needUpdateNetwork {
+		log.Info("Node locations or network config update required")
+		err = s.updateNodePoolConfig(ctx, nodePoolUpdateNetwork)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Node pool network updating in progress")
+		s.scope.GCPManagedMachinePool.Status.Ready = true
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
+	if deletingMachines {
+		// The node pool is resized once the instances of the deleted machines are gone, so that scaling down
+		// doesn't delete other instances.
+		log.Info("Waiting for the instances of deleted machines to be deleted")
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
+	// The size of the node pool isn't set if an autoscaler changes it, which would otherwise be reverted.
+	if !s.replicasManagedExternally() {
+		needUpdateSize, setNodePoolSizeRequest := s.checkDiffAndPrepareUpdateSize(nodePool, instances)
+		if needUpdateSize {
+			log.Info("Size update required")
+			err = s.updateNodePoolSize(ctx, setNodePoolSizeRequest)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			log.Info("Node pool size updating in progress")
+			s.scope.GCPManagedMachinePool.Status.Ready = true
+			conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+			return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+		}
+	}
+
+	if err := s.reconcileMachinePoolReplicas(ctx, instances, &log); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpdatedReason, clusterv1.ConditionSeverityInfo, "")
 
 	s.scope.SetReplicas(int32(len(s.scope.GCPManagedMachinePool.Spec.ProviderIDList)))
@@ -189,9 +308,21 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	}
 	if nodePool == nil {
 		log.Info("Node pool already deleted")
+		s.scope.GCPManagedMachinePool.Status.PendingOperation = nil
+		if err := s.releaseMachinePoolMachines(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolDeletedReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, err
 	}
+	if !s.isNodePoolManaged(nodePool) {
+		log.Info("Node pool was not created by the provider, skipping deletion", "nodepool", nodePool.Name)
+		if err := s.releaseMachinePoolMachines(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolDeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
 
 	switch nodePool.Status {
 	case containerpb.NodePool_PROVISIONING:
@@ -209,6 +340,15 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		break
 	}
 
+	operationPending, err := s.checkPendingOperation(ctx, &log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if operationPending {
+		log.Info("Waiting for GKE operation to complete before deleting the node pool", "operation", s.scope.GCPManagedMachinePool.Status.PendingOperation.Name)
+		return ctrl.Result{RequeueAfter: shared.OperationPollInterval}, nil
+	}
+
 	if err = s.deleteNodePool(ctx); err != nil {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
@@ -228,11 +368,8 @@ func (s *Service) describeNodePool(ctx context.Context, log *logr.Logger) (*cont
 	}
 	nodePool, err := s.scope.ManagedMachinePoolClient().GetNodePool(ctx, getNodePoolRequest)
 	if err != nil {
-		var e *apierror.APIError
-		if ok := errors.As(err, &e); ok {
-			if e.GRPCStatus().Code() == codes.NotFound {
-				return nil, nil
-			}
+		if gcperrors.IsNotFound(err) {
+			return nil, nil
 		}
 		log.Error(err, "Error getting GKE node pool", "name", s.scope.GCPManagedMachinePool.Name)
 		return nil, err
@@ -276,43 +413,75 @@ func (s *Service) createNodePool(ctx context.Context, log *logr.Logger) error {
 		return fmt.Errorf("preflight checks on machine pool before creating: %w", err)
 	}
 
-	isRegional := shared.IsRegional(s.scope.Region())
-
 	createNodePoolRequest := &containerpb.CreateNodePoolRequest{
-		NodePool: scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, isRegional),
+		NodePool: scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region()),
 		Parent:   s.scope.NodePoolLocation(),
 	}
-	_, err := s.scope.ManagedMachinePoolClient().CreateNodePool(ctx, createNodePoolRequest)
+	op, err := s.scope.ManagedMachinePoolClient().CreateNodePool(ctx, createNodePoolRequest)
 	if err != nil {
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
 
 func (s *Service) updateNodePoolVersionOrImage(ctx context.Context, updateNodePoolRequest *containerpb.UpdateNodePoolRequest) error {
-	_, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, updateNodePoolRequest)
+	op, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, updateNodePoolRequest)
+	if err != nil {
+		return err
+	}
+	s.setPendingOperation(op)
+
+	return nil
+}
+
+func (s *Service) updateNodePoolConfig(ctx context.Context, updateNodePoolRequest *containerpb.UpdateNodePoolRequest) error {
+	op, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, updateNodePoolRequest)
 	if err != nil {
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
 
 func (s *Service) updateNodePoolAutoscaling(ctx context.Context, setNodePoolAutoscalingRequest *containerpb.SetNodePoolAutoscalingRequest) error {
-	_, err := s.scope.ManagedMachinePoolClient().SetNodePoolAutoscaling(ctx, setNodePoolAutoscalingRequest)
+	op, err := s.scope.ManagedMachinePoolClient().SetNodePoolAutoscaling(ctx, setNodePoolAutoscalingRequest)
+	if err != nil {
+		return err
+	}
+	s.setPendingOperation(op)
+
+	return nil
+}
+
+func (s *Service) updateNodePoolManagement(ctx context.Context, setNodePoolManagementRequest *containerpb.SetNodePoolManagementRequest) error {
+	op, err := s.scope.ManagedMachinePoolClient().SetNodePoolManagement(ctx, setNodePoolManagementRequest)
+	if err != nil {
+		return err
+	}
+	s.setPendingOperation(op)
+
+	return nil
+}
+
+func (s *Service) updateNodePoolUpgradeSettings(ctx context.Context, updateNodePoolRequest *containerpb.UpdateNodePoolRequest) error {
+	op, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, updateNodePoolRequest)
 	if err != nil {
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
 
 func (s *Service) updateNodePoolSize(ctx context.Context, setNodePoolSizeRequest *containerpb.SetNodePoolSizeRequest) error {
-	_, err := s.scope.ManagedMachinePoolClient().SetNodePoolSize(ctx, setNodePoolSizeRequest)
+	op, err := s.scope.ManagedMachinePoolClient().SetNodePoolSize(ctx, setNodePoolSizeRequest)
 	if err != nil {
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
@@ -321,10 +490,11 @@ func (s *Service) deleteNodePool(ctx context.Context) error {
 	deleteNodePoolRequest := &containerpb.DeleteNodePoolRequest{
 		Name: s.scope.NodePoolFullName(),
 	}
-	_, err := s.scope.ManagedMachinePoolClient().DeleteNodePool(ctx, deleteNodePoolRequest)
+	op, err := s.scope.ManagedMachinePoolClient().DeleteNodePool(ctx, deleteNodePoolRequest)
 	if err != nil {
 		return err
 	}
+	s.setPendingOperation(op)
 
 	return nil
 }
@@ -335,34 +505,136 @@ func (s *Service) checkDiffAndPrepareUpdateVersionOrImage(existingNodePool *cont
 		Name: s.scope.NodePoolFullName(),
 	}
 	// Node version
-	if s.scope.NodePoolVersion() != nil && *s.scope.NodePoolVersion() != existingNodePool.Version {
+	if s.scope.NodePoolVersion() != nil && !shared.VersionSatisfies(existingNodePool.Version, *s.scope.NodePoolVersion()) {
 		needUpdate = true
-		updateNodePoolRequest.NodeVersion = *s.scope.NodePoolVersion()
+		updateNodePoolRequest.NodeVersion = shared.NormalizeVersion(*s.scope.NodePoolVersion())
+	}
+	return needUpdate, &updateNodePoolRequest
+}
+
+func (s *Service) checkDiffAndPrepareUpdateConfig(existingNodePool *containerpb.NodePool) (bool, *containerpb.UpdateNodePoolRequest) {
+	needUpdate := false
+	updateNodePoolRequest := containerpb.UpdateNodePoolRequest{
+		Name: s.scope.NodePoolFullName(),
 	}
+
+	desiredConfig := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region()).Config
+	existingConfig := existingNodePool.GetConfig()
+
 	// Kubernetes labels
-	if !reflect.DeepEqual(map[string]string(s.scope.GCPManagedMachinePool.Spec.KubernetesLabels), existingNodePool.Config.Labels) {
+	if !compareStringMaps(desiredConfig.Labels, existingConfig.GetLabels()) {
 		needUpdate = true
 		updateNodePoolRequest.Labels = &containerpb.NodeLabels{
-			Labels: s.scope.GCPManagedMachinePool.Spec.KubernetesLabels,
+			Labels: desiredConfig.Labels,
 		}
 	}
 	// Kubernetes taints
-	desiredKubernetesTaints := infrav1exp.ConvertToSdkTaint(s.scope.GCPManagedMachinePool.Spec.KubernetesTaints)
-	if !reflect.DeepEqual(desiredKubernetesTaints, existingNodePool.Config.Taints) {
+	if !compareTaints(desiredConfig.Taints, existingConfig.GetTaints()) {
 		needUpdate = true
 		updateNodePoolRequest.Taints = &containerpb.NodeTaints{
-			Taints: desiredKubernetesTaints,
+			Taints: desiredConfig.Taints,
+		}
+	}
+	// Network tags
+	if !compareStringSets(desiredConfig.Tags, existingConfig.GetTags()) {
+		needUpdate = true
+		updateNodePoolRequest.Tags = &containerpb.NetworkTags{
+			Tags: desiredConfig.Tags,
+		}
+	}
+	// Resource labels
+	if desiredConfig.ResourceLabels != nil && !compareStringMaps(desiredConfig.ResourceLabels, existingConfig.GetResourceLabels()) {
+		needUpdate = true
+		updateNodePoolRequest.ResourceLabels = &containerpb.ResourceLabels{
+			Labels: desiredConfig.ResourceLabels,
 		}
 	}
+	// Linux node config
+	if desiredConfig.LinuxNodeConfig != nil && !compareLinuxNodeConfig(desiredConfig.LinuxNodeConfig, existingConfig.GetLinuxNodeConfig()) {
+		needUpdate = true
+		updateNodePoolRequest.LinuxNodeConfig = desiredConfig.LinuxNodeConfig
+	}
+	// Kubelet config
+	if desiredConfig.KubeletConfig != nil && !compareKubeletConfig(desiredConfig.KubeletConfig, existingConfig.GetKubeletConfig()) {
+		needUpdate = true
+		updateNodePoolRequest.KubeletConfig = desiredConfig.KubeletConfig
+	}
 	return needUpdate, &updateNodePoolRequest
 }
 
+// compareStringMaps returns true if the two maps are equal, nil and empty maps being equal.
+func compareStringMaps(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareStringSets returns true if the two slices contain the same elements, regardless of their order.
+func compareStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return sets.New(a...).Equal(sets.New(b...))
+}
+
+// compareTaints returns true if the two lists of taints are equal regardless of their order.
+// Taints are identified by their key and effect, as GKE doesn't preserve the order they are specified in.
+func compareTaints(a, b []*containerpb.NodeTaint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	type taintKey struct {
+		key    string
+		effect containerpb.NodeTaint_Effect
+	}
+	values := make(map[taintKey]string, len(a))
+	for _, taint := range a {
+		values[taintKey{key: taint.Key, effect: taint.Effect}] = taint.Value
+	}
+	for _, taint := range b {
+		value, ok := values[taintKey{key: taint.Key, effect: taint.Effect}]
+		if !ok || value != taint.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// compareLinuxNodeConfig returns true if the existing Linux node config matches the desired one.
+// The cgroup mode is only compared when it is specified.
+func compareLinuxNodeConfig(desired, existing *containerpb.LinuxNodeConfig) bool {
+	if !compareStringMaps(desired.Sysctls, existing.GetSysctls()) {
+		return false
+	}
+	if desired.CgroupMode != containerpb.LinuxNodeConfig_CGROUP_MODE_UNSPECIFIED && desired.CgroupMode != existing.GetCgroupMode() {
+		return false
+	}
+	return true
+}
+
+// compareKubeletConfig returns true if the existing kubelet config matches the desired one.
+// Only the fields that are specified are compared as GKE fills the others with its defaults.
+func compareKubeletConfig(desired, existing *containerpb.NodeKubeletConfig) bool {
+	if desired.CpuManagerPolicy != "" && desired.CpuManagerPolicy != existing.GetCpuManagerPolicy() {
+		return false
+	}
+	if desired.CpuCfsQuota != nil && desired.CpuCfsQuota.GetValue() != existing.GetCpuCfsQuota().GetValue() {
+		return false
+	}
+	if desired.CpuCfsQuotaPeriod != "" && desired.CpuCfsQuotaPeriod != existing.GetCpuCfsQuotaPeriod() {
+		return false
+	}
+	if desired.PodPidsLimit != 0 && desired.PodPidsLimit != existing.GetPodPidsLimit() {
+		return false
+	}
+	return true
+}
+
 func (s *Service) checkDiffAndPrepareUpdateAutoscaling(existingNodePool *containerpb.NodePool) (bool, *containerpb.SetNodePoolAutoscalingRequest) {
 	needUpdate := false
 
-	isRegional := shared.IsRegional(s.scope.Region())
-
-	desiredAutoscaling := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, isRegional).Autoscaling
+	desiredAutoscaling := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region()).Autoscaling
 	var existingAutoscaling *containerpb.NodePoolAutoscaling
 	if existingNodePool.Autoscaling != nil && existingNodePool.Autoscaling.Enabled {
 		existingAutoscaling = &containerpb.NodePoolAutoscaling{
@@ -382,20 +654,131 @@ func (s *Service) checkDiffAndPrepareUpdateAutoscaling(existingNodePool *contain
 	return needUpdate, &setNodePoolAutoscalingRequest
 }
 
-func (s *Service) checkDiffAndPrepareUpdateSize(existingNodePool *containerpb.NodePool) (bool, *containerpb.SetNodePoolSizeRequest) {
+func (s *Service) checkDiffAndPrepareUpdateManagement(existingNodePool *containerpb.NodePool) (bool, *containerpb.SetNodePoolManagementRequest) {
+	desiredManagement := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region()).Management
+	if desiredManagement == nil {
+		return false, nil
+	}
+	if desiredManagement.AutoRepair == existingNodePool.GetManagement().GetAutoRepair() &&
+		desiredManagement.AutoUpgrade == existingNodePool.GetManagement().GetAutoUpgrade() {
+		return false, nil
+	}
+
+	return true, &containerpb.SetNodePoolManagementRequest{
+		Name:       s.scope.NodePoolFullName(),
+		Management: desiredManagement,
+	}
+}
+
+func (s *Service) checkDiffAndPrepareUpdateUpgradeSettings(existingNodePool *containerpb.NodePool) (bool, *containerpb.UpdateNodePoolRequest) {
+	desiredUpgradeSettings := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, s.scope.Region()).UpgradeSettings
+	if desiredUpgradeSettings == nil || compareUpgradeSettings(desiredUpgradeSettings, existingNodePool.UpgradeSettings) {
+		return false, nil
+	}
+
+	return true, &containerpb.UpdateNodePoolRequest{
+		Name:            s.scope.NodePoolFullName(),
+		UpgradeSettings: desiredUpgradeSettings,
+	}
+}
+
+// compareUpgradeSettings returns true if the existing upgrade settings match the desired ones.
+// Blue-green settings that aren't specified are ignored as GKE fills them with its defaults.
+func compareUpgradeSettings(desired, existing *containerpb.NodePool_UpgradeSettings) bool {
+	existingStrategy := existing.GetStrategy()
+	if existingStrategy == containerpb.NodePoolUpdateStrategy_NODE_POOL_UPDATE_STRATEGY_UNSPECIFIED {
+		existingStrategy = containerpb.NodePoolUpdateStrategy_SURGE
+	}
+	if desired.GetStrategy() != existingStrategy {
+		return false
+	}
+
+	if desired.GetStrategy() != containerpb.NodePoolUpdateStrategy_BLUE_GREEN {
+		return desired.MaxSurge == existing.GetMaxSurge() && desired.MaxUnavailable == existing.GetMaxUnavailable()
+	}
+
+	desiredBlueGreen := desired.GetBlueGreenSettings()
+	existingBlueGreen := existing.GetBlueGreenSettings()
+	if desiredBlueGreen.GetNodePoolSoakDuration() != nil && desiredBlueGreen.GetNodePoolSoakDuration().AsDuration() != existingBlueGreen.GetNodePoolSoakDuration().AsDuration() {
+		return false
+	}
+	if desiredPolicy := desiredBlueGreen.GetStandardRolloutPolicy(); desiredPolicy != nil {
+		existingPolicy := existingBlueGreen.GetStandardRolloutPolicy()
+		switch desiredPolicy.UpdateBatchSize.(type) {
+		case *containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchPercentage:
+			if desiredPolicy.GetBatchPercentage() != existingPolicy.GetBatchPercentage() {
+				return false
+			}
+		case *containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchNodeCount:
+			if desiredPolicy.GetBatchNodeCount() != existingPolicy.GetBatchNodeCount() {
+				return false
+			}
+		}
+		if desiredPolicy.GetBatchSoakDuration() != nil && desiredPolicy.GetBatchSoakDuration().AsDuration() != existingPolicy.GetBatchSoakDuration().AsDuration() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkDiffAndPrepareUpdateNetwork checks whether the node locations or the private nodes setting of the node pool have to be updated.
+// The pod range and the maximum number of pods per node can't be changed once the node pool is created.
+func (s *Service) checkDiffAndPrepareUpdateNetwork(existingNodePool *containerpb.NodePool) (bool, *containerpb.UpdateNodePoolRequest) {
 	needUpdate := false
-	setNodePoolSizeRequest := containerpb.SetNodePoolSizeRequest{
+	updateNodePoolRequest := containerpb.UpdateNodePoolRequest{
 		Name: s.scope.NodePoolFullName(),
 	}
 
-	replicas := *s.scope.MachinePool.Spec.Replicas
-	if shared.IsRegional(s.scope.Region()) {
-		replicas /= cloud.DefaultNumRegionsPerZone
+	desiredLocations := s.scope.GCPManagedMachinePool.Spec.NodeLocations
+	if len(desiredLocations) > 0 && !compareStringSets(desiredLocations, existingNodePool.GetLocations()) {
+		needUpdate = true
+		updateNodePoolRequest.Locations = desiredLocations
+	}
+
+	if config := s.scope.GCPManagedMachinePool.Spec.NetworkConfig; config != nil && config.EnablePrivateNodes != nil &&
+		*config.EnablePrivateNodes != existingNodePool.GetNetworkConfig().GetEnablePrivateNodes() {
+		needUpdate = true
+		updateNodePoolRequest.NodeNetworkConfig = &containerpb.NodeNetworkConfig{
+			EnablePrivateNodes: config.EnablePrivateNodes,
+		}
 	}
 
+	return needUpdate, &updateNodePoolRequest
+}
+
+func (s *Service) checkDiffAndPrepareUpdateSize(existingNodePool *containerpb.NodePool, instances []*computepb.ManagedInstance) (bool, *containerpb.SetNodePoolSizeRequest) {
+	needUpdate := false
+	setNodePoolSizeRequest := containerpb.SetNodePoolSizeRequest{
+		Name: s.scope.NodePoolFullName(),
+	}
+
+	zoneCount := shared.NodePoolZoneCount(s.scope.GCPManagedMachinePool, s.scope.Region())
+	replicas := *s.scope.MachinePool.Spec.Replicas / zoneCount
+
 	if replicas != existingNodePool.InitialNodeCount {
 		needUpdate = true
+	}
+	// Instances deleted from the managed instance groups, e.g. together with their machine, reduce the size of the
+	// node pool without changing its initial node count.
+	if instances != nil && countActiveInstances(instances) != replicas*zoneCount {
+		needUpdate = true
+	}
+	if needUpdate {
 		setNodePoolSizeRequest.NodeCount = replicas
 	}
 	return needUpdate, &setNodePoolSizeRequest
 }
+
+// countActiveInstances returns the number of instances that aren't being deleted or abandoned.
+func countActiveInstances(instances []*computepb.ManagedInstance) int32 {
+	count := int32(0)
+	for _, instance := range instances {
+		switch instance.GetCurrentAction() {
+		case computepb.ManagedInstance_DELETING.String(), computepb.ManagedInstance_ABANDONING.String():
+		default:
+			count++
+		}
+	}
+	return count
+}
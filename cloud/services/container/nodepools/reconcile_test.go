@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestCompareUpgradeSettings(t *testing.T) {
+	surge := containerpb.NodePoolUpdateStrategy_SURGE
+	blueGreen := containerpb.NodePoolUpdateStrategy_BLUE_GREEN
+
+	tests := []struct {
+		name     string
+		desired  *containerpb.NodePool_UpgradeSettings
+		existing *containerpb.NodePool_UpgradeSettings
+		want     bool
+	}{
+		{
+			name:     "surge matches unspecified strategy",
+			desired:  &containerpb.NodePool_UpgradeSettings{Strategy: &surge, MaxSurge: 1},
+			existing: &containerpb.NodePool_UpgradeSettings{MaxSurge: 1},
+			want:     true,
+		},
+		{
+			name:     "surge max unavailable differs",
+			desired:  &containerpb.NodePool_UpgradeSettings{Strategy: &surge, MaxSurge: 1, MaxUnavailable: 1},
+			existing: &containerpb.NodePool_UpgradeSettings{Strategy: &surge, MaxSurge: 1},
+			want:     false,
+		},
+		{
+			name:     "strategy differs",
+			desired:  &containerpb.NodePool_UpgradeSettings{Strategy: &blueGreen},
+			existing: &containerpb.NodePool_UpgradeSettings{Strategy: &surge, MaxSurge: 1},
+			want:     false,
+		},
+		{
+			name:    "blue-green ignores GKE defaults",
+			desired: &containerpb.NodePool_UpgradeSettings{Strategy: &blueGreen},
+			existing: &containerpb.NodePool_UpgradeSettings{
+				Strategy: &blueGreen,
+				BlueGreenSettings: &containerpb.BlueGreenSettings{
+					NodePoolSoakDuration: durationpb.New(time.Hour),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "blue-green batch node count differs",
+			desired: &containerpb.NodePool_UpgradeSettings{
+				Strategy: &blueGreen,
+				BlueGreenSettings: &containerpb.BlueGreenSettings{
+					RolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy_{
+						StandardRolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy{
+							UpdateBatchSize: &containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchNodeCount{BatchNodeCount: 2},
+						},
+					},
+				},
+			},
+			existing: &containerpb.NodePool_UpgradeSettings{
+				Strategy: &blueGreen,
+				BlueGreenSettings: &containerpb.BlueGreenSettings{
+					RolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy_{
+						StandardRolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy{
+							UpdateBatchSize: &containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchNodeCount{BatchNodeCount: 1},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareUpgradeSettings(tt.desired, tt.existing); got != tt.want {
+				t.Errorf("compareUpgradeSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareKubeletConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  *containerpb.NodeKubeletConfig
+		existing *containerpb.NodeKubeletConfig
+		want     bool
+	}{
+		{
+			name:     "unspecified fields are ignored",
+			desired:  &containerpb.NodeKubeletConfig{PodPidsLimit: 4096},
+			existing: &containerpb.NodeKubeletConfig{PodPidsLimit: 4096, CpuManagerPolicy: "none"},
+			want:     true,
+		},
+		{
+			name:     "cpu manager policy differs",
+			desired:  &containerpb.NodeKubeletConfig{CpuManagerPolicy: "static"},
+			existing: &containerpb.NodeKubeletConfig{CpuManagerPolicy: "none"},
+			want:     false,
+		},
+		{
+			name:     "cpu cfs quota missing on node pool",
+			desired:  &containerpb.NodeKubeletConfig{CpuCfsQuota: wrapperspb.Bool(true)},
+			existing: nil,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareKubeletConfig(tt.desired, tt.existing); got != tt.want {
+				t.Errorf("compareKubeletConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareStringMaps(t *testing.T) {
+	if !compareStringMaps(nil, map[string]string{}) {
+		t.Errorf("compareStringMaps() expected nil and empty maps to be equal")
+	}
+	if compareStringMaps(map[string]string{"a": "b"}, map[string]string{"a": "c"}) {
+		t.Errorf("compareStringMaps() expected maps with different values to differ")
+	}
+}
+
+func TestCompareTaints(t *testing.T) {
+	gpu := &containerpb.NodeTaint{Key: "gpu", Value: "true", Effect: containerpb.NodeTaint_NO_SCHEDULE}
+	dedicated := &containerpb.NodeTaint{Key: "dedicated", Value: "ml", Effect: containerpb.NodeTaint_NO_EXECUTE}
+
+	tests := []struct {
+		name string
+		a, b []*containerpb.NodeTaint
+		want bool
+	}{
+		{
+			name: "same order",
+			a:    []*containerpb.NodeTaint{gpu, dedicated},
+			b:    []*containerpb.NodeTaint{gpu, dedicated},
+			want: true,
+		},
+		{
+			name: "different order",
+			a:    []*containerpb.NodeTaint{gpu, dedicated},
+			b:    []*containerpb.NodeTaint{dedicated, gpu},
+			want: true,
+		},
+		{
+			name: "different value",
+			a:    []*containerpb.NodeTaint{gpu},
+			b:    []*containerpb.NodeTaint{{Key: "gpu", Value: "false", Effect: containerpb.NodeTaint_NO_SCHEDULE}},
+			want: false,
+		},
+		{
+			name: "different effect",
+			a:    []*containerpb.NodeTaint{gpu},
+			b:    []*containerpb.NodeTaint{{Key: "gpu", Value: "true", Effect: containerpb.NodeTaint_PREFER_NO_SCHEDULE}},
+			want: false,
+		},
+		{
+			name: "missing taint",
+			a:    []*containerpb.NodeTaint{gpu, dedicated},
+			b:    []*containerpb.NodeTaint{gpu},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareTaints(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareTaints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateConfigResourceLabels(t *testing.T) {
+	s := New(&scope.ManagedMachinePoolScope{
+		MachinePool: &clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{ClusterName: "capi-cluster", Replicas: pointer.Int32(3)}},
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{Project: "my-project", Location: "us-central1", ClusterName: "my-cluster"},
+		},
+		GCPManagedMachinePool: &infrav1exp.GCPManagedMachinePool{
+			Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool", AdditionalLabels: infrav1.Labels{"team": "a"}},
+		},
+	})
+	ownership := infrav1.ClusterTagKey("capi-cluster")
+	existingNodePool := &containerpb.NodePool{
+		Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{ownership: "owned", "team": "b"}},
+	}
+
+	needUpdate, request := s.checkDiffAndPrepareUpdateConfig(existingNodePool)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdateConfig() = false, want true")
+	}
+	want := &containerpb.UpdateNodePoolRequest{
+		Name:           s.scope.NodePoolFullName(),
+		ResourceLabels: &containerpb.ResourceLabels{Labels: map[string]string{ownership: "owned", "team": "a"}},
+	}
+	if diff := cmp.Diff(want, request, protocmp.Transform()); diff != "" {
+		t.Errorf("checkDiffAndPrepareUpdateConfig() mismatch (-want +got):\n%s", diff)
+	}
+
+	existingNodePool.Config.ResourceLabels = request.ResourceLabels.Labels
+	if needUpdate, _ := s.checkDiffAndPrepareUpdateConfig(existingNodePool); needUpdate {
+		t.Errorf("checkDiffAndPrepareUpdateConfig() = true once the resource labels match the spec, want false")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// replicasManagedExternally returns true if an autoscaler other than Cluster API changes the size of the node pool,
+// either because the MachinePool has the replicas-managed-by annotation or because the autoscaler of GKE is enabled.
+// The Kubernetes cluster-autoscaler scales the node pool through the replicas of the MachinePool, so they are never
+// overwritten with the observed size when it is selected, even if the MachinePool has the annotation.
+func (s *Service) replicasManagedExternally() bool {
+	if scaling := s.scope.GCPManagedMachinePool.Spec.Scaling; scaling != nil && scaling.Autoscaler == infrav1exp.KubernetesClusterAutoscaler {
+		return false
+	}
+	return annotations.ReplicasManagedByExternalAutoscaler(s.scope.MachinePool) || s.scope.GCPManagedMachinePool.GKEAutoscalingEnabled()
+}
+
+// reconcileMachinePoolReplicas updates the MachinePool for the autoscaler of the node pool. If the size of the node
+// pool is managed externally, the observed number of nodes is written back to the replicas of the MachinePool.
+// If the Kubernetes cluster-autoscaler manages the node pool, the min and max counts are reported to it in the
+// annotations of the MachinePool, and they are removed if the autoscaler of GKE is enabled instead.
+func (s *Service) reconcileMachinePoolReplicas(ctx context.Context, instances []*computepb.ManagedInstance, log *logr.Logger) error {
+	machinePool := s.scope.MachinePool
+	patch := client.MergeFrom(machinePool.DeepCopy())
+	changed := false
+
+	if s.replicasManagedExternally() {
+		replicas := countActiveInstances(instances)
+		if machinePool.Spec.Replicas == nil || *machinePool.Spec.Replicas != replicas {
+			log.Info("Updating MachinePool replicas to the observed size of the node pool", "replicas", replicas)
+			machinePool.Spec.Replicas = &replicas
+			changed = true
+		}
+	}
+
+	scaling := s.scope.GCPManagedMachinePool.Spec.Scaling
+	switch {
+	case s.scope.GCPManagedMachinePool.GKEAutoscalingEnabled():
+		for _, annotation := range []string{clusterv1.AutoscalerMinSizeAnnotation, clusterv1.AutoscalerMaxSizeAnnotation} {
+			if _, ok := machinePool.Annotations[annotation]; ok {
+				delete(machinePool.Annotations, annotation)
+				changed = true
+			}
+		}
+	case scaling != nil && scaling.MinCount != nil && scaling.MaxCount != nil:
+		changed = annotations.AddAnnotations(machinePool, map[string]string{
+			clusterv1.AutoscalerMinSizeAnnotation: strconv.Itoa(int(*scaling.MinCount)),
+			clusterv1.AutoscalerMaxSizeAnnotation: strconv.Itoa(int(*scaling.MaxCount)),
+		}) || changed
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := s.scope.Client().Patch(ctx, machinePool, patch); err != nil {
+		return errors.Wrap(err, "error patching MachinePool")
+	}
+
+	return nil
+}
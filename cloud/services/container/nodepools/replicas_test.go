@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestReplicasManagedExternally(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		scaling     *infrav1exp.NodePoolAutoScaling
+		want        bool
+	}{
+		{
+			name: "managed by Cluster API",
+			want: false,
+		},
+		{
+			name:        "replicas-managed-by annotation",
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: "external-autoscaler"},
+			want:        true,
+		},
+		{
+			name:    "GKE autoscaler",
+			scaling: &infrav1exp.NodePoolAutoScaling{MinCount: pointer.Int32(1), MaxCount: pointer.Int32(3)},
+			want:    true,
+		},
+		{
+			name:    "cluster-autoscaler",
+			scaling: &infrav1exp.NodePoolAutoScaling{MinCount: pointer.Int32(1), MaxCount: pointer.Int32(3), Autoscaler: infrav1exp.KubernetesClusterAutoscaler},
+			want:    false,
+		},
+		{
+			name:        "cluster-autoscaler with replicas-managed-by annotation",
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: "cluster-autoscaler"},
+			scaling:     &infrav1exp.NodePoolAutoScaling{MinCount: pointer.Int32(1), MaxCount: pointer.Int32(3), Autoscaler: infrav1exp.KubernetesClusterAutoscaler},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedMachinePoolScope{
+				MachinePool: &clusterv1exp.MachinePool{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}},
+				GCPManagedMachinePool: &infrav1exp.GCPManagedMachinePool{
+					Spec: infrav1exp.GCPManagedMachinePoolSpec{Scaling: tt.scaling},
+				},
+			})
+			if got := s.replicasManagedExternally(); got != tt.want {
+				t.Errorf("replicasManagedExternally() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
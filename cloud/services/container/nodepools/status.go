@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
+
+// setStatusFromNodePool copies the state GKE reports for the node pool and its instances into the status.
+func (s *Service) setStatusFromNodePool(nodePool *containerpb.NodePool, instances []*computepb.ManagedInstance) {
+	status := &s.scope.GCPManagedMachinePool.Status
+
+	status.CurrentVersion = nodePool.GetVersion()
+	status.InstanceGroupURLs = nodePool.GetInstanceGroupUrls()
+	status.StatusMessage = nodePool.GetStatusMessage() //nolint:staticcheck // The message is still reported by GKE.
+	status.NodePoolConditions = convertFromSdkStatusConditions(nodePool.GetConditions())
+	status.ZoneReplicas = zoneReplicas(instances)
+	status.ReadyReplicas = 0
+	for _, zone := range status.ZoneReplicas {
+		status.ReadyReplicas += zone.ReadyReplicas
+	}
+}
+
+// setFailure records a terminal problem of the node pool, which is reflected in the MachinePool.
+func (s *Service) setFailure(failureReason capierrors.MachinePoolStatusFailure, message string) {
+	s.scope.GCPManagedMachinePool.Status.FailureReason = &failureReason
+	s.scope.GCPManagedMachinePool.Status.FailureMessage = &message
+}
+
+// clearFailure removes a terminal problem recorded before once the node pool recovered.
+func (s *Service) clearFailure() {
+	s.scope.GCPManagedMachinePool.Status.FailureReason = nil
+	s.scope.GCPManagedMachinePool.Status.FailureMessage = nil
+}
+
+func convertFromSdkStatusConditions(statusConditions []*containerpb.StatusCondition) []infrav1exp.NodePoolStatusCondition {
+	if len(statusConditions) == 0 {
+		return nil
+	}
+
+	nodePoolConditions := make([]infrav1exp.NodePoolStatusCondition, len(statusConditions))
+	for i, statusCondition := range statusConditions {
+		nodePoolConditions[i] = infrav1exp.NodePoolStatusCondition{
+			Code:    statusCondition.GetCanonicalCode().String(),
+			Message: statusCondition.GetMessage(),
+		}
+	}
+
+	return nodePoolConditions
+}
+
+// conditionsMessage joins the messages of the conditions GKE reports for the node pool.
+func conditionsMessage(nodePool *containerpb.NodePool) string {
+	messages := []string{}
+	for _, statusCondition := range nodePool.GetConditions() {
+		if message := statusCondition.GetMessage(); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	if len(messages) == 0 {
+		return nodePool.GetStatusMessage() //nolint:staticcheck // The message is still reported by GKE.
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// zoneReplicas counts the instances of the node pool and the running ones in each zone.
+func zoneReplicas(instances []*computepb.ManagedInstance) []infrav1exp.ZoneReplicaStatus {
+	replicas := map[string]*infrav1exp.ZoneReplicaStatus{}
+	for _, instance := range instances {
+		providerID, err := providerid.NewFromResourceURL(instance.GetInstance())
+		if err != nil {
+			continue
+		}
+		zone, ok := replicas[providerID.Location()]
+		if !ok {
+			zone = &infrav1exp.ZoneReplicaStatus{Zone: providerID.Location()}
+			replicas[providerID.Location()] = zone
+		}
+		zone.Replicas++
+		if instanceReady(instance) {
+			zone.ReadyReplicas++
+		}
+	}
+
+	zoneReplicas := make([]infrav1exp.ZoneReplicaStatus, 0, len(replicas))
+	for _, zone := range replicas {
+		zoneReplicas = append(zoneReplicas, *zone)
+	}
+	sort.Slice(zoneReplicas, func(i, j int) bool {
+		return zoneReplicas[i].Zone < zoneReplicas[j].Zone
+	})
+
+	return zoneReplicas
+}
+
+// instanceReady returns true if the instance is running and the managed instance group isn't acting on it.
+func instanceReady(instance *computepb.ManagedInstance) bool {
+	return instance.GetInstanceStatus() == computepb.ManagedInstance_RUNNING.String() && instance.GetCurrentAction() == computepb.ManagedInstance_NONE.String()
+}
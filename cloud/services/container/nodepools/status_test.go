@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestZoneReplicas(t *testing.T) {
+	instanceURL := "https://www.googleapis.com/compute/v1/projects/my-project/zones/"
+	instances := []*computepb.ManagedInstance{
+		{Instance: pointer.String(instanceURL + "us-central1-b/instances/node-1"), InstanceStatus: pointer.String("RUNNING"), CurrentAction: pointer.String("NONE")},
+		{Instance: pointer.String(instanceURL + "us-central1-a/instances/node-2"), InstanceStatus: pointer.String("RUNNING"), CurrentAction: pointer.String("NONE")},
+		{Instance: pointer.String(instanceURL + "us-central1-a/instances/node-3"), InstanceStatus: pointer.String("STAGING"), CurrentAction: pointer.String("CREATING")},
+		{Instance: pointer.String(instanceURL + "us-central1-b/instances/node-4"), InstanceStatus: pointer.String("RUNNING"), CurrentAction: pointer.String("REFRESHING")},
+	}
+
+	want := []infrav1exp.ZoneReplicaStatus{
+		{Zone: "us-central1-a", Replicas: 2, ReadyReplicas: 1},
+		{Zone: "us-central1-b", Replicas: 2, ReadyReplicas: 1},
+	}
+	if got := zoneReplicas(instances); !reflect.DeepEqual(got, want) {
+		t.Errorf("zoneReplicas() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConditionsMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodePool *containerpb.NodePool
+		want     string
+	}{
+		{
+			name: "conditions",
+			nodePool: &containerpb.NodePool{
+				Conditions: []*containerpb.StatusCondition{
+					{CanonicalCode: code.Code_RESOURCE_EXHAUSTED, Message: "zone us-central1-a has no capacity"},
+					{CanonicalCode: code.Code_PERMISSION_DENIED, Message: "service account can't be used"},
+				},
+			},
+			want: "zone us-central1-a has no capacity; service account can't be used",
+		},
+		{
+			name:     "status message",
+			nodePool: &containerpb.NodePool{StatusMessage: "node pool is broken"},
+			want:     "node pool is broken",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionsMessage(tt.nodePool); got != tt.want {
+				t.Errorf("conditionsMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertFromSdkStatusConditions(t *testing.T) {
+	statusConditions := []*containerpb.StatusCondition{
+		{CanonicalCode: code.Code_RESOURCE_EXHAUSTED, Message: "zone us-central1-a has no capacity"},
+	}
+
+	want := []infrav1exp.NodePoolStatusCondition{
+		{Code: "RESOURCE_EXHAUSTED", Message: "zone us-central1-a has no capacity"},
+	}
+	if got := convertFromSdkStatusConditions(statusConditions); !reflect.DeepEqual(got, want) {
+		t.Errorf("convertFromSdkStatusConditions() = %+v, want %+v", got, want)
+	}
+}
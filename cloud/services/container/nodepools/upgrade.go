@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// checkVersionUpgradeAllowed checks whether the node pool can be upgraded to the given version now.
+// Node pools wait for the control plane to finish its upgrade and for other node pools of the cluster
+// to finish updating, so that node pools are upgraded one after the other. When the upgrade has to wait,
+// false is returned with a message explaining why. An error wrapping shared.ErrUnsupportedVersionSkew is
+// returned if the version is too old for the control plane.
+func (s *Service) checkVersionUpgradeAllowed(ctx context.Context, nodeVersion string) (bool, string, error) {
+	controlPlane := s.scope.GCPManagedControlPlane
+	if upgrade := controlPlane.Status.Upgrade; upgrade != nil && upgrade.Phase == infrav1exp.UpgradePhaseControlPlane {
+		return false, fmt.Sprintf("waiting for the control plane upgrade to %s to complete", upgrade.TargetVersion), nil
+	}
+	if controlPlane.Status.CurrentVersion == "" {
+		return false, "waiting for the control plane version to be reported", nil
+	}
+
+	err := shared.ValidateNodeVersionSkew(controlPlane.Status.CurrentVersion, nodeVersion)
+	switch {
+	case errors.Is(err, shared.ErrNodeVersionAheadOfControlPlane):
+		return false, fmt.Sprintf("waiting for the control plane to be upgraded to %s", nodeVersion), nil
+	case err != nil:
+		return false, "", err
+	}
+
+	listNodePoolsRequest := &containerpb.ListNodePoolsRequest{
+		Parent: s.scope.NodePoolLocation(),
+	}
+	nodePools, err := s.scope.ManagedMachinePoolClient().ListNodePools(ctx, listNodePoolsRequest)
+	if err != nil {
+		return false, "", err
+	}
+	if name := reconcilingNodePool(nodePools.GetNodePools(), s.scope.NodePoolName()); name != "" {
+		return false, fmt.Sprintf("waiting for node pool %s to finish updating", name), nil
+	}
+
+	return true, "", nil
+}
+
+// reconcilingNodePool returns the name of a node pool other than the given one which is being updated by GKE.
+func reconcilingNodePool(nodePools []*containerpb.NodePool, nodePoolName string) string {
+	for _, nodePool := range nodePools {
+		if nodePool.GetName() != nodePoolName && nodePool.GetStatus() == containerpb.NodePool_RECONCILING {
+			return nodePool.GetName()
+		}
+	}
+
+	return ""
+}
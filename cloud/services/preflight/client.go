@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/serviceusage/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+// DefaultClientTTL is the period during which the clients of the preflight checks are reused. They are created
+// again afterwards, so that rotated credentials are picked up.
+const DefaultClientTTL = time.Hour
+
+// Client calls the GCP APIs used by the preflight checks.
+type Client struct {
+	serviceUsage    *serviceusage.Service
+	resourceManager *cloudresourcemanager.Service
+	compute         *compute.Service
+}
+
+// NewClient returns a Client created with the options.
+func NewClient(ctx context.Context, opts ...option.ClientOption) (*Client, error) {
+	serviceUsage, err := serviceusage.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new service usage service instance: %w", err)
+	}
+
+	resourceManager, err := cloudresourcemanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new resource manager service instance: %w", err)
+	}
+
+	computeSvc, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new compute service instance: %w", err)
+	}
+
+	return &Client{
+		serviceUsage:    serviceUsage,
+		resourceManager: resourceManager,
+		compute:         computeSvc,
+	}, nil
+}
+
+// ClientCache reuses the clients of the preflight checks across reconciliations, per credentials.
+// It is safe for concurrent use.
+type ClientCache struct {
+	ttl     time.Duration
+	clients *cache.Expiring
+}
+
+// NewClientCache returns an empty ClientCache whose clients expire after the ttl.
+func NewClientCache(ttl time.Duration) *ClientCache {
+	return &ClientCache{
+		ttl:     ttl,
+		clients: cache.NewExpiring(),
+	}
+}
+
+// Get returns the client authenticating with the credentials of the scope, which is created if it isn't in the cache.
+func (c *ClientCache) Get(ctx context.Context, scope Scope) (*Client, error) {
+	var key infrav1.ObjectReference
+	if ref := scope.CredentialsRef(); ref != nil {
+		key = *ref
+	}
+	if client, ok := c.clients.Get(key); ok {
+		return client.(*Client), nil
+	}
+
+	opts, err := scope.ClientOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.clients.Set(key, client, c.ttl)
+
+	return client, nil
+}
+
+// ServiceState returns the state of the API in the project, e.g. ENABLED.
+func (c *Client) ServiceState(ctx context.Context, project, api string) (string, error) {
+	svc, err := c.serviceUsage.Services.Get(fmt.Sprintf("projects/%s/services/%s", project, api)).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+
+	return svc.State, nil
+}
+
+// TestIamPermissions returns the permissions the credentials have on the project among the given ones.
+func (c *Client) TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	res, err := c.resourceManager.Projects.TestIamPermissions(project, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: permissions,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Permissions, nil
+}
+
+// GetMachineType returns the machine type in the zone.
+func (c *Client) GetMachineType(ctx context.Context, project, zone, machineType string) (*compute.MachineType, error) {
+	return c.compute.MachineTypes.Get(project, zone, machineType).Context(ctx).Do()
+}
+
+// GetRegion returns the region, with its quotas and zones.
+func (c *Client) GetRegion(ctx context.Context, project, region string) (*compute.Region, error) {
+	return c.compute.Regions.Get(project, region).Context(ctx).Do()
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+func TestClientCache(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clients := NewClientCache(DefaultClientTTL)
+
+	defaultClient, err := clients.Get(ctx, &fakeScope{})
+	g.Expect(err).NotTo(HaveOccurred())
+	again, err := clients.Get(ctx, &fakeScope{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(BeIdenticalTo(defaultClient))
+
+	other, err := clients.Get(ctx, &fakeScope{credentialsRef: &infrav1.ObjectReference{Namespace: "default", Name: "credentials"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(BeIdenticalTo(defaultClient))
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// failure is a preflight check that didn't pass.
+type failure struct {
+	reason   string
+	severity clusterv1.ConditionSeverity
+	message  string
+}
+
+// Reconcile runs the preflight checks until they pass, and reports the result in the
+// PreflightChecksSucceeded condition. It requeues while a check doesn't pass, so that nothing is created.
+func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	if conditions.IsTrue(s.scope.ConditionSetter(), infrav1.PreflightChecksSucceededCondition) {
+		return ctrl.Result{}, nil
+	}
+	if s.requirements.IsZero() {
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1.PreflightChecksSucceededCondition)
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Running preflight checks")
+	failure, err := s.check(ctx)
+	if err != nil {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.PreflightChecksSucceededCondition, infrav1.PreflightChecksFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return ctrl.Result{}, errors.Wrap(err, "failed to run preflight checks")
+	}
+	if failure != nil {
+		log.Info("Preflight check failed", "reason", failure.reason, "message", failure.message)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.PreflightChecksSucceededCondition, failure.reason, failure.severity, "%s", failure.message)
+		return ctrl.Result{RequeueAfter: RetryInterval}, nil
+	}
+
+	log.Info("Preflight checks passed")
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1.PreflightChecksSucceededCondition)
+	return ctrl.Result{}, nil
+}
+
+// Delete does nothing, the preflight checks don't create resources.
+func (s *Service) Delete(_ context.Context) (ctrl.Result, error) {
+	return ctrl.Result{}, nil
+}
+
+// check runs the checks in turn and returns the first failure.
+func (s *Service) check(ctx context.Context) (*failure, error) {
+	if err := s.initClients(ctx); err != nil {
+		return nil, err
+	}
+
+	if f, err := s.checkAPIs(ctx); err != nil || f != nil {
+		return f, err
+	}
+	if f, err := s.checkPermissions(ctx); err != nil || f != nil {
+		return f, err
+	}
+
+	var region *compute.Region
+	if len(s.requirements.Quotas) > 0 || s.requirements.Instances > 0 || (s.requirements.MachineType != "" && len(s.requirements.Zones) == 0) {
+		var err error
+		region, err = s.regions.GetRegion(ctx, s.scope.Project(), s.scope.Region())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	machineType, f, err := s.checkMachineType(ctx, region)
+	if err != nil || f != nil {
+		return f, err
+	}
+
+	return s.checkQuotas(region, machineType), nil
+}
+
+// initClients sets the API clients that aren't set, from the cache if there is one.
+func (s *Service) initClients(ctx context.Context) error {
+	if s.services != nil && s.permissions != nil && s.machineTypes != nil && s.regions != nil {
+		return nil
+	}
+
+	c, err := s.newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.services == nil {
+		s.services = c
+	}
+	if s.permissions == nil {
+		s.permissions = c
+	}
+	if s.machineTypes == nil {
+		s.machineTypes = c
+	}
+	if s.regions == nil {
+		s.regions = c
+	}
+	return nil
+}
+
+func (s *Service) newClient(ctx context.Context) (*Client, error) {
+	if s.clients != nil {
+		return s.clients.Get(ctx, s.scope)
+	}
+
+	opts, err := s.scope.ClientOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(ctx, opts...)
+}
+
+// checkAPIs checks that the APIs are enabled. The check is skipped if the credentials can't get the state of the APIs.
+func (s *Service) checkAPIs(ctx context.Context) (*failure, error) {
+	log := log.FromContext(ctx)
+	var disabled []string
+	for _, api := range s.requirements.APIs {
+		state, err := s.services.ServiceState(ctx, s.scope.Project(), api)
+		if err != nil {
+			if gcperrors.IsPermissionDenied(err) {
+				log.V(2).Info("Cannot check whether the API is enabled", "api", api, "error", err.Error())
+				continue
+			}
+			return nil, err
+		}
+		if state != "ENABLED" {
+			disabled = append(disabled, api)
+		}
+	}
+	if len(disabled) == 0 {
+		return nil, nil
+	}
+
+	return &failure{
+		reason:   infrav1.APINotEnabledReason,
+		severity: clusterv1.ConditionSeverityError,
+		message:  fmt.Sprintf("APIs not enabled in project %s: %s", s.scope.Project(), strings.Join(disabled, ", ")),
+	}, nil
+}
+
+// checkPermissions checks that the credentials have the permissions. The check is skipped if the permissions can't be
+// tested.
+func (s *Service) checkPermissions(ctx context.Context) (*failure, error) {
+	if len(s.requirements.Permissions) == 0 {
+		return nil, nil
+	}
+
+	granted, err := s.permissions.TestIamPermissions(ctx, s.scope.Project(), s.requirements.Permissions)
+	if err != nil {
+		if gcperrors.IsPermissionDenied(err) {
+			log.FromContext(ctx).V(2).Info("Cannot test the IAM permissions", "error", err.Error())
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	missing := sets.New(s.requirements.Permissions...).Delete(granted...)
+	if missing.Len() == 0 {
+		return nil, nil
+	}
+
+	return &failure{
+		reason:   infrav1.PermissionsMissingReason,
+		severity: clusterv1.ConditionSeverityError,
+		message:  fmt.Sprintf("Credentials lack permissions on project %s: %s", s.scope.Project(), strings.Join(sets.List(missing), ", ")),
+	}, nil
+}
+
+// checkMachineType checks that the machine type exists in the zones, and returns it.
+func (s *Service) checkMachineType(ctx context.Context, region *compute.Region) (*compute.MachineType, *failure, error) {
+	if s.requirements.MachineType == "" {
+		return nil, nil, nil
+	}
+
+	zones := s.requirements.Zones
+	if len(zones) == 0 {
+		for _, zone := range region.Zones {
+			zones = append(zones, zone[strings.LastIndex(zone, "/")+1:])
+		}
+	}
+
+	var found *compute.MachineType
+	var missing []string
+	for _, zone := range zones {
+		machineType, err := s.machineTypes.GetMachineType(ctx, s.scope.Project(), zone, s.requirements.MachineType)
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				missing = append(missing, zone)
+				continue
+			}
+			return nil, nil, err
+		}
+		found = machineType
+	}
+	if len(missing) == 0 {
+		return found, nil, nil
+	}
+
+	sort.Strings(missing)
+	return nil, &failure{
+		reason:   infrav1.MachineTypeNotFoundReason,
+		severity: clusterv1.ConditionSeverityError,
+		message:  fmt.Sprintf("Machine type %s doesn't exist in zones %s", s.requirements.MachineType, strings.Join(missing, ", ")),
+	}, nil
+}
+
+// checkQuotas checks that the regional quotas have room for the resources, including the CPUs of the instances.
+func (s *Service) checkQuotas(region *compute.Region, machineType *compute.MachineType) *failure {
+	if region == nil {
+		return nil
+	}
+
+	needed := make(map[string]float64, len(s.requirements.Quotas)+1)
+	for metric, n := range s.requirements.Quotas {
+		needed[metric] = n
+	}
+	if machineType != nil && s.requirements.Instances > 0 {
+		needed[cpusQuota] += float64(machineType.GuestCpus * s.requirements.Instances)
+	}
+
+	var exceeded []string
+	for _, quota := range region.Quotas {
+		n, ok := needed[quota.Metric]
+		if !ok || n == 0 {
+			continue
+		}
+		if available := quota.Limit - quota.Usage; available < n {
+			exceeded = append(exceeded, fmt.Sprintf("%s (%g needed, %g available)", quota.Metric, n, available))
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	sort.Strings(exceeded)
+	return &failure{
+		reason:   infrav1.QuotaExceededReason,
+		severity: clusterv1.ConditionSeverityWarning,
+		message:  fmt.Sprintf("Quotas of region %s exceeded: %s", s.scope.Region(), strings.Join(exceeded, ", ")),
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type fakeScope struct {
+	gcpMachine     *infrav1.GCPMachine
+	credentialsRef *infrav1.ObjectReference
+}
+
+func (f *fakeScope) Project() string { return "my-project" }
+
+func (f *fakeScope) Region() string { return "us-central1" }
+
+func (f *fakeScope) ClientOptions(_ context.Context) ([]option.ClientOption, error) {
+	return []option.ClientOption{option.WithoutAuthentication()}, nil
+}
+
+func (f *fakeScope) CredentialsRef() *infrav1.ObjectReference { return f.credentialsRef }
+
+func (f *fakeScope) ConditionSetter() conditions.Setter { return f.gcpMachine }
+
+// fakeClient answers the preflight checks from its fields.
+type fakeClient struct {
+	states      map[string]string
+	stateErr    error
+	granted     []string
+	machineType map[string]*compute.MachineType
+	region      *compute.Region
+	calls       int
+}
+
+func (f *fakeClient) ServiceState(_ context.Context, _, api string) (string, error) {
+	f.calls++
+	if f.stateErr != nil {
+		return "", f.stateErr
+	}
+	return f.states[api], nil
+}
+
+func (f *fakeClient) TestIamPermissions(_ context.Context, _ string, _ []string) ([]string, error) {
+	f.calls++
+	return f.granted, nil
+}
+
+func (f *fakeClient) GetMachineType(_ context.Context, _, zone, _ string) (*compute.MachineType, error) {
+	f.calls++
+	if mt, ok := f.machineType[zone]; ok {
+		return mt, nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func (f *fakeClient) GetRegion(_ context.Context, _, _ string) (*compute.Region, error) {
+	f.calls++
+	return f.region, nil
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		states:  map[string]string{computeAPI: "ENABLED"},
+		granted: []string{"compute.instances.create"},
+		machineType: map[string]*compute.MachineType{
+			"us-central1-a": {Name: "n1-standard-2", GuestCpus: 2},
+			"us-central1-b": {Name: "n1-standard-2", GuestCpus: 2},
+		},
+		region: &compute.Region{
+			Name: "us-central1",
+			Quotas: []*compute.Quota{
+				{Metric: cpusQuota, Limit: 24, Usage: 20},
+				{Metric: addressesQuota, Limit: 8, Usage: 8},
+			},
+			Zones: []string{
+				"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a",
+				"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-b",
+			},
+		},
+	}
+}
+
+var machineRequirements = Requirements{
+	APIs:        []string{computeAPI},
+	Permissions: []string{"compute.instances.create"},
+	MachineType: "n1-standard-2",
+	Zones:       []string{"us-central1-a"},
+	Instances:   1,
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements Requirements
+		client       func() *fakeClient
+		conditions   clusterv1.Conditions
+		wantErr      bool
+		wantRequeue  bool
+		wantReason   string
+		wantSeverity clusterv1.ConditionSeverity
+		wantNoCalls  bool
+	}{
+		{
+			name:         "all checks pass",
+			requirements: machineRequirements,
+			client:       newFakeClient,
+		},
+		{
+			name:         "checks already passed",
+			requirements: machineRequirements,
+			client:       newFakeClient,
+			conditions:   clusterv1.Conditions{*conditions.TrueCondition(infrav1.PreflightChecksSucceededCondition)},
+			wantNoCalls:  true,
+		},
+		{
+			name:        "nothing to check",
+			client:      newFakeClient,
+			wantNoCalls: true,
+		},
+		{
+			name:         "API not enabled",
+			requirements: machineRequirements,
+			client: func() *fakeClient {
+				c := newFakeClient()
+				c.states[computeAPI] = "DISABLED"
+				return c
+			},
+			wantRequeue:  true,
+			wantReason:   infrav1.APINotEnabledReason,
+			wantSeverity: clusterv1.ConditionSeverityError,
+		},
+		{
+			name:         "API state can't be read (should skip the check)",
+			requirements: machineRequirements,
+			client: func() *fakeClient {
+				c := newFakeClient()
+				c.stateErr = &googleapi.Error{Code: http.StatusForbidden}
+				return c
+			},
+		},
+		{
+			name:         "API state error",
+			requirements: machineRequirements,
+			client: func() *fakeClient {
+				c := newFakeClient()
+				c.stateErr = &googleapi.Error{Code: http.StatusInternalServerError}
+				return c
+			},
+			wantErr:      true,
+			wantReason:   infrav1.PreflightChecksFailedReason,
+			wantSeverity: clusterv1.ConditionSeverityWarning,
+		},
+		{
+			name:         "permission missing",
+			requirements: machineRequirements,
+			client: func() *fakeClient {
+				c := newFakeClient()
+				c.granted = nil
+				return c
+			},
+			wantRequeue:  true,
+			wantReason:   infrav1.PermissionsMissingReason,
+			wantSeverity: clusterv1.ConditionSeverityError,
+		},
+		{
+			name: "machine type not found in a zone of the region",
+			requirements: Requirements{
+				MachineType: "n1-standard-2",
+				Instances:   1,
+			},
+			client: func() *fakeClient {
+				c := newFakeClient()
+				delete(c.machineType, "us-central1-b")
+				return c
+			},
+			wantRequeue:  true,
+			wantReason:   infrav1.MachineTypeNotFoundReason,
+			wantSeverity: clusterv1.ConditionSeverityError,
+		},
+		{
+			name: "CPU quota exceeded",
+			requirements: Requirements{
+				MachineType: "n1-standard-2",
+				Zones:       []string{"us-central1-a"},
+				Instances:   3,
+			},
+			client:       newFakeClient,
+			wantRequeue:  true,
+			wantReason:   infrav1.QuotaExceededReason,
+			wantSeverity: clusterv1.ConditionSeverityWarning,
+		},
+		{
+			name: "address quota exceeded",
+			requirements: Requirements{
+				Quotas: map[string]float64{addressesQuota: 1},
+			},
+			client:       newFakeClient,
+			wantRequeue:  true,
+			wantReason:   infrav1.QuotaExceededReason,
+			wantSeverity: clusterv1.ConditionSeverityWarning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scope := &fakeScope{gcpMachine: &infrav1.GCPMachine{}}
+			scope.gcpMachine.Status.Conditions = tt.conditions
+			c := tt.client()
+			s := New(scope, tt.requirements, nil)
+			s.services = c
+			s.permissions = c
+			s.machineTypes = c
+			s.regions = c
+
+			res, err := s.Reconcile(context.TODO())
+			g.Expect(err != nil).To(Equal(tt.wantErr))
+			g.Expect(res.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+			if tt.wantNoCalls {
+				g.Expect(c.calls).To(BeZero())
+			}
+
+			condition := conditions.Get(scope.gcpMachine, infrav1.PreflightChecksSucceededCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tt.wantReason == "" {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+			g.Expect(condition.Severity).To(Equal(tt.wantSeverity))
+		})
+	}
+}
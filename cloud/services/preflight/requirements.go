@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+const (
+	computeAPI   = "compute.googleapis.com"
+	containerAPI = "container.googleapis.com"
+
+	cpusQuota           = "CPUS"
+	addressesQuota      = "IN_USE_ADDRESSES"
+	instanceGroupsQuota = "INSTANCE_GROUPS"
+
+	// gkeDefaultMachineType is the machine type of the nodes of GKE node pools, which don't set one.
+	gkeDefaultMachineType = "e2-medium"
+)
+
+// Requirements are what the resources of an object need from the GCP project.
+type Requirements struct {
+	// APIs are the services that must be enabled in the project, e.g. compute.googleapis.com.
+	APIs []string
+	// Permissions are the IAM permissions the credentials must have on the project.
+	Permissions []string
+	// MachineType is the machine type of the instances, which must exist in each of the Zones.
+	MachineType string
+	// Zones are the zones of the instances. The zones of the region are used if it's empty.
+	Zones []string
+	// Instances is the number of instances of the machine type, whose CPUs must fit in the regional CPU quota.
+	Instances int64
+	// Quotas are the regional quotas that must have room, by metric, e.g. IN_USE_ADDRESSES.
+	Quotas map[string]float64
+}
+
+// IsZero returns true if there's nothing to check.
+func (r Requirements) IsZero() bool {
+	return len(r.APIs) == 0 && len(r.Permissions) == 0 && r.MachineType == "" && len(r.Quotas) == 0
+}
+
+// ClusterRequirements returns the requirements of the infrastructure of the GCPCluster, or none once it is being
+// created.
+func ClusterRequirements(gcpCluster *infrav1.GCPCluster) Requirements {
+	if gcpCluster.Status.Network.SelfLink != nil || len(gcpCluster.Status.PendingOperations) > 0 {
+		return Requirements{}
+	}
+
+	return Requirements{
+		APIs: []string{computeAPI},
+		Permissions: []string{
+			"compute.networks.create",
+			"compute.routers.create",
+			"compute.subnetworks.create",
+			"compute.firewalls.create",
+			"compute.instanceGroups.create",
+			"compute.healthChecks.create",
+			"compute.backendServices.create",
+			"compute.targetTcpProxies.create",
+			"compute.globalAddresses.create",
+			"compute.globalForwardingRules.create",
+		},
+		Quotas: map[string]float64{
+			// A control plane instance group is created in each failure domain.
+			instanceGroupsQuota: float64(len(gcpCluster.Status.FailureDomains)),
+		},
+	}
+}
+
+// MachineRequirements returns the requirements of the instance of the GCPMachine in the zone, or none once it is
+// being created.
+func MachineRequirements(gcpMachine *infrav1.GCPMachine, zone string, controlPlane bool) Requirements {
+	if gcpMachine.Spec.ProviderID != nil || len(gcpMachine.Status.PendingOperations) > 0 {
+		return Requirements{}
+	}
+
+	r := Requirements{
+		APIs: []string{computeAPI},
+		Permissions: []string{
+			"compute.instances.create",
+			"compute.instances.setMetadata",
+			"compute.instances.setServiceAccount",
+			"compute.disks.create",
+			"compute.subnetworks.use",
+		},
+		MachineType: gcpMachine.Spec.InstanceType,
+		Zones:       []string{zone},
+		Instances:   1,
+		Quotas:      map[string]float64{},
+	}
+	if controlPlane {
+		r.Permissions = append(r.Permissions, "compute.instanceGroups.update")
+	}
+	if pointer.BoolDeref(gcpMachine.Spec.PublicIP, false) {
+		r.Permissions = append(r.Permissions, "compute.subnetworks.useExternalIp")
+		r.Quotas[addressesQuota] = 1
+	}
+
+	return r
+}
+
+// ManagedControlPlaneRequirements returns the requirements of the GKE cluster of the GCPManagedControlPlane, or none
+// once it is being created.
+func ManagedControlPlaneRequirements(controlPlane *infrav1exp.GCPManagedControlPlane) Requirements {
+	if controlPlane.Status.CurrentVersion != "" || controlPlane.Status.PendingOperation != nil {
+		return Requirements{}
+	}
+
+	return Requirements{
+		APIs: []string{computeAPI, containerAPI},
+		Permissions: []string{
+			"container.clusters.create",
+			"container.clusters.get",
+			"container.clusters.update",
+			"container.operations.get",
+		},
+	}
+}
+
+// ManagedMachinePoolRequirements returns the requirements of the GKE node pool of the GCPManagedMachinePool, or none
+// once it is being created.
+func ManagedMachinePoolRequirements(controlPlane *infrav1exp.GCPManagedControlPlane, managedPool *infrav1exp.GCPManagedMachinePool, machinePool *clusterv1exp.MachinePool) Requirements {
+	if len(managedPool.Spec.ProviderIDList) > 0 || managedPool.Status.Ready || managedPool.Status.PendingOperation != nil {
+		return Requirements{}
+	}
+
+	zones := managedPool.Spec.NodeLocations
+	if loc, err := location.Parse(controlPlane.Spec.Location); len(zones) == 0 && err == nil && loc.Zone != nil {
+		zones = []string{loc.Region + "-" + *loc.Zone}
+	}
+
+	return Requirements{
+		APIs: []string{computeAPI, containerAPI},
+		Permissions: []string{
+			"container.clusters.update",
+			"container.operations.get",
+		},
+		MachineType: gkeDefaultMachineType,
+		Zones:       zones,
+		Instances:   int64(pointer.Int32Deref(machinePool.Spec.Replicas, 0)),
+	}
+}
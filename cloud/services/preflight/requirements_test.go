@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestMachineRequirements(t *testing.T) {
+	g := NewWithT(t)
+
+	gcpMachine := &infrav1.GCPMachine{
+		Spec: infrav1.GCPMachineSpec{
+			InstanceType: "n1-standard-2",
+			PublicIP:     pointer.Bool(true),
+		},
+	}
+	r := MachineRequirements(gcpMachine, "us-central1-a", true)
+	g.Expect(r.MachineType).To(Equal("n1-standard-2"))
+	g.Expect(r.Zones).To(ConsistOf("us-central1-a"))
+	g.Expect(r.Instances).To(BeEquivalentTo(1))
+	g.Expect(r.Permissions).To(ContainElements("compute.instanceGroups.update", "compute.subnetworks.useExternalIp"))
+	g.Expect(r.Quotas).To(HaveKeyWithValue(addressesQuota, float64(1)))
+
+	// Nothing is checked once the instance is created.
+	gcpMachine.Spec.ProviderID = pointer.String("gce://my-project/us-central1-a/my-machine")
+	g.Expect(MachineRequirements(gcpMachine, "us-central1-a", true).IsZero()).To(BeTrue())
+}
+
+func TestManagedMachinePoolRequirements(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlane := &infrav1exp.GCPManagedControlPlane{
+		Spec: infrav1exp.GCPManagedControlPlaneSpec{Location: "us-central1-c"},
+	}
+	machinePool := &clusterv1exp.MachinePool{
+		Spec: clusterv1exp.MachinePoolSpec{Replicas: pointer.Int32(3)},
+	}
+
+	// The nodes of a zonal cluster are in its zone.
+	r := ManagedMachinePoolRequirements(controlPlane, &infrav1exp.GCPManagedMachinePool{}, machinePool)
+	g.Expect(r.MachineType).To(Equal(gkeDefaultMachineType))
+	g.Expect(r.Zones).To(ConsistOf("us-central1-c"))
+	g.Expect(r.Instances).To(BeEquivalentTo(3))
+
+	// The node locations take precedence.
+	managedPool := &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodeLocations: []string{"us-central1-a", "us-central1-b"}},
+	}
+	r = ManagedMachinePoolRequirements(controlPlane, managedPool, machinePool)
+	g.Expect(r.Zones).To(ConsistOf("us-central1-a", "us-central1-b"))
+
+	// Nothing is checked once the node pool is created.
+	managedPool.Status.Ready = true
+	g.Expect(ManagedMachinePoolRequirements(controlPlane, managedPool, machinePool).IsZero()).To(BeTrue())
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// RetryInterval is the interval at which failed preflight checks are run again.
+const RetryInterval = time.Minute
+
+type servicesInterface interface {
+	ServiceState(ctx context.Context, project, api string) (string, error)
+}
+
+type permissionsInterface interface {
+	TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error)
+}
+
+type machineTypesInterface interface {
+	GetMachineType(ctx context.Context, project, zone, machineType string) (*compute.MachineType, error)
+}
+
+type regionsInterface interface {
+	GetRegion(ctx context.Context, project, region string) (*compute.Region, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	Project() string
+	Region() string
+	ClientOptions(ctx context.Context) ([]option.ClientOption, error)
+	CredentialsRef() *infrav1.ObjectReference
+	ConditionSetter() conditions.Setter
+}
+
+// Service implements the preflight checks reconciler.
+type Service struct {
+	scope        Scope
+	requirements Requirements
+	clients      *ClientCache
+	services     servicesInterface
+	permissions  permissionsInterface
+	machineTypes machineTypesInterface
+	regions      regionsInterface
+}
+
+var _ cloud.ReconcilerWithResult = &Service{}
+
+// New returns Service checking the requirements in the project of the scope.
+// The API clients are taken from the cache when the checks are run, or created if the cache is nil.
+func New(scope Scope, requirements Requirements, clients *ClientCache) *Service {
+	return &Service{
+		scope:        scope,
+		requirements: requirements,
+		clients:      clients,
+	}
+}
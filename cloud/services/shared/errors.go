@@ -0,0 +1,32 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ErrorConditionSeverity returns the severity of a condition reporting err: a warning if the error is expected to
+// clear with time, e.g. an exhausted quota, an error otherwise.
+func ErrorConditionSeverity(err error) clusterv1.ConditionSeverity {
+	if gcperrors.RetryAfter(err) > 0 {
+		return clusterv1.ConditionSeverityWarning
+	}
+
+	return clusterv1.ConditionSeverityError
+}
@@ -25,6 +25,7 @@ import (
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	gcplocation "sigs.k8s.io/cluster-api-provider-gcp/util/location"
 )
 
 // ManagedMachinePoolPreflightCheck will perform checks against the machine pool before its created.
@@ -33,7 +34,15 @@ func ManagedMachinePoolPreflightCheck(managedPool *infrav1exp.GCPManagedMachineP
 		return fmt.Errorf("expect machinepool infraref (%s) to match managed machine pool name (%s)", machinePool.Spec.Template.Spec.InfrastructureRef.Name, managedPool.Name)
 	}
 
-	if IsRegional(location) {
+	if err := validateNodeLocations(managedPool.Spec.NodeLocations, location); err != nil {
+		return fmt.Errorf("machine pool (%s): %w", machinePool.Name, err)
+	}
+
+	if len(managedPool.Spec.NodeLocations) > 0 {
+		if zones := NodePoolZoneCount(managedPool, location); *machinePool.Spec.Replicas%zones != 0 {
+			return fmt.Errorf("a machine pool (%s) with %d node locations must have replicas with a multiple of %d", machinePool.Name, zones, zones)
+		}
+	} else if IsRegional(location) {
 		if *machinePool.Spec.Replicas%cloud.DefaultNumRegionsPerZone != 0 {
 			return fmt.Errorf("a machine pool (%s) in a regional cluster must have replicas with a multiple of %d", machinePool.Name, cloud.DefaultNumRegionsPerZone)
 		}
@@ -42,6 +51,38 @@ func ManagedMachinePoolPreflightCheck(managedPool *infrav1exp.GCPManagedMachineP
 	return nil
 }
 
+// validateNodeLocations checks that the node locations are zones of the region of the cluster.
+func validateNodeLocations(nodeLocations []string, clusterLocation string) error {
+	clusterLoc, err := gcplocation.Parse(clusterLocation)
+	if err != nil {
+		return err
+	}
+
+	for _, nodeLocation := range nodeLocations {
+		loc, err := gcplocation.Parse(nodeLocation)
+		if err != nil || loc.Zone == nil {
+			return fmt.Errorf("node location %s is not a zone", nodeLocation)
+		}
+		if loc.Region != clusterLoc.Region {
+			return fmt.Errorf("node location %s is not in the region %s of the cluster", nodeLocation, clusterLoc.Region)
+		}
+	}
+
+	return nil
+}
+
+// NodePoolZoneCount returns the number of zones the nodes of a node pool are spread across.
+// The replicas of the MachinePool are divided by it to get the number of nodes per zone of the node pool.
+func NodePoolZoneCount(managedPool *infrav1exp.GCPManagedMachinePool, location string) int32 {
+	if len(managedPool.Spec.NodeLocations) > 0 {
+		return int32(len(managedPool.Spec.NodeLocations))
+	}
+	if IsRegional(location) {
+		return cloud.DefaultNumRegionsPerZone
+	}
+	return 1
+}
+
 // ManagedMachinePoolsPreflightCheck will perform checks against a slice of machine pool before they are created.
 func ManagedMachinePoolsPreflightCheck(managedPools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool, location string) error {
 	if len(machinePools) != len(managedPools) {
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestManagedMachinePoolPreflightCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodeLocations []string
+		replicas      int32
+		wantErr       bool
+	}{
+		{name: "regional pool", replicas: 3},
+		{name: "regional pool with replicas not a multiple of the zones", replicas: 4, wantErr: true},
+		{name: "node locations in the region", nodeLocations: []string{"us-central1-a", "us-central1-b"}, replicas: 4},
+		{name: "replicas not a multiple of the node locations", nodeLocations: []string{"us-central1-a", "us-central1-b"}, replicas: 3, wantErr: true},
+		{name: "node location in another region", nodeLocations: []string{"us-central1-a", "europe-west1-b"}, replicas: 2, wantErr: true},
+		{name: "node location is a region", nodeLocations: []string{"us-central1"}, replicas: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedPool := &infrav1exp.GCPManagedMachinePool{}
+			managedPool.Name = "pool"
+			managedPool.Spec.NodeLocations = tt.nodeLocations
+			machinePool := &clusterv1exp.MachinePool{}
+			machinePool.Name = "pool"
+			machinePool.Spec.Replicas = pointer.Int32(tt.replicas)
+			machinePool.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{Name: "pool"}
+
+			err := ManagedMachinePoolPreflightCheck(managedPool, machinePool, "us-central1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ManagedMachinePoolPreflightCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodePoolZoneCount(t *testing.T) {
+	managedPool := &infrav1exp.GCPManagedMachinePool{}
+	if got := NodePoolZoneCount(managedPool, "us-central1"); got != 3 {
+		t.Errorf("NodePoolZoneCount() = %d, want 3 for a regional cluster", got)
+	}
+	if got := NodePoolZoneCount(managedPool, "us-central1-a"); got != 1 {
+		t.Errorf("NodePoolZoneCount() = %d, want 1 for a zonal cluster", got)
+	}
+	managedPool.Spec.NodeLocations = []string{"us-central1-a", "us-central1-f"}
+	if got := NodePoolZoneCount(managedPool, "us-central1"); got != 2 {
+		t.Errorf("NodePoolZoneCount() = %d, want 2 for explicit node locations", got)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// OperationPollInterval is the interval at which a pending GKE operation is polled.
+const OperationPollInterval = 15 * time.Second
+
+// NewGKEOperation returns the status of a GKE operation started in the location, in the
+// format projects/{project}/locations/{location}.
+func NewGKEOperation(op *containerpb.Operation, location string) *infrav1exp.GKEOperation {
+	if op == nil || op.GetName() == "" {
+		return nil
+	}
+
+	startTime := metav1.Now()
+	if t, err := time.Parse(time.RFC3339, op.GetStartTime()); err == nil {
+		startTime = metav1.NewTime(t)
+	}
+
+	return &infrav1exp.GKEOperation{
+		Name:      fmt.Sprintf("%s/operations/%s", location, op.GetName()),
+		Type:      op.GetOperationType().String(),
+		StartTime: &startTime,
+	}
+}
+
+// GetGKEOperation returns the GKE operation with the full name, or nil if it doesn't exist anymore.
+func GetGKEOperation(ctx context.Context, client *container.ClusterManagerClient, name string) (*containerpb.Operation, error) {
+	op, err := client.GetOperation(ctx, &containerpb.GetOperationRequest{Name: name})
+	if err != nil {
+		return nil, gcperrors.IgnoreNotFound(err)
+	}
+
+	return op, nil
+}
+
+// gkeOperationError is the error of a failed GKE operation. It carries the status of the operation, so that it can
+// be classified like the error of an API call.
+type gkeOperationError struct {
+	status *status.Status
+}
+
+func (e *gkeOperationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.status.Code(), e.status.Message())
+}
+
+// GRPCStatus returns the status of the operation.
+func (e *gkeOperationError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// GKEOperationError returns the error of a completed GKE operation, or nil if it succeeded.
+func GKEOperationError(op *containerpb.Operation) error {
+	if op.GetError() != nil && op.GetError().GetCode() != int32(codes.OK) {
+		return &gkeOperationError{status: status.FromProto(op.GetError())}
+	}
+	if message := op.GetStatusMessage(); message != "" { //nolint:staticcheck // Older operations only report the status message.
+		return errors.New(message)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+func TestNewGKEOperation(t *testing.T) {
+	op := &containerpb.Operation{
+		Name:          "operation-123",
+		OperationType: containerpb.Operation_UPGRADE_MASTER,
+		StartTime:     "2023-08-01T10:00:00.123456789Z",
+	}
+	got := NewGKEOperation(op, "projects/my-project/locations/us-central1")
+	if got == nil {
+		t.Fatal("NewGKEOperation() = nil")
+	}
+	if want := "projects/my-project/locations/us-central1/operations/operation-123"; got.Name != want {
+		t.Errorf("Name = %s, want %s", got.Name, want)
+	}
+	if want := "UPGRADE_MASTER"; got.Type != want {
+		t.Errorf("Type = %s, want %s", got.Type, want)
+	}
+	if want := time.Date(2023, 8, 1, 10, 0, 0, 123456789, time.UTC); got.StartTime == nil || !got.StartTime.Time.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", got.StartTime, want)
+	}
+
+	if got := NewGKEOperation(nil, "projects/my-project/locations/us-central1"); got != nil {
+		t.Errorf("NewGKEOperation(nil) = %v, want nil", got)
+	}
+}
+
+func TestGKEOperationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      *containerpb.Operation
+		wantErr string
+	}{
+		{
+			name: "succeeded",
+			op:   &containerpb.Operation{Status: containerpb.Operation_DONE},
+		},
+		{
+			name: "succeeded with ok status",
+			op:   &containerpb.Operation{Status: containerpb.Operation_DONE, Error: &status.Status{Code: int32(code.Code_OK)}},
+		},
+		{
+			name:    "failed",
+			op:      &containerpb.Operation{Status: containerpb.Operation_DONE, Error: &status.Status{Code: int32(code.Code_RESOURCE_EXHAUSTED), Message: "quota exceeded"}},
+			wantErr: "ResourceExhausted: quota exceeded",
+		},
+		{
+			name:    "failed with status message",
+			op:      &containerpb.Operation{Status: containerpb.Operation_DONE, StatusMessage: "zone unavailable"}, //nolint:staticcheck // Older operations only report the status message.
+			wantErr: "zone unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GKEOperationError(tt.op)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("GKEOperationError() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("GKEOperationError() = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGKEOperationErrorClassification(t *testing.T) {
+	err := GKEOperationError(&containerpb.Operation{Status: containerpb.Operation_DONE, Error: &status.Status{Code: int32(code.Code_RESOURCE_EXHAUSTED), Message: "quota exceeded"}})
+	if !gcperrors.IsQuotaExceeded(err) {
+		t.Errorf("GKEOperationError() = %v, want a quota exceeded error", err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	// versionSkewIncrease is the version from which nodes may lag three minor versions behind the control plane.
+	versionSkewIncrease = version.MustParseGeneric("1.28")
+
+	// ErrNodeVersionAheadOfControlPlane is used when a node version is newer than the control plane version.
+	ErrNodeVersionAheadOfControlPlane = errors.New("node version is newer than the control plane version")
+	// ErrUnsupportedVersionSkew is used when a node version is older than the control plane version allows.
+	ErrUnsupportedVersionSkew = errors.New("node version is outside the supported version skew of the control plane")
+)
+
+// NormalizeVersion removes the optional "v" prefix used by Cluster API from a Kubernetes version,
+// giving the format expected by GKE.
+func NormalizeVersion(v string) string {
+	return strings.TrimPrefix(v, "v")
+}
+
+// IsVersionAlias returns true if the version is one of the aliases accepted by GKE instead of an
+// explicit version.
+func IsVersionAlias(v string) bool {
+	return v == "" || v == "-" || v == "latest"
+}
+
+// VersionSatisfies returns true if the current GKE version matches the desired version.
+// The desired version can be partial (e.g. "1.27" or "1.27.3") in which case any GKE version
+// starting with it matches.
+func VersionSatisfies(current, desired string) bool {
+	current = NormalizeVersion(current)
+	desired = NormalizeVersion(desired)
+	if IsVersionAlias(desired) || current == desired {
+		return true
+	}
+
+	return strings.HasPrefix(current, desired+".") || strings.HasPrefix(current, desired+"-")
+}
+
+// MaxNodeVersionSkew returns the number of minor versions nodes are allowed to lag behind a
+// control plane running the given version.
+func MaxNodeVersionSkew(controlPlaneVersion *version.Version) uint {
+	if controlPlaneVersion.AtLeast(versionSkewIncrease) {
+		return 3
+	}
+	return 2
+}
+
+// ValidateNodeVersionSkew checks that nodes running nodeVersion are supported by a control plane
+// running controlPlaneVersion. It returns an error wrapping ErrNodeVersionAheadOfControlPlane or
+// ErrUnsupportedVersionSkew if they aren't.
+func ValidateNodeVersionSkew(controlPlaneVersion, nodeVersion string) error {
+	cpVersion, err := version.ParseGeneric(NormalizeVersion(controlPlaneVersion))
+	if err != nil {
+		return fmt.Errorf("parsing control plane version %q: %w", controlPlaneVersion, err)
+	}
+	nVersion, err := version.ParseGeneric(NormalizeVersion(nodeVersion))
+	if err != nil {
+		return fmt.Errorf("parsing node version %q: %w", nodeVersion, err)
+	}
+
+	if nVersion.Major() != cpVersion.Major() {
+		return fmt.Errorf("%w: node version %s, control plane version %s", ErrUnsupportedVersionSkew, nodeVersion, controlPlaneVersion)
+	}
+	// Partial versions only ask for the latest patch of a minor version available to nodes, which
+	// is never newer than the control plane once the control plane runs that minor version.
+	nodeComponents := strings.Count(NormalizeVersion(nodeVersion), ".") + 1
+	if nVersion.Minor() > cpVersion.Minor() || (nodeComponents > 2 && nVersion.Minor() == cpVersion.Minor() && nVersion.Patch() > cpVersion.Patch()) {
+		return fmt.Errorf("%w: node version %s, control plane version %s", ErrNodeVersionAheadOfControlPlane, nodeVersion, controlPlaneVersion)
+	}
+	if cpVersion.Minor()-nVersion.Minor() > MaxNodeVersionSkew(cpVersion) {
+		return fmt.Errorf("%w: node version %s, control plane version %s", ErrUnsupportedVersionSkew, nodeVersion, controlPlaneVersion)
+	}
+
+	return nil
+}
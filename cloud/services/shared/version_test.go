@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		current string
+		desired string
+		want    bool
+	}{
+		{current: "1.27.3-gke.100", desired: "1.27.3-gke.100", want: true},
+		{current: "1.27.3-gke.100", desired: "v1.27.3-gke.100", want: true},
+		{current: "1.27.3-gke.100", desired: "1.27", want: true},
+		{current: "1.27.3-gke.100", desired: "1.27.3", want: true},
+		{current: "1.27.3-gke.100", desired: "latest", want: true},
+		{current: "1.27.13-gke.100", desired: "1.27.1", want: false},
+		{current: "1.27.3-gke.100", desired: "1.28", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+"/"+tt.desired, func(t *testing.T) {
+			if got := VersionSatisfies(tt.current, tt.desired); got != tt.want {
+				t.Errorf("VersionSatisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNodeVersionSkew(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane string
+		node         string
+		wantErr      error
+	}{
+		{name: "same version", controlPlane: "1.27.3-gke.100", node: "1.27.3-gke.100"},
+		{name: "partial node version", controlPlane: "1.27.3-gke.100", node: "v1.27"},
+		{name: "two minor versions behind", controlPlane: "1.27.3-gke.100", node: "1.25.10-gke.200"},
+		{name: "three minor versions behind before 1.28", controlPlane: "1.27.3-gke.100", node: "1.24.10-gke.200", wantErr: ErrUnsupportedVersionSkew},
+		{name: "three minor versions behind from 1.28", controlPlane: "1.28.3-gke.100", node: "1.25.10-gke.200"},
+		{name: "newer minor version", controlPlane: "1.27.3-gke.100", node: "1.28", wantErr: ErrNodeVersionAheadOfControlPlane},
+		{name: "newer patch version", controlPlane: "1.27.3-gke.100", node: "1.27.5-gke.100", wantErr: ErrNodeVersionAheadOfControlPlane},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodeVersionSkew(tt.controlPlane, tt.node)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateNodeVersionSkew() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
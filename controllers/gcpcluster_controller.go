@@ -25,12 +25,13 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
-	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -52,6 +53,8 @@ type GCPClusterReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// PreflightClients are the clients of the preflight checks, reused across reconciliations.
+	PreflightClients *preflight.ClientCache
 }
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
@@ -152,7 +155,7 @@ func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Handle deleted clusters
 	if !gcpCluster.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.reconcileDelete(ctx, clusterScope)
+		return r.reconcileDelete(ctx, clusterScope)
 	}
 
 	// Handle non-deleted clusters
@@ -197,19 +200,23 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 
 	clusterScope.SetFailureDomains(failureDomains)
 
-	reconcilers := []cloud.Reconciler{
-		networks.New(clusterScope),
-		firewalls.New(clusterScope),
-		loadbalancers.New(clusterScope),
-		subnets.New(clusterScope),
+	services, err := r.newClusterServices(clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for _, r := range reconcilers {
-		if err := r.Reconcile(ctx); err != nil {
-			log.Error(err, "Reconcile error")
-			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+	res, err := services.Reconcile(ctx)
+	if err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
 		}
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.V(4).Info("Reconciler requested requeue", "after", res.RequeueAfter)
+		return res, nil
 	}
 
 	controlPlaneEndpoint := clusterScope.ControlPlaneEndpoint()
@@ -225,26 +232,43 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	return ctrl.Result{}, nil
 }
 
-func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
+func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPCluster")
 
-	reconcilers := []cloud.Reconciler{
-		subnets.New(clusterScope),
-		loadbalancers.New(clusterScope),
-		firewalls.New(clusterScope),
-		networks.New(clusterScope),
+	services, err := r.newClusterServices(clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for _, r := range reconcilers {
-		if err := r.Delete(ctx); err != nil {
-			log.Error(err, "Reconcile error")
-			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
-			return err
+	res, err := services.Delete(ctx)
+	if err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
 		}
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.V(4).Info("Reconciler requested requeue", "after", res.RequeueAfter)
+		return res, nil
 	}
 
 	controllerutil.RemoveFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
 	record.Event(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconciled")
-	return nil
+	return ctrl.Result{}, nil
+}
+
+// newClusterServices returns the graph of the cluster infrastructure services. Nothing is created until the preflight
+// checks pass. The firewalls, the subnets and the control-plane loadbalancer only need the network, so they are
+// reconciled together once it exists.
+func (r *GCPClusterReconciler) newClusterServices(clusterScope *scope.ClusterScope) (*reconciler.Graph, error) {
+	return reconciler.NewGraph(
+		reconciler.Service{Name: "preflight", Reconciler: preflight.New(clusterScope, preflight.ClusterRequirements(clusterScope.GCPCluster), r.PreflightClients)},
+		reconciler.Service{Name: "networks", Reconciler: networks.New(clusterScope), DependsOn: []string{"preflight"}},
+		reconciler.Service{Name: "firewalls", Reconciler: firewalls.New(clusterScope), DependsOn: []string{"networks"}},
+		reconciler.Service{Name: "loadbalancers", Reconciler: loadbalancers.New(clusterScope), DependsOn: []string{"networks"}},
+		reconciler.Service{Name: "subnets", Reconciler: subnets.New(clusterScope), DependsOn: []string{"networks"}},
+	)
 }
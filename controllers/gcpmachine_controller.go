@@ -24,9 +24,12 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/instances"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/stockout"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
@@ -47,6 +50,10 @@ type GCPMachineReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// ExhaustedZones are the zones that recently ran out of resources for a machine type.
+	ExhaustedZones *stockout.Cache
+	// PreflightClients are the clients of the preflight checks, reused across reconciliations.
+	PreflightClients *preflight.ClientCache
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
@@ -191,10 +198,11 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Create the machine scope
 	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
-		Client:        r.Client,
-		Machine:       machine,
-		GCPMachine:    gcpMachine,
-		ClusterGetter: clusterScope,
+		Client:         r.Client,
+		Machine:        machine,
+		GCPMachine:     gcpMachine,
+		ClusterGetter:  clusterScope,
+		ExhaustedZones: r.ExhaustedZones,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -209,7 +217,7 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Handle deleted machines
 	if !gcpMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.reconcileDelete(ctx, machineScope)
+		return r.reconcileDelete(ctx, machineScope)
 	}
 
 	// Handle non-deleted machines
@@ -225,11 +233,38 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		return ctrl.Result{}, err
 	}
 
-	if err := instances.New(machineScope).Reconcile(ctx); err != nil {
+	requirements := preflight.MachineRequirements(machineScope.GCPMachine, machineScope.Zone(), machineScope.IsControlPlane())
+	res, err := preflight.New(machineScope, requirements, r.PreflightClients).Reconcile(ctx)
+	if err != nil {
+		log.Error(err, "Error running preflight checks")
+		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Preflight checks error - %v", err)
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.Info("Preflight checks failed, retrying later", "after", res.RequeueAfter)
+		return res, nil
+	}
+
+	res, err = instances.New(machineScope).Reconcile(ctx)
+	if err != nil {
 		log.Error(err, "Error reconciling instance resources")
 		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Reconcile error - %v", err)
+		if gcperrors.IsTerminal(err) {
+			// GCE rejected the instance, retrying won't help until the GCPMachine is fixed.
+			machineScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+			machineScope.SetFailureMessage(err)
+			return ctrl.Result{}, nil
+		}
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			log.Info("Retrying later", "reason", gcperrors.Classify(err), "after", after)
+			return ctrl.Result{RequeueAfter: after}, nil
+		}
 		return ctrl.Result{}, err
 	}
+	if !res.IsZero() {
+		log.V(4).Info("Waiting for instance operations to complete", "after", res.RequeueAfter)
+		return res, nil
+	}
 
 	instanceState := *machineScope.GetInstanceStatus()
 	switch instanceState {
@@ -250,16 +285,24 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 	}
 }
 
-func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) error {
+func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPMachine")
 
-	if err := instances.New(machineScope).Delete(ctx); err != nil {
+	res, err := instances.New(machineScope).Delete(ctx)
+	if err != nil {
 		log.Error(err, "Error deleting instance resources")
-		return err
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.V(4).Info("Waiting for instance operations to complete", "after", res.RequeueAfter)
+		return res, nil
 	}
 
 	controllerutil.RemoveFinalizer(machineScope.GCPMachine, infrav1.MachineFinalizer)
 	record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
-	return nil
+	return ctrl.Result{}, nil
 }
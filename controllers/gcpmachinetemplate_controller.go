@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/machinetypes"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// GCPMachineTemplateReconciler reconciles the capacity of a GCPMachineTemplate object.
+type GCPMachineTemplateReconciler struct {
+	client.Client
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+	// MachineTypes are the machine types already looked up, per zone.
+	MachineTypes *machinetypes.Cache
+}
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinetemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinetemplates/status,verbs=get;update;patch
+
+func (r *GCPMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.GCPMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Watches(
+			&infrav1.GCPCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.GCPClusterToGCPMachineTemplates(ctx)),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	return nil
+}
+
+// GCPClusterToGCPMachineTemplates is a handler.ToRequestsFunc to be used to enqeue requests for reconciliation
+// of GCPMachineTemplates, so that their capacity is reported once the cluster has failure domains.
+func (r *GCPMachineTemplateReconciler) GCPClusterToGCPMachineTemplates(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(mapCtx context.Context, o client.Object) []ctrl.Request {
+		c, ok := o.(*infrav1.GCPCluster)
+		if !ok {
+			log.Error(errors.Errorf("expected a GCPCluster but got a %T", o), "failed to get GCPMachineTemplates for GCPCluster")
+			return nil
+		}
+
+		cluster, err := util.GetOwnerCluster(mapCtx, r.Client, c.ObjectMeta)
+		switch {
+		case apierrors.IsNotFound(err) || cluster == nil:
+			return nil
+		case err != nil:
+			log.Error(err, "failed to get owning cluster")
+			return nil
+		}
+
+		templateList := &infrav1.GCPMachineTemplateList{}
+		if err := r.List(mapCtx, templateList, client.InNamespace(c.Namespace)); err != nil {
+			log.Error(err, "failed to list GCPMachineTemplates")
+			return nil
+		}
+
+		result := []ctrl.Request{}
+		for _, t := range templateList.Items {
+			if templateClusterName(t.ObjectMeta) != cluster.Name {
+				continue
+			}
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+		}
+
+		return result
+	}
+}
+
+// templateClusterName returns the name of the Cluster of a machine template: the owner Cluster, which is set by
+// the MachineDeployment and MachineSet controllers, or the cluster name label.
+func templateClusterName(obj metav1.ObjectMeta) string {
+	for _, ref := range obj.OwnerReferences {
+		if ref.Kind == "Cluster" && ref.APIVersion == clusterv1.GroupVersion.String() {
+			return ref.Name
+		}
+	}
+	return obj.Labels[clusterv1.ClusterNameLabel]
+}
+
+func (r *GCPMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	log := ctrl.LoggerFrom(ctx)
+	gcpMachineTemplate := &infrav1.GCPMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, gcpMachineTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !gcpMachineTemplate.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	clusterName := templateClusterName(gcpMachineTemplate.ObjectMeta)
+	if clusterName == "" {
+		log.Info("GCPMachineTemplate has no owner Cluster or cluster label yet")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", clusterName)
+	cluster, err := util.GetClusterByName(ctx, r.Client, gcpMachineTemplate.Namespace, clusterName)
+	if err != nil {
+		log.Info("Cluster does not exist")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, gcpMachineTemplate) {
+		log.Info("GCPMachineTemplate or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.Spec.InfrastructureRef == nil {
+		log.Info("Cluster has no infrastructure yet")
+		return ctrl.Result{}, nil
+	}
+
+	gcpCluster := &infrav1.GCPCluster{}
+	gcpClusterKey := client.ObjectKey{
+		Namespace: gcpMachineTemplate.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, gcpClusterKey, gcpCluster); err != nil {
+		log.Info("GCPCluster is not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:     r.Client,
+		Cluster:    cluster,
+		GCPCluster: gcpCluster,
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	machineTemplateScope, err := scope.NewMachineTemplateScope(scope.MachineTemplateScopeParams{
+		Client:             r.Client,
+		ClusterGetter:      clusterScope,
+		GCPMachineTemplate: gcpMachineTemplate,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any GCPMachineTemplate changes.
+	defer func() {
+		if err := machineTemplateScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if err := machinetypes.New(machineTemplateScope, r.MachineTypes).Reconcile(ctx); err != nil {
+		log.Error(err, "Error reconciling GCPMachineTemplate capacity")
+		record.Warnf(gcpMachineTemplate, "GCPMachineTemplateReconcile", "Reconcile error - %v", err)
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
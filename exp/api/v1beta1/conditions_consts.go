@@ -27,6 +27,10 @@ const (
 	GKEControlPlaneUpdatingCondition clusterv1.ConditionType = "GKEControlPlaneUpdating"
 	// GKEControlPlaneDeletingCondition condition reports on whether the GKE control plane is deleting.
 	GKEControlPlaneDeletingCondition clusterv1.ConditionType = "GKEControlPlaneDeleting"
+	// GKEControlPlaneUpgradingCondition condition reports on whether a Kubernetes version upgrade of the GKE control plane or its node pools is in progress.
+	GKEControlPlaneUpgradingCondition clusterv1.ConditionType = "GKEControlPlaneUpgrading"
+	// GKEControlPlaneOperationSucceededCondition condition reports on whether the most recent GKE operation on the cluster succeeded.
+	GKEControlPlaneOperationSucceededCondition clusterv1.ConditionType = "GKEControlPlaneOperationSucceeded"
 
 	// GKEControlPlaneCreatingReason used to report GKE control plane being created.
 	GKEControlPlaneCreatingReason = "GKEControlPlaneCreating"
@@ -44,6 +48,16 @@ const (
 	GKEControlPlaneReconciliationFailedReason = "GKEControlPlaneReconciliationFailed"
 	// GKEControlPlaneRequiresAtLeastOneNodePoolReason used to report that no node pool is specified for the GKE control plane.
 	GKEControlPlaneRequiresAtLeastOneNodePoolReason = "GKEControlPlaneRequiresAtLeastOneNodePool"
+	// GKEControlPlaneAdoptionRequiredReason used to report that a GKE cluster with the same name exists but wasn't created by the provider.
+	GKEControlPlaneAdoptionRequiredReason = "GKEControlPlaneAdoptionRequired"
+	// GKEControlPlaneAdoptionBlockedReason used to report that adopting an existing GKE cluster requires differences to be acknowledged.
+	GKEControlPlaneAdoptionBlockedReason = "GKEControlPlaneAdoptionBlocked"
+	// GKEControlPlaneUpgradedReason used to report GKE control plane and node pools are upgraded.
+	GKEControlPlaneUpgradedReason = "GKEControlPlaneUpgraded"
+	// GKEControlPlaneInvalidVersionReason used to report that the requested GKE control plane version can't be used.
+	GKEControlPlaneInvalidVersionReason = "GKEControlPlaneInvalidVersion"
+	// GKEControlPlaneOperationFailedReason used to report that a GKE operation on the cluster failed.
+	GKEControlPlaneOperationFailedReason = "GKEControlPlaneOperationFailed"
 
 	// GKEMachinePoolReadyCondition condition reports on the successful reconciliation of GKE node pool.
 	GKEMachinePoolReadyCondition clusterv1.ConditionType = "GKEMachinePoolReady"
@@ -53,6 +67,8 @@ const (
 	GKEMachinePoolUpdatingCondition clusterv1.ConditionType = "GKEMachinePoolUpdating"
 	// GKEMachinePoolDeletingCondition condition reports on whether the GKE node pool is deleting.
 	GKEMachinePoolDeletingCondition clusterv1.ConditionType = "GKEMachinePoolDeleting"
+	// GKEMachinePoolOperationSucceededCondition condition reports on whether the most recent GKE operation on the node pool succeeded.
+	GKEMachinePoolOperationSucceededCondition clusterv1.ConditionType = "GKEMachinePoolOperationSucceeded"
 
 	// WaitingForGKEControlPlaneReason used when the machine pool is waiting for GKE control plane infrastructure to be ready before proceeding.
 	WaitingForGKEControlPlaneReason = "WaitingForGKEControlPlane"
@@ -68,6 +84,34 @@ const (
 	GKEMachinePoolDeletedReason = "GKEMachinePoolDeleted"
 	// GKEMachinePoolErrorReason used to report GKE node pool is in error state.
 	GKEMachinePoolErrorReason = "GKEMachinePoolError"
+	// GKEMachinePoolDegradedReason used to report GKE node pool is running with errors.
+	GKEMachinePoolDegradedReason = "GKEMachinePoolDegraded"
 	// GKEMachinePoolReconciliationFailedReason used to report failures while reconciling GKE node pool.
 	GKEMachinePoolReconciliationFailedReason = "GKEMachinePoolReconciliationFailed"
+	// GKEMachinePoolAdoptionRequiredReason used to report that a GKE node pool with the same name exists but wasn't created by the provider.
+	GKEMachinePoolAdoptionRequiredReason = "GKEMachinePoolAdoptionRequired"
+	// GKEMachinePoolAdoptionBlockedReason used to report that adopting an existing GKE node pool requires differences to be acknowledged.
+	GKEMachinePoolAdoptionBlockedReason = "GKEMachinePoolAdoptionBlocked"
+	// GKEMachinePoolUpgradePendingReason used to report that a GKE node pool version upgrade is waiting for the control plane or another node pool.
+	GKEMachinePoolUpgradePendingReason = "GKEMachinePoolUpgradePending"
+	// GKEMachinePoolVersionSkewReason used to report that the GKE node pool version isn't within the skew supported by the control plane.
+	GKEMachinePoolVersionSkewReason = "GKEMachinePoolVersionSkew"
+	// GKEMachinePoolOperationFailedReason used to report that a GKE operation on the node pool failed.
+	GKEMachinePoolOperationFailedReason = "GKEMachinePoolOperationFailed"
+
+	// InstanceGroupReadyCondition condition reports on whether all the instances of the managed instance group of a GCPMachinePool run its current instance template.
+	InstanceGroupReadyCondition clusterv1.ConditionType = "InstanceGroupReady"
+
+	// WaitingForClusterInfrastructureReason used when the machine pool is waiting for the cluster infrastructure to be ready before proceeding.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+	// WaitingForBootstrapDataReason used when the machine pool is waiting for the bootstrap data to be ready before proceeding.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// InstanceGroupCreatingReason used to report the managed instance group being created.
+	InstanceGroupCreatingReason = "InstanceGroupCreating"
+	// InstanceGroupUpdatingReason used to report the instances of the managed instance group being created, replaced or deleted.
+	InstanceGroupUpdatingReason = "InstanceGroupUpdating"
+	// InstanceGroupDeletingReason used to report the managed instance group being deleted.
+	InstanceGroupDeletingReason = "InstanceGroupDeleting"
+	// InstanceGroupReconciliationFailedReason used to report failures while reconciling the managed instance group.
+	InstanceGroupReconciliationFailedReason = "InstanceGroupReconciliationFailed"
 )
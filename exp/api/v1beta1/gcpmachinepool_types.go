@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
+)
+
+const (
+	// MachinePoolFinalizer allows Reconcile to clean up GCP resources associated with the GCPMachinePool before
+	// removing it from the apiserver.
+	MachinePoolFinalizer = "gcpmachinepool.infrastructure.cluster.x-k8s.io"
+)
+
+// GCPMachinePoolSpec defines the desired state of GCPMachinePool.
+type GCPMachinePoolSpec struct {
+	// Template is the spec of the instances of the managed instance group, from which its instance template is
+	// built. It has the same settings as a GCPMachine, except ProviderID which is ignored.
+	Template infrav1.GCPMachineSpec `json:"template"`
+	// UpdateStrategy specifies how the instances are updated when the instance template changes.
+	// If not specified, the instances are replaced proactively, with the GCE defaults for the surge and
+	// unavailable instances.
+	// +optional
+	UpdateStrategy *MachinePoolUpdateStrategy `json:"updateStrategy,omitempty"`
+	// ProviderIDList are the provider IDs of the instances of the managed instance group.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+}
+
+// MachinePoolUpdateType is the type of update of the instances of a managed instance group.
+// +kubebuilder:validation:Enum=Proactive;Opportunistic
+type MachinePoolUpdateType string
+
+const (
+	// ProactiveUpdateType replaces the instances created from an older instance template.
+	ProactiveUpdateType MachinePoolUpdateType = "Proactive"
+	// OpportunisticUpdateType only creates the instances added afterwards, e.g. on scale up, from the new instance
+	// template.
+	OpportunisticUpdateType MachinePoolUpdateType = "Opportunistic"
+)
+
+// MachinePoolUpdateStrategy specifies how the instances of a managed instance group are updated.
+type MachinePoolUpdateStrategy struct {
+	// Type is the type of update. Defaults to Proactive.
+	// +optional
+	Type MachinePoolUpdateType `json:"type,omitempty"`
+	// MaxSurge is the maximum number of instances, or the percentage of the target size, that can be created above
+	// the target size during a proactive update. For a managed instance group spread across zones, a fixed number
+	// must be 0 or at least the number of zones. Defaults to the number of zones.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of instances, or the percentage of the target size, that can be
+	// unavailable during a proactive update. For a managed instance group spread across zones, a fixed number must
+	// be 0 or at least the number of zones. Defaults to the number of zones.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// GCPMachinePoolStatus defines the observed state of GCPMachinePool.
+type GCPMachinePoolStatus struct {
+	// Ready is true when the managed instance group has been created.
+	// +optional
+	Ready bool `json:"ready"`
+	// Replicas is the number of running instances of the managed instance group.
+	// +optional
+	Replicas int32 `json:"replicas"`
+	// InstanceGroupManager is the URL of the managed instance group. It is zonal if the MachinePool has a single
+	// failure domain, regional otherwise.
+	// +optional
+	InstanceGroupManager string `json:"instanceGroupManager,omitempty"`
+	// InstanceTemplate is the name of the instance template the instances are created from.
+	// +optional
+	InstanceTemplate string `json:"instanceTemplate,omitempty"`
+	// FailureReason will be set in the event that there is a terminal problem with the managed instance group
+	// and will contain a succinct value suitable for machine interpretation.
+	// +optional
+	FailureReason *errors.MachinePoolStatusFailure `json:"failureReason,omitempty"`
+	// FailureMessage will be set in the event that there is a terminal problem with the managed instance group
+	// and will contain a more verbose string suitable for logging and human consumption.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+	// PendingOperations are the GCE operations started by the provider that are still in progress.
+	// No other operation is started on their resources until they complete.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	PendingOperations []infrav1.GCEOperation `json:"pendingOperations,omitempty"`
+	// Conditions defines current service state of the GCPMachinePool.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmachinepools,scope=Namespaced,categories=cluster-api,shortName=gcpmp
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Managed instance group ready status"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Running instances of the managed instance group"
+// +kubebuilder:printcolumn:name="InstanceTemplate",type="string",JSONPath=".status.instanceTemplate",description="Instance template of the instances"
+
+// GCPMachinePool is the Schema for the gcpmachinepools API.
+type GCPMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPMachinePoolSpec   `json:"spec,omitempty"`
+	Status GCPMachinePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPMachinePoolList contains a list of GCPMachinePool.
+type GCPMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPMachinePool `json:"items"`
+}
+
+// GetConditions returns the machine pool conditions.
+func (r *GCPMachinePool) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the GCPMachinePool.
+func (r *GCPMachinePool) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPMachinePool{}, &GCPMachinePoolList{})
+}
@@ -62,6 +62,12 @@ type GCPManagedClusterStatus struct {
 	Ready          bool                     `json:"ready"`
 	// Conditions specifies the conditions for the managed control plane
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+	// PendingOperations are the GCE operations started by the provider that are still in progress.
+	// No other operation is started on their resources until they complete.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	PendingOperations []infrav1.GCEOperation `json:"pendingOperations,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -57,6 +57,61 @@ type GCPManagedControlPlaneSpec struct {
 	// This feature is disabled if this field is not specified.
 	// +optional
 	MasterAuthorizedNetworksConfig *MasterAuthorizedNetworksConfig `json:"master_authorized_networks_config,omitempty"`
+	// Addons represents the configuration of the addons of the GKE cluster.
+	// Addons that are not specified are left at the GKE defaults.
+	// +optional
+	Addons *AddonsConfig `json:"addons,omitempty"`
+	// MaintenancePolicy represents the maintenance windows and exclusions of the GKE cluster.
+	// If not specified, the maintenance policy of the cluster is not managed.
+	// +optional
+	MaintenancePolicy *MaintenancePolicy `json:"maintenancePolicy,omitempty"`
+	// Networking represents the networking configuration of the GKE cluster.
+	// Settings that are not specified are left at the GKE defaults.
+	// +optional
+	Networking *ClusterNetworking `json:"networking,omitempty"`
+	// LoggingConfig represents the logging configuration of the GKE cluster.
+	// If not specified, the logging configuration of the cluster is not managed.
+	// +optional
+	LoggingConfig *LoggingConfig `json:"loggingConfig,omitempty"`
+	// MonitoringConfig represents the monitoring configuration of the GKE cluster.
+	// If not specified, the monitoring configuration of the cluster is not managed.
+	// +optional
+	MonitoringConfig *MonitoringConfig `json:"monitoringConfig,omitempty"`
+	// ClusterAutoscaling represents the cluster autoscaling and node auto-provisioning configuration of the GKE cluster.
+	// If not specified, the cluster autoscaling configuration of the cluster is not managed.
+	// +optional
+	ClusterAutoscaling *ClusterAutoscaling `json:"clusterAutoscaling,omitempty"`
+	// EnableShieldedNodes indicates whether shielded nodes are enabled for the GKE cluster.
+	// If not specified, it is left at the GKE default.
+	// +optional
+	EnableShieldedNodes *bool `json:"enableShieldedNodes,omitempty"`
+	// BinaryAuthorizationEvaluationMode is the Binary Authorization evaluation mode of the GKE cluster.
+	// If not specified, the Binary Authorization configuration of the cluster is not managed.
+	// +optional
+	BinaryAuthorizationEvaluationMode *BinaryAuthorizationEvaluationMode `json:"binaryAuthorizationEvaluationMode,omitempty"`
+	// DatabaseEncryption represents the application-layer secrets encryption configuration of the GKE cluster.
+	// If not specified, the secrets encryption configuration of the cluster is not managed.
+	// +optional
+	DatabaseEncryption *DatabaseEncryption `json:"databaseEncryption,omitempty"`
+	// AuthenticatorGroupsConfig represents the configuration of Google Groups for RBAC of the GKE cluster.
+	// If not specified, Google Groups for RBAC is not managed.
+	// +optional
+	AuthenticatorGroupsConfig *AuthenticatorGroupsConfig `json:"authenticatorGroupsConfig,omitempty"`
+	// UserKubeconfig configures the kubeconfig generated for users of the GKE cluster.
+	// If not specified, the public endpoint and the gke-gcloud-auth-plugin are used.
+	// +optional
+	UserKubeconfig *UserKubeconfig `json:"userKubeconfig,omitempty"`
+	// KubeconfigServiceAccount is the email of the service account the access token embedded in the
+	// kubeconfig used by Cluster API is generated for. The credentials of the provider must be allowed
+	// to create tokens for it (roles/iam.serviceAccountTokenCreator). If not specified, the token is
+	// generated for the service account of the provider credentials.
+	// +kubebuilder:validation:Pattern=`^[^@]+@[^@]+$`
+	// +optional
+	KubeconfigServiceAccount *string `json:"kubeconfigServiceAccount,omitempty"`
+	// Adoption allows an existing GKE cluster that wasn't created by the provider to be managed.
+	// If not specified, an existing GKE cluster with the same name is not reconciled.
+	// +optional
+	Adoption *AdoptionPolicy `json:"adoption,omitempty"`
 }
 
 // GCPManagedControlPlaneStatus defines the observed state of GCPManagedControlPlane.
@@ -77,6 +132,26 @@ type GCPManagedControlPlaneStatus struct {
 	// CurrentVersion shows the current version of the GKE control plane.
 	// +optional
 	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// NextMaintenanceWindow shows the start time of the next scheduled maintenance window
+	// of the GKE cluster, taking maintenance exclusions into account.
+	// +optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+
+	// Upgrade shows the progress of the most recent Kubernetes version upgrade of the GKE
+	// control plane and of the node pools following it.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// PendingOperation is the GKE operation started on the cluster that hasn't completed yet.
+	// No other operation is started on the cluster until it completes.
+	// +optional
+	PendingOperation *GKEOperation `json:"pendingOperation,omitempty"`
+
+	// Adopted is true once an existing GKE cluster that wasn't created by the provider has been adopted.
+	// The spec is no longer filled from the live cluster after that.
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -142,6 +217,332 @@ type MasterAuthorizedNetworksConfigCidrBlock struct {
 	CidrBlock string `json:"cidr_block,omitempty"`
 }
 
+// AddonsConfig contains the configuration of the addons of the GKE cluster.
+type AddonsConfig struct {
+	// HTTPLoadBalancing indicates whether the HTTP (L7) load balancing controller addon is enabled.
+	// +optional
+	HTTPLoadBalancing *bool `json:"httpLoadBalancing,omitempty"`
+	// HorizontalPodAutoscaling indicates whether the horizontal pod autoscaling addon is enabled.
+	// +optional
+	HorizontalPodAutoscaling *bool `json:"horizontalPodAutoscaling,omitempty"`
+	// NetworkPolicy indicates whether the network policy addon is enabled. This must be enabled
+	// in order to enforce network policies on the nodes.
+	// +optional
+	NetworkPolicy *bool `json:"networkPolicy,omitempty"`
+	// GcePersistentDiskCsiDriver indicates whether the Compute Engine persistent disk CSI driver is enabled.
+	// +optional
+	GcePersistentDiskCsiDriver *bool `json:"gcePersistentDiskCsiDriver,omitempty"`
+	// GcpFilestoreCsiDriver indicates whether the Filestore CSI driver is enabled.
+	// +optional
+	GcpFilestoreCsiDriver *bool `json:"gcpFilestoreCsiDriver,omitempty"`
+	// GcsFuseCsiDriver indicates whether the Cloud Storage Fuse CSI driver is enabled.
+	// +optional
+	GcsFuseCsiDriver *bool `json:"gcsFuseCsiDriver,omitempty"`
+	// DNSCache indicates whether NodeLocal DNSCache is enabled.
+	// +optional
+	DNSCache *bool `json:"dnsCache,omitempty"`
+	// ConfigConnector indicates whether the Config Connector addon is enabled.
+	// +optional
+	ConfigConnector *bool `json:"configConnector,omitempty"`
+	// GatewayAPIChannel is the Gateway API release channel to use. Setting it to
+	// disabled disables the Gateway API on the cluster.
+	// +optional
+	GatewayAPIChannel *GatewayAPIChannel `json:"gatewayAPIChannel,omitempty"`
+}
+
+// GatewayAPIChannel is the Gateway API release channel of the GKE cluster.
+// +kubebuilder:validation:Enum=disabled;experimental;standard
+type GatewayAPIChannel string
+
+const (
+	// GatewayAPIChannelDisabled disables the Gateway API.
+	GatewayAPIChannelDisabled GatewayAPIChannel = "disabled"
+	// GatewayAPIChannelExperimental uses the experimental Gateway API channel.
+	GatewayAPIChannelExperimental GatewayAPIChannel = "experimental"
+	// GatewayAPIChannelStandard uses the standard Gateway API channel.
+	GatewayAPIChannelStandard GatewayAPIChannel = "standard"
+)
+
+// MaintenancePolicy defines the maintenance policy of the GKE cluster.
+type MaintenancePolicy struct {
+	// DailyMaintenanceWindow specifies a daily maintenance window.
+	// Cannot be used together with RecurringMaintenanceWindow.
+	// +optional
+	DailyMaintenanceWindow *DailyMaintenanceWindow `json:"dailyMaintenanceWindow,omitempty"`
+	// RecurringMaintenanceWindow specifies a recurring maintenance window.
+	// Cannot be used together with DailyMaintenanceWindow.
+	// +optional
+	RecurringMaintenanceWindow *RecurringMaintenanceWindow `json:"recurringMaintenanceWindow,omitempty"`
+	// MaintenanceExclusions specifies the periods during which automatic maintenance must not occur.
+	// +optional
+	MaintenanceExclusions []MaintenanceExclusion `json:"maintenanceExclusions,omitempty"`
+}
+
+// DailyMaintenanceWindow is a maintenance window that starts every day at the same time.
+type DailyMaintenanceWindow struct {
+	// StartTime is the time the maintenance window starts, in the "HH:MM" format (GMT).
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+}
+
+// RecurringMaintenanceWindow is a maintenance window that repeats according to a recurrence rule.
+type RecurringMaintenanceWindow struct {
+	// StartTime is the start time of the first window. Its time of day is the time each window starts.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the end time of the first window. The difference with StartTime is the duration of each window.
+	EndTime metav1.Time `json:"endTime"`
+	// Recurrence is an RRULE (https://tools.ietf.org/html/rfc5545#section-3.8.5.3) for how
+	// the window recurs, e.g. "FREQ=WEEKLY;BYDAY=SA,SU".
+	Recurrence string `json:"recurrence"`
+}
+
+// MaintenanceExclusion is a period during which automatic maintenance must not occur.
+type MaintenanceExclusion struct {
+	// Name is the name of the maintenance exclusion.
+	Name string `json:"name"`
+	// StartTime is the time the exclusion starts.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time the exclusion ends.
+	EndTime metav1.Time `json:"endTime"`
+	// Scope specifies which upgrades are prevented during the exclusion. Defaults to NoUpgrades.
+	// +optional
+	Scope *MaintenanceExclusionScope `json:"scope,omitempty"`
+}
+
+// MaintenanceExclusionScope is the scope of a maintenance exclusion.
+// +kubebuilder:validation:Enum=NoUpgrades;NoMinorUpgrades;NoMinorOrNodeUpgrades
+type MaintenanceExclusionScope string
+
+const (
+	// NoUpgrades prevents all upgrades, including patch upgrades and node upgrades.
+	NoUpgrades MaintenanceExclusionScope = "NoUpgrades"
+	// NoMinorUpgrades prevents minor upgrades of the control plane and the nodes.
+	NoMinorUpgrades MaintenanceExclusionScope = "NoMinorUpgrades"
+	// NoMinorOrNodeUpgrades prevents minor upgrades of the control plane and all upgrades of the nodes.
+	NoMinorOrNodeUpgrades MaintenanceExclusionScope = "NoMinorOrNodeUpgrades"
+)
+
+// ClusterNetworking defines the networking configuration of the GKE cluster.
+type ClusterNetworking struct {
+	// DatapathProvider is the datapath of the cluster. AdvancedDatapath enables GKE Dataplane V2,
+	// which enforces network policies without Calico. It can't be changed once the cluster is created.
+	// +optional
+	DatapathProvider *DatapathProvider `json:"datapathProvider,omitempty"`
+	// EnableNetworkPolicy indicates whether network policies are enforced with Calico. It can't be enabled
+	// together with the advanced datapath and requires the network policy addon.
+	// +optional
+	EnableNetworkPolicy *bool `json:"enableNetworkPolicy,omitempty"`
+	// EnableIntraNodeVisibility indicates whether the traffic between pods on the same node is visible to the VPC network.
+	// +optional
+	EnableIntraNodeVisibility *bool `json:"enableIntraNodeVisibility,omitempty"`
+	// DisableDefaultSNAT indicates whether the source NAT of the traffic of the pods to destinations outside
+	// of the VPC network is disabled.
+	// +optional
+	DisableDefaultSNAT *bool `json:"disableDefaultSNAT,omitempty"`
+	// DNS is the DNS configuration of the cluster.
+	// +optional
+	DNS *ClusterDNS `json:"dns,omitempty"`
+}
+
+// DatapathProvider is the datapath of the GKE cluster.
+// +kubebuilder:validation:Enum=LegacyDatapath;AdvancedDatapath
+type DatapathProvider string
+
+const (
+	// LegacyDatapath uses the iptables based datapath.
+	LegacyDatapath DatapathProvider = "LegacyDatapath"
+	// AdvancedDatapath uses the eBPF based GKE Dataplane V2.
+	AdvancedDatapath DatapathProvider = "AdvancedDatapath"
+)
+
+// ClusterDNS defines the DNS configuration of the GKE cluster.
+type ClusterDNS struct {
+	// Provider is the DNS provider of the cluster.
+	Provider ClusterDNSProvider `json:"provider"`
+	// Scope is the scope of the DNS records of Cloud DNS.
+	// +optional
+	Scope *ClusterDNSScope `json:"scope,omitempty"`
+	// Domain is the suffix of the DNS records of the cluster when Scope is VPC.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+}
+
+// ClusterDNSProvider is the DNS provider of the GKE cluster.
+// +kubebuilder:validation:Enum=PlatformDefault;CloudDNS;KubeDNS
+type ClusterDNSProvider string
+
+const (
+	// PlatformDefaultDNS uses the default DNS provider of GKE.
+	PlatformDefaultDNS ClusterDNSProvider = "PlatformDefault"
+	// CloudDNS uses Cloud DNS for GKE.
+	CloudDNS ClusterDNSProvider = "CloudDNS"
+	// KubeDNS uses kube-dns.
+	KubeDNS ClusterDNSProvider = "KubeDNS"
+)
+
+// ClusterDNSScope is the scope of the Cloud DNS records of the GKE cluster.
+// +kubebuilder:validation:Enum=Cluster;VPC
+type ClusterDNSScope string
+
+const (
+	// ClusterDNSScopeCluster makes the DNS records resolvable within the cluster only.
+	ClusterDNSScopeCluster ClusterDNSScope = "Cluster"
+	// ClusterDNSScopeVPC makes the DNS records resolvable within the VPC network.
+	ClusterDNSScopeVPC ClusterDNSScope = "VPC"
+)
+
+// LoggingConfig defines the logging configuration of the GKE cluster.
+type LoggingConfig struct {
+	// EnableComponents are the components whose logs are sent to Cloud Logging.
+	// An empty list disables logging.
+	// +optional
+	EnableComponents []LoggingComponent `json:"enableComponents,omitempty"`
+}
+
+// LoggingComponent is a component of the GKE cluster whose logs can be collected.
+// +kubebuilder:validation:Enum=SYSTEM_COMPONENTS;WORKLOADS;APISERVER;SCHEDULER;CONTROLLER_MANAGER
+type LoggingComponent string
+
+const (
+	// LoggingSystemComponents collects the logs of the system components.
+	LoggingSystemComponents LoggingComponent = "SYSTEM_COMPONENTS"
+	// LoggingWorkloads collects the logs of the workloads.
+	LoggingWorkloads LoggingComponent = "WORKLOADS"
+	// LoggingAPIServer collects the logs of the kube-apiserver.
+	LoggingAPIServer LoggingComponent = "APISERVER"
+	// LoggingScheduler collects the logs of the kube-scheduler.
+	LoggingScheduler LoggingComponent = "SCHEDULER"
+	// LoggingControllerManager collects the logs of the kube-controller-manager.
+	LoggingControllerManager LoggingComponent = "CONTROLLER_MANAGER"
+)
+
+// MonitoringConfig defines the monitoring configuration of the GKE cluster.
+type MonitoringConfig struct {
+	// EnableComponents are the components whose metrics are sent to Cloud Monitoring.
+	// If not specified, the monitoring components of the cluster are not managed.
+	// +optional
+	EnableComponents []MonitoringComponent `json:"enableComponents,omitempty"`
+	// ManagedPrometheus indicates whether Google Cloud Managed Service for Prometheus is enabled.
+	// If not specified, it is left at the GKE default.
+	// +optional
+	ManagedPrometheus *bool `json:"managedPrometheus,omitempty"`
+}
+
+// MonitoringComponent is a component of the GKE cluster whose metrics can be collected.
+// +kubebuilder:validation:Enum=SYSTEM_COMPONENTS;APISERVER;SCHEDULER;CONTROLLER_MANAGER;STORAGE;HPA;POD;DAEMONSET;DEPLOYMENT;STATEFULSET
+type MonitoringComponent string
+
+const (
+	// MonitoringSystemComponents collects the metrics of the system components.
+	MonitoringSystemComponents MonitoringComponent = "SYSTEM_COMPONENTS"
+	// MonitoringAPIServer collects the metrics of the kube-apiserver.
+	MonitoringAPIServer MonitoringComponent = "APISERVER"
+	// MonitoringScheduler collects the metrics of the kube-scheduler.
+	MonitoringScheduler MonitoringComponent = "SCHEDULER"
+	// MonitoringControllerManager collects the metrics of the kube-controller-manager.
+	MonitoringControllerManager MonitoringComponent = "CONTROLLER_MANAGER"
+	// MonitoringStorage collects the storage metrics.
+	MonitoringStorage MonitoringComponent = "STORAGE"
+	// MonitoringHPA collects the metrics of the horizontal pod autoscalers.
+	MonitoringHPA MonitoringComponent = "HPA"
+	// MonitoringPod collects the metrics of the pods.
+	MonitoringPod MonitoringComponent = "POD"
+	// MonitoringDaemonSet collects the metrics of the daemon sets.
+	MonitoringDaemonSet MonitoringComponent = "DAEMONSET"
+	// MonitoringDeployment collects the metrics of the deployments.
+	MonitoringDeployment MonitoringComponent = "DEPLOYMENT"
+	// MonitoringStatefulSet collects the metrics of the stateful sets.
+	MonitoringStatefulSet MonitoringComponent = "STATEFULSET"
+)
+
+// ClusterAutoscaling defines the cluster autoscaling configuration of the GKE cluster.
+type ClusterAutoscaling struct {
+	// EnableNodeAutoprovisioning indicates whether GKE creates and deletes node pools automatically
+	// based on the resource requests of unschedulable pods.
+	// +optional
+	EnableNodeAutoprovisioning bool `json:"enableNodeAutoprovisioning,omitempty"`
+	// ResourceLimits are the limits of the resources of the cluster. cpu and memory limits are
+	// required when node auto-provisioning is enabled.
+	// +optional
+	ResourceLimits []ResourceLimit `json:"resourceLimits,omitempty"`
+	// AutoscalingProfile is the profile used by the cluster autoscaler.
+	// If not specified, it is left at the GKE default.
+	// +optional
+	AutoscalingProfile *AutoscalingProfile `json:"autoscalingProfile,omitempty"`
+	// AutoprovisioningNodePoolDefaults are the defaults of the node pools created by node auto-provisioning.
+	// +optional
+	AutoprovisioningNodePoolDefaults *AutoprovisioningNodePoolDefaults `json:"autoprovisioningNodePoolDefaults,omitempty"`
+}
+
+// ResourceLimit is a limit of a resource of the GKE cluster.
+type ResourceLimit struct {
+	// ResourceType is the type of the resource, e.g. cpu, memory or a GPU type such as nvidia-tesla-t4.
+	// Memory is expressed in GB.
+	// +kubebuilder:validation:MinLength=1
+	ResourceType string `json:"resourceType"`
+	// Minimum is the minimum amount of the resource in the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Minimum int64 `json:"minimum,omitempty"`
+	// Maximum is the maximum amount of the resource in the cluster.
+	// +kubebuilder:validation:Minimum=0
+	Maximum int64 `json:"maximum"`
+}
+
+// AutoscalingProfile is the profile used by the cluster autoscaler.
+// +kubebuilder:validation:Enum=balanced;optimize-utilization
+type AutoscalingProfile string
+
+const (
+	// BalancedAutoscalingProfile keeps more resources available for incoming pods.
+	BalancedAutoscalingProfile AutoscalingProfile = "balanced"
+	// OptimizeUtilizationAutoscalingProfile removes underutilized nodes more aggressively.
+	OptimizeUtilizationAutoscalingProfile AutoscalingProfile = "optimize-utilization"
+)
+
+// AutoprovisioningNodePoolDefaults are the defaults of the node pools created by node auto-provisioning.
+type AutoprovisioningNodePoolDefaults struct {
+	// ServiceAccount is the email of the service account used by the nodes.
+	// If not specified, the Compute Engine default service account is used.
+	// +optional
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+	// OauthScopes are the OAuth scopes granted to the service account of the nodes.
+	// +optional
+	OauthScopes []string `json:"oauthScopes,omitempty"`
+	// BootDiskKMSKey is the Cloud KMS key used to encrypt the boot disks of the nodes (CMEK), in the format
+	// projects/[KEY_PROJECT_ID]/locations/[LOCATION]/keyRings/[RING_NAME]/cryptoKeys/[KEY_NAME].
+	// +optional
+	BootDiskKMSKey *string `json:"bootDiskKMSKey,omitempty"`
+}
+
+// BinaryAuthorizationEvaluationMode is the Binary Authorization evaluation mode of the GKE cluster.
+// +kubebuilder:validation:Enum=Disabled;ProjectSingletonPolicyEnforce
+type BinaryAuthorizationEvaluationMode string
+
+const (
+	// BinaryAuthorizationDisabled disables Binary Authorization.
+	BinaryAuthorizationDisabled BinaryAuthorizationEvaluationMode = "Disabled"
+	// BinaryAuthorizationProjectSingletonPolicyEnforce enforces the Binary Authorization policy of the project.
+	BinaryAuthorizationProjectSingletonPolicyEnforce BinaryAuthorizationEvaluationMode = "ProjectSingletonPolicyEnforce"
+)
+
+// DatabaseEncryption defines the application-layer secrets encryption configuration of the GKE cluster.
+type DatabaseEncryption struct {
+	// KeyName is the Cloud KMS key used to encrypt the secrets, in the format
+	// projects/[KEY_PROJECT_ID]/locations/[LOCATION]/keyRings/[RING_NAME]/cryptoKeys/[KEY_NAME].
+	// The key must be in the region of the cluster. An empty key name disables the encryption.
+	// +optional
+	KeyName string `json:"keyName,omitempty"`
+}
+
+// AuthenticatorGroupsConfig defines the configuration of Google Groups for RBAC of the GKE cluster.
+type AuthenticatorGroupsConfig struct {
+	// SecurityGroup is the name of the group containing the groups that can be used in RBAC policies,
+	// e.g. gke-security-groups@example.com.
+	// +kubebuilder:validation:Pattern=`^gke-security-groups@[^@]+$`
+	SecurityGroup string `json:"securityGroup"`
+}
+
 // GetConditions returns the control planes conditions.
 func (r *GCPManagedControlPlane) GetConditions() clusterv1.Conditions {
 	return r.Status.Conditions
@@ -155,3 +556,111 @@ func (r *GCPManagedControlPlane) SetConditions(conditions clusterv1.Conditions)
 func init() {
 	SchemeBuilder.Register(&GCPManagedControlPlane{}, &GCPManagedControlPlaneList{})
 }
+
+// UpgradeStatus shows the progress of a Kubernetes version upgrade of a GKE cluster.
+type UpgradeStatus struct {
+	// TargetVersion is the Kubernetes version the control plane is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+	// Phase is the current phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+	// StartTime is the time at which the control plane upgrade was requested.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time at which the control plane and all node pools reached
+	// their desired versions.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// PendingNodePools lists the GKE node pools that haven't reached their desired version yet.
+	// +optional
+	PendingNodePools []string `json:"pendingNodePools,omitempty"`
+}
+
+// UpgradePhase is the phase of a GKE cluster upgrade.
+type UpgradePhase string
+
+const (
+	// UpgradePhaseControlPlane means the control plane is being upgraded.
+	UpgradePhaseControlPlane UpgradePhase = "UpgradingControlPlane"
+	// UpgradePhaseNodePools means the control plane is upgraded and node pools are being upgraded.
+	UpgradePhaseNodePools UpgradePhase = "UpgradingNodePools"
+	// UpgradePhaseCompleted means the control plane and all node pools are upgraded.
+	UpgradePhaseCompleted UpgradePhase = "Completed"
+)
+
+// UserKubeconfig configures the kubeconfig generated for users of the GKE cluster.
+type UserKubeconfig struct {
+	// EndpointType is the endpoint of the control plane used in the kubeconfig.
+	// Public uses the public endpoint, Private the private endpoint of a private cluster
+	// and DNS the DNS name set in DNSName.
+	// +kubebuilder:validation:Enum=Public;Private;DNS
+	// +kubebuilder:default=Public
+	// +optional
+	EndpointType KubeconfigEndpointType `json:"endpointType,omitempty"`
+	// DNSName is the DNS name resolving to the control plane, used when EndpointType is DNS.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+	// AuthMethod is how users authenticate to the cluster.
+	// GcloudPlugin uses gke-gcloud-auth-plugin, Exec runs the command set in Exec
+	// and None leaves the user of the kubeconfig without credentials.
+	// +kubebuilder:validation:Enum=GcloudPlugin;Exec;None
+	// +kubebuilder:default=GcloudPlugin
+	// +optional
+	AuthMethod KubeconfigAuthMethod `json:"authMethod,omitempty"`
+	// Exec is the credential plugin run when AuthMethod is Exec.
+	// +optional
+	Exec *KubeconfigExecConfig `json:"exec,omitempty"`
+}
+
+// KubeconfigEndpointType is the endpoint of the control plane used in a kubeconfig.
+type KubeconfigEndpointType string
+
+const (
+	// PublicEndpoint is the public endpoint of the control plane.
+	PublicEndpoint KubeconfigEndpointType = "Public"
+	// PrivateEndpoint is the private endpoint of the control plane of a private cluster.
+	PrivateEndpoint KubeconfigEndpointType = "Private"
+	// DNSEndpoint is a DNS name resolving to the control plane.
+	DNSEndpoint KubeconfigEndpointType = "DNS"
+)
+
+// KubeconfigAuthMethod is how users authenticate with a kubeconfig.
+type KubeconfigAuthMethod string
+
+const (
+	// GcloudPluginAuth uses the gke-gcloud-auth-plugin credential plugin.
+	GcloudPluginAuth KubeconfigAuthMethod = "GcloudPlugin"
+	// ExecAuth uses a custom credential plugin.
+	ExecAuth KubeconfigAuthMethod = "Exec"
+	// NoAuth doesn't set any credentials.
+	NoAuth KubeconfigAuthMethod = "None"
+)
+
+// KubeconfigExecConfig is a credential plugin run to authenticate users.
+type KubeconfigExecConfig struct {
+	// Command is the command to run.
+	Command string `json:"command"`
+	// Args are the arguments passed to the command.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are additional environment variables set when running the command.
+	// +optional
+	Env []KubeconfigExecEnvVar `json:"env,omitempty"`
+	// APIVersion is the version of the client.authentication.k8s.io API used by the plugin.
+	// +kubebuilder:default="client.authentication.k8s.io/v1beta1"
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// ProvideClusterInfo passes the cluster information to the plugin.
+	// +optional
+	ProvideClusterInfo bool `json:"provideClusterInfo,omitempty"`
+	// InstallHint is shown to users when the command can't be found.
+	// +optional
+	InstallHint string `json:"installHint,omitempty"`
+}
+
+// KubeconfigExecEnvVar is an environment variable set when running a credential plugin.
+type KubeconfigExecEnvVar struct {
+	// Name is the name of the variable.
+	Name string `json:"name"`
+	// Value is the value of the variable.
+	Value string `json:"value"`
+}
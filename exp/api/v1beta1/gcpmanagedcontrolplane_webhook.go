@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,6 +40,8 @@ const (
 	resourcePrefix       = "capg-"
 )
 
+var kmsKeyNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
 // log is for logging in this package.
 var gcpmanagedcontrolplanelog = logf.Log.WithName("gcpmanagedcontrolplane-resource")
 
@@ -89,6 +92,13 @@ func (r *GCPManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec", "ReleaseChannel"), "Release channel is required for an autopilot enabled cluster"))
 	}
 
+	allErrs = append(allErrs, validateMaintenancePolicy(r.Spec.MaintenancePolicy, field.NewPath("spec", "maintenancePolicy"))...)
+	allErrs = append(allErrs, validateUserKubeconfig(r.Spec.UserKubeconfig, field.NewPath("spec", "userKubeconfig"))...)
+	allErrs = append(allErrs, validateObservability(r.Spec.LoggingConfig, r.Spec.MonitoringConfig, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateClusterAutoscaling(r.Spec.ClusterAutoscaling, r.Spec.EnableAutopilot, field.NewPath("spec", "clusterAutoscaling"))...)
+	allErrs = append(allErrs, validateDatabaseEncryption(r.Spec.DatabaseEncryption, r.Spec.Location, field.NewPath("spec", "databaseEncryption"))...)
+	allErrs = append(allErrs, validateNetworking(r.Spec.Networking, r.Spec.Addons, r.Spec.EnableAutopilot, field.NewPath("spec", "networking"))...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -130,6 +140,20 @@ func (r *GCPManagedControlPlane) ValidateUpdate(oldRaw runtime.Object) (admissio
 		)
 	}
 
+	if !cmp.Equal(datapathProvider(r.Spec.Networking), datapathProvider(old.Spec.Networking)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "networking", "datapathProvider"),
+				datapathProvider(r.Spec.Networking), "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, validateMaintenancePolicy(r.Spec.MaintenancePolicy, field.NewPath("spec", "maintenancePolicy"))...)
+	allErrs = append(allErrs, validateUserKubeconfig(r.Spec.UserKubeconfig, field.NewPath("spec", "userKubeconfig"))...)
+	allErrs = append(allErrs, validateObservability(r.Spec.LoggingConfig, r.Spec.MonitoringConfig, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateClusterAutoscaling(r.Spec.ClusterAutoscaling, r.Spec.EnableAutopilot, field.NewPath("spec", "clusterAutoscaling"))...)
+	allErrs = append(allErrs, validateDatabaseEncryption(r.Spec.DatabaseEncryption, r.Spec.Location, field.NewPath("spec", "databaseEncryption"))...)
+	allErrs = append(allErrs, validateNetworking(r.Spec.Networking, r.Spec.Addons, r.Spec.EnableAutopilot, field.NewPath("spec", "networking"))...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -144,6 +168,207 @@ func (r *GCPManagedControlPlane) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func validateMaintenancePolicy(policy *MaintenancePolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil {
+		return allErrs
+	}
+
+	if policy.DailyMaintenanceWindow != nil && policy.RecurringMaintenanceWindow != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("recurringMaintenanceWindow"), "cannot be set together with dailyMaintenanceWindow"))
+	}
+
+	if window := policy.RecurringMaintenanceWindow; window != nil {
+		if !window.EndTime.After(window.StartTime.Time) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("recurringMaintenanceWindow", "endTime"), window.EndTime, "must be after startTime"))
+		}
+		if !strings.HasPrefix(window.Recurrence, "FREQ=") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("recurringMaintenanceWindow", "recurrence"), window.Recurrence, "must be an RRULE starting with FREQ="))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, exclusion := range policy.MaintenanceExclusions {
+		exclusionPath := fldPath.Child("maintenanceExclusions").Index(i)
+		if exclusion.Name == "" {
+			allErrs = append(allErrs, field.Required(exclusionPath.Child("name"), "maintenance exclusion name is required"))
+		} else if names[exclusion.Name] {
+			allErrs = append(allErrs, field.Duplicate(exclusionPath.Child("name"), exclusion.Name))
+		}
+		names[exclusion.Name] = true
+		if !exclusion.EndTime.After(exclusion.StartTime.Time) {
+			allErrs = append(allErrs, field.Invalid(exclusionPath.Child("endTime"), exclusion.EndTime, "must be after startTime"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateObservability checks that the system components are collected whenever other components are,
+// as GKE requires them for every other logging and monitoring component.
+func validateObservability(loggingConfig *LoggingConfig, monitoringConfig *MonitoringConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if loggingConfig != nil && len(loggingConfig.EnableComponents) > 0 && !containsLoggingComponent(loggingConfig.EnableComponents, LoggingSystemComponents) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("loggingConfig", "enableComponents"), loggingConfig.EnableComponents, "must include SYSTEM_COMPONENTS"))
+	}
+	if monitoringConfig != nil && len(monitoringConfig.EnableComponents) > 0 && !containsMonitoringComponent(monitoringConfig.EnableComponents, MonitoringSystemComponents) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("monitoringConfig", "enableComponents"), monitoringConfig.EnableComponents, "must include SYSTEM_COMPONENTS"))
+	}
+
+	return allErrs
+}
+
+func validateClusterAutoscaling(autoscaling *ClusterAutoscaling, enableAutopilot bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if autoscaling == nil {
+		return allErrs
+	}
+
+	if enableAutopilot {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cluster autoscaling is managed by GKE for autopilot clusters"))
+	}
+
+	resourceTypes := map[string]bool{}
+	for i, limit := range autoscaling.ResourceLimits {
+		if resourceTypes[limit.ResourceType] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("resourceLimits").Index(i).Child("resourceType"), limit.ResourceType))
+		}
+		resourceTypes[limit.ResourceType] = true
+		if limit.Maximum < limit.Minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resourceLimits").Index(i).Child("maximum"), limit.Maximum, "must be greater than or equal to minimum"))
+		}
+	}
+	if autoscaling.EnableNodeAutoprovisioning {
+		for _, resourceType := range []string{"cpu", "memory"} {
+			if !resourceTypes[resourceType] {
+				allErrs = append(allErrs, field.Required(fldPath.Child("resourceLimits"), fmt.Sprintf("a %s limit is required when node auto-provisioning is enabled", resourceType)))
+			}
+		}
+	}
+	if defaults := autoscaling.AutoprovisioningNodePoolDefaults; defaults != nil && defaults.BootDiskKMSKey != nil {
+		if err := validateKMSKeyName(*defaults.BootDiskKMSKey, fldPath.Child("autoprovisioningNodePoolDefaults", "bootDiskKMSKey")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
+
+func validateNetworking(networking *ClusterNetworking, addons *AddonsConfig, enableAutopilot bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if networking == nil {
+		return allErrs
+	}
+
+	if networking.EnableNetworkPolicy != nil && *networking.EnableNetworkPolicy {
+		switch {
+		case enableAutopilot:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableNetworkPolicy"), "network policies are enforced by Dataplane V2 on autopilot clusters"))
+		case datapathProvider(networking) == AdvancedDatapath:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableNetworkPolicy"), "network policies are enforced by Dataplane V2 with the advanced datapath"))
+		case addons != nil && addons.NetworkPolicy != nil && !*addons.NetworkPolicy:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableNetworkPolicy"), "the network policy addon must be enabled"))
+		}
+	}
+
+	if dns := networking.DNS; dns != nil {
+		if dns.Scope != nil && dns.Provider != CloudDNS {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dns", "scope"), "can only be set when the provider is CloudDNS"))
+		}
+		if dns.Domain != "" && (dns.Scope == nil || *dns.Scope != ClusterDNSScopeVPC) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dns", "domain"), "can only be set when the scope is VPC"))
+		}
+	}
+
+	return allErrs
+}
+
+// datapathProvider returns the datapath provider of the networking configuration, or an empty string if it's not specified.
+func datapathProvider(networking *ClusterNetworking) DatapathProvider {
+	if networking == nil || networking.DatapathProvider == nil {
+		return ""
+	}
+	return *networking.DatapathProvider
+}
+
+func validateDatabaseEncryption(encryption *DatabaseEncryption, location string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if encryption == nil || encryption.KeyName == "" {
+		return allErrs
+	}
+
+	if err := validateKMSKeyName(encryption.KeyName, fldPath.Child("keyName")); err != nil {
+		return append(allErrs, err)
+	}
+	// The key has to be in the region of the cluster, multi-regional key locations aren't supported by GKE.
+	region := locationRegion(location)
+	if keyLocation := strings.Split(encryption.KeyName, "/")[3]; keyLocation != region {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyName"), encryption.KeyName, fmt.Sprintf("key must be in the region of the cluster (%s)", region)))
+	}
+
+	return allErrs
+}
+
+// locationRegion returns the region of a cluster location, which is the location without the zone suffix for zonal
+// clusters.
+func locationRegion(location string) string {
+	if strings.Count(location, "-") <= 1 {
+		return location
+	}
+	return location[:strings.LastIndex(location, "-")]
+}
+
+// validateKMSKeyName checks that a Cloud KMS key name is in the format
+// projects/[KEY_PROJECT_ID]/locations/[LOCATION]/keyRings/[RING_NAME]/cryptoKeys/[KEY_NAME].
+func validateKMSKeyName(keyName string, fldPath *field.Path) *field.Error {
+	if !kmsKeyNameRegex.MatchString(keyName) {
+		return field.Invalid(fldPath, keyName, "must be in the format projects/[KEY_PROJECT_ID]/locations/[LOCATION]/keyRings/[RING_NAME]/cryptoKeys/[KEY_NAME]")
+	}
+	return nil
+}
+
+func containsLoggingComponent(components []LoggingComponent, component LoggingComponent) bool {
+	for _, c := range components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonitoringComponent(components []MonitoringComponent, component MonitoringComponent) bool {
+	for _, c := range components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+func validateUserKubeconfig(config *UserKubeconfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if config == nil {
+		return allErrs
+	}
+
+	if config.EndpointType == DNSEndpoint && config.DNSName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("dnsName"), "dnsName is required when endpointType is DNS"))
+	}
+	if config.EndpointType != DNSEndpoint && config.DNSName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsName"), "can only be set when endpointType is DNS"))
+	}
+
+	if config.AuthMethod == ExecAuth && (config.Exec == nil || config.Exec.Command == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("exec", "command"), "exec command is required when authMethod is Exec"))
+	}
+	if config.AuthMethod != ExecAuth && config.Exec != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("exec"), "can only be set when authMethod is Exec"))
+	}
+
+	return allErrs
+}
+
 func generateGKEName(resourceName, namespace string, maxLength int) (string, error) {
 	escapedName := strings.ReplaceAll(resourceName, ".", "-")
 	gkeName := fmt.Sprintf("%s-%s", namespace, escapedName)
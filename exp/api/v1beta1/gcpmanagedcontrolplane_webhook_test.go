@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateDatabaseEncryption(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyName  string
+		location string
+		wantErr  bool
+	}{
+		{
+			name:     "key in the region of a regional cluster",
+			keyName:  "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1",
+		},
+		{
+			name:     "key in the region of a zonal cluster",
+			keyName:  "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1-a",
+		},
+		{
+			name:     "key in another region",
+			keyName:  "projects/my-proj/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1",
+			wantErr:  true,
+		},
+		{
+			name:     "multi-regional key for a regional cluster",
+			keyName:  "projects/my-proj/locations/us/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1",
+			wantErr:  true,
+		},
+		{
+			name:     "multi-regional key for a zonal cluster",
+			keyName:  "projects/my-proj/locations/us/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1-a",
+			wantErr:  true,
+		},
+		{
+			name:     "global key",
+			keyName:  "projects/my-proj/locations/global/keyRings/my-ring/cryptoKeys/my-key",
+			location: "us-central1",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encryption := &DatabaseEncryption{KeyName: tt.keyName}
+			errs := validateDatabaseEncryption(encryption, tt.location, field.NewPath("spec", "databaseEncryption"))
+			if gotErr := len(errs) > 0; gotErr != tt.wantErr {
+				t.Errorf("validateDatabaseEncryption() = %v, want error %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
 )
 
 const (
@@ -43,10 +44,47 @@ type GCPManagedMachinePoolSpec struct {
 	// KubernetesTaints specifies the taints to apply to the nodes of the node pool.
 	// +optional
 	KubernetesTaints Taints `json:"kubernetesTaints,omitempty"`
+	// NetworkTags specifies the network tags to apply to the nodes of the node pool. Network tags are used
+	// to identify valid sources or targets for network firewalls.
+	// +optional
+	NetworkTags []string `json:"networkTags,omitempty"`
+	// LinuxNodeConfig specifies the Linux node configuration of the nodes of the node pool.
+	// +optional
+	LinuxNodeConfig *LinuxNodeConfig `json:"linuxNodeConfig,omitempty"`
+	// KubeletConfig specifies the kubelet configuration of the nodes of the node pool.
+	// +optional
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
 	// AdditionalLabels is an optional set of tags to add to GCP resources managed by the GCP provider, in addition to the
 	// ones added by default.
 	// +optional
 	AdditionalLabels infrav1.Labels `json:"additionalLabels,omitempty"`
+	// Management specifies the node management options of the node pool.
+	// If not specified, the GKE defaults are used.
+	// +optional
+	Management *NodePoolManagement `json:"management,omitempty"`
+	// UpgradeSettings specifies the upgrade strategy of the node pool.
+	// If not specified, the GKE defaults are used.
+	// +optional
+	UpgradeSettings *NodePoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+	// NetworkConfig specifies the network configuration of the nodes of the node pool.
+	// If not specified, the network configuration of the cluster is used.
+	// +optional
+	NetworkConfig *NodePoolNetworkConfig `json:"networkConfig,omitempty"`
+	// MaxPodsPerNode is the maximum number of pods per node of the node pool. It can't be changed once the
+	// node pool is created. If not specified, the default of the cluster is used.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=256
+	// +optional
+	MaxPodsPerNode *int64 `json:"maxPodsPerNode,omitempty"`
+	// NodeLocations are the zones in which the nodes of the node pool are created. They must be in the region
+	// of the cluster, and the replicas of the MachinePool are spread evenly across them.
+	// If not specified, the locations of the cluster are used.
+	// +optional
+	NodeLocations []string `json:"nodeLocations,omitempty"`
+	// Adoption allows an existing GKE node pool that wasn't created by the provider to be managed.
+	// If not specified, an existing GKE node pool with the same name is not reconciled.
+	// +optional
+	Adoption *AdoptionPolicy `json:"adoption,omitempty"`
 	// ProviderIDList are the provider IDs of instances in the
 	// managed instance group corresponding to the nodegroup represented by this
 	// machine pool
@@ -54,16 +92,95 @@ type GCPManagedMachinePoolSpec struct {
 	ProviderIDList []string `json:"providerIDList,omitempty"`
 }
 
+// NodePoolNetworkConfig defines the network configuration of the nodes of a node pool.
+type NodePoolNetworkConfig struct {
+	// PodRange is the name of the secondary range of the subnet used for the pod IPs of the node pool.
+	// It can't be changed once the node pool is created.
+	// +optional
+	PodRange string `json:"podRange,omitempty"`
+	// CreatePodRange indicates whether the secondary range named PodRange is created with the node pool.
+	// +optional
+	CreatePodRange bool `json:"createPodRange,omitempty"`
+	// PodIPv4CIDRBlock is the IP address range of the secondary range created for the pod IPs, either a
+	// netmask size like /14 or a CIDR block. It can only be set when CreatePodRange is true.
+	// +optional
+	PodIPv4CIDRBlock string `json:"podIPv4CIDRBlock,omitempty"`
+	// EnablePrivateNodes indicates whether the nodes only have internal IP addresses.
+	// If not specified, the setting of the cluster is used.
+	// +optional
+	EnablePrivateNodes *bool `json:"enablePrivateNodes,omitempty"`
+}
+
 // GCPManagedMachinePoolStatus defines the observed state of GCPManagedMachinePool.
 type GCPManagedMachinePoolStatus struct {
 	Ready bool `json:"ready"`
 	// Replicas is the most recently observed number of replicas.
 	// +optional
 	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of instances of the node pool that are running.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// ZoneReplicas are the numbers of instances of the node pool in each of its zones.
+	// +optional
+	ZoneReplicas []ZoneReplicaStatus `json:"zoneReplicas,omitempty"`
+	// CurrentVersion is the Kubernetes version of the nodes of the node pool.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// InstanceGroupURLs are the URLs of the managed instance groups of the node pool.
+	// +optional
+	InstanceGroupURLs []string `json:"instanceGroupURLs,omitempty"`
+	// StatusMessage is the message GKE reports for the current status of the node pool.
+	// +optional
+	StatusMessage string `json:"statusMessage,omitempty"`
+	// NodePoolConditions are the conditions GKE reports for the node pool.
+	// +optional
+	NodePoolConditions []NodePoolStatusCondition `json:"nodePoolConditions,omitempty"`
+	// FailureReason will be set in the event that there is a terminal problem with the node pool
+	// and will contain a succinct value suitable for machine interpretation.
+	// +optional
+	FailureReason *errors.MachinePoolStatusFailure `json:"failureReason,omitempty"`
+	// FailureMessage will be set in the event that there is a terminal problem with the node pool
+	// and will contain a more verbose string suitable for logging and human consumption.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+	// PendingOperation is the GKE operation started on the node pool that hasn't completed yet.
+	// No other operation is started on the node pool until it completes.
+	// +optional
+	PendingOperation *GKEOperation `json:"pendingOperation,omitempty"`
+	// PendingOperations are the GCE operations started by the provider on the instances of the node pool
+	// that are still in progress. No other operation is started on their resources until they complete.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	PendingOperations []infrav1.GCEOperation `json:"pendingOperations,omitempty"`
+	// InfrastructureMachineKind is the kind of the infrastructure machines created for the instances of the
+	// node pool, which Cluster API uses to create a Machine for each of them.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
 	// Conditions specifies the cpnditions for the managed machine pool
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
+// ZoneReplicaStatus is the number of instances of a node pool in a zone.
+type ZoneReplicaStatus struct {
+	// Zone is the zone of the instances.
+	Zone string `json:"zone"`
+	// Replicas is the number of instances in the zone.
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of running instances in the zone.
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
+
+// NodePoolStatusCondition is a condition GKE reports for a node pool.
+type NodePoolStatusCondition struct {
+	// Code is the canonical code of the condition, e.g. RESOURCE_EXHAUSTED.
+	// +optional
+	Code string `json:"code,omitempty"`
+	// Message describes the condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
 // +kubebuilder:resource:path=gcpmanagedmachinepools,scope=Namespaced,categories=cluster-api,shortName=gcpmmp
@@ -92,6 +209,146 @@ type GCPManagedMachinePoolList struct {
 type NodePoolAutoScaling struct {
 	MinCount *int32 `json:"minCount,omitempty"`
 	MaxCount *int32 `json:"maxCount,omitempty"`
+	// Autoscaler selects the autoscaler that changes the size of the node pool between MinCount and MaxCount.
+	// GKE enables the autoscaler of GKE for the node pool, and the observed size of the node pool is written back
+	// to the replicas of the MachinePool. ClusterAutoscaler leaves the scaling to the Kubernetes cluster-autoscaler,
+	// which changes the replicas of the MachinePool, and reports MinCount and MaxCount to it in annotations of the
+	// MachinePool. Defaults to GKE.
+	// +optional
+	Autoscaler NodePoolAutoscaler `json:"autoscaler,omitempty"`
+}
+
+// NodePoolAutoscaler is the autoscaler that changes the size of a node pool.
+// +kubebuilder:validation:Enum=GKE;ClusterAutoscaler
+type NodePoolAutoscaler string
+
+const (
+	// GKEAutoscaler is the autoscaler of GKE.
+	GKEAutoscaler NodePoolAutoscaler = "GKE"
+	// KubernetesClusterAutoscaler is the Kubernetes cluster-autoscaler with its Cluster API provider.
+	KubernetesClusterAutoscaler NodePoolAutoscaler = "ClusterAutoscaler"
+)
+
+// LinuxNodeConfig specifies the Linux node configuration of the nodes of a node pool.
+type LinuxNodeConfig struct {
+	// Sysctls specifies the Linux kernel parameters to apply to the nodes.
+	// +optional
+	Sysctls []SysctlConfig `json:"sysctls,omitempty"`
+	// CgroupMode specifies the cgroup mode of the nodes.
+	// +optional
+	CgroupMode *CgroupMode `json:"cgroupMode,omitempty"`
+}
+
+// SysctlConfig is a Linux kernel parameter.
+type SysctlConfig struct {
+	// Parameter is the name of the kernel parameter, e.g. net.core.somaxconn.
+	Parameter string `json:"parameter"`
+	// Value is the value of the kernel parameter.
+	Value string `json:"value"`
+}
+
+// CgroupMode is the cgroup mode of the nodes of a node pool.
+// +kubebuilder:validation:Enum=v1;v2
+type CgroupMode string
+
+const (
+	// CgroupModeV1 uses cgroupv1 on the nodes.
+	CgroupModeV1 CgroupMode = "v1"
+	// CgroupModeV2 uses cgroupv2 on the nodes.
+	CgroupModeV2 CgroupMode = "v2"
+)
+
+// KubeletConfig specifies the kubelet configuration of the nodes of a node pool.
+type KubeletConfig struct {
+	// CPUManagerPolicy is the CPU management policy of the kubelet.
+	// +kubebuilder:validation:Enum=none;static
+	// +optional
+	CPUManagerPolicy *string `json:"cpuManagerPolicy,omitempty"`
+	// CPUCFSQuota indicates whether CPU CFS quota enforcement is enabled for containers that specify CPU limits.
+	// +optional
+	CPUCFSQuota *bool `json:"cpuCFSQuota,omitempty"`
+	// CPUCFSQuotaPeriod is the CPU CFS quota period value, e.g. "100ms".
+	// +optional
+	CPUCFSQuotaPeriod *string `json:"cpuCFSQuotaPeriod,omitempty"`
+	// PodPidsLimit is the maximum number of PIDs in any pod.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=4194304
+	// +optional
+	PodPidsLimit *int64 `json:"podPidsLimit,omitempty"`
+}
+
+// NodePoolManagement specifies the node management options of the node pool.
+type NodePoolManagement struct {
+	// AutoRepair indicates whether the nodes of the node pool are automatically repaired. Defaults to true.
+	// +optional
+	AutoRepair *bool `json:"autoRepair,omitempty"`
+	// AutoUpgrade indicates whether the nodes of the node pool are automatically upgraded. Defaults to true.
+	// +optional
+	AutoUpgrade *bool `json:"autoUpgrade,omitempty"`
+}
+
+// NodePoolUpgradeStrategy is the strategy used to upgrade the nodes of a node pool.
+// +kubebuilder:validation:Enum=Surge;BlueGreen
+type NodePoolUpgradeStrategy string
+
+const (
+	// SurgeUpgradeStrategy upgrades the nodes in a rolling fashion, creating surge nodes as needed.
+	SurgeUpgradeStrategy NodePoolUpgradeStrategy = "Surge"
+	// BlueGreenUpgradeStrategy upgrades the nodes by creating a new set of nodes and draining the old ones in batches.
+	BlueGreenUpgradeStrategy NodePoolUpgradeStrategy = "BlueGreen"
+)
+
+// NodePoolUpgradeSettings specifies the upgrade strategy of the node pool.
+type NodePoolUpgradeSettings struct {
+	// Strategy is the upgrade strategy of the node pool. Defaults to Surge.
+	// +optional
+	Strategy *NodePoolUpgradeStrategy `json:"strategy,omitempty"`
+	// MaxSurge is the maximum number of nodes that can be created beyond the current size of the
+	// node pool during a surge upgrade. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of nodes that can be simultaneously unavailable during
+	// a surge upgrade. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+	// BlueGreenSettings specifies the settings of a blue-green upgrade.
+	// +optional
+	BlueGreenSettings *BlueGreenSettings `json:"blueGreenSettings,omitempty"`
+}
+
+// BlueGreenSettings specifies the settings of a blue-green upgrade.
+type BlueGreenSettings struct {
+	// StandardRolloutPolicy specifies how the old (blue) nodes are drained.
+	// +optional
+	StandardRolloutPolicy *StandardRolloutPolicy `json:"standardRolloutPolicy,omitempty"`
+	// NodePoolSoakDuration is the time to wait after all the blue nodes are drained before deleting them.
+	// +optional
+	NodePoolSoakDuration *metav1.Duration `json:"nodePoolSoakDuration,omitempty"`
+}
+
+// StandardRolloutPolicy drains the old (blue) nodes in batches.
+type StandardRolloutPolicy struct {
+	// BatchPercentage is the percentage of the blue nodes to drain in a batch.
+	// Cannot be used together with BatchNodeCount.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	BatchPercentage *int32 `json:"batchPercentage,omitempty"`
+	// BatchNodeCount is the number of blue nodes to drain in a batch.
+	// Cannot be used together with BatchPercentage.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchNodeCount *int32 `json:"batchNodeCount,omitempty"`
+	// BatchSoakDuration is the time to wait after draining each batch.
+	// +optional
+	BatchSoakDuration *metav1.Duration `json:"batchSoakDuration,omitempty"`
+}
+
+// GKEAutoscalingEnabled returns true if the autoscaler of GKE changes the size of the node pool.
+func (r *GCPManagedMachinePool) GKEAutoscalingEnabled() bool {
+	return r.Spec.Scaling != nil && r.Spec.Scaling.Autoscaler != KubernetesClusterAutoscaler
 }
 
 // GetConditions returns the machine pool conditions.
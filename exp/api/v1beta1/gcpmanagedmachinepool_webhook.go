@@ -70,6 +70,49 @@ func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 				allErrs = append(allErrs, field.Invalid(maxField, *max, fmt.Sprintf("must be greater than field %s", minField.String())))
 			}
 		}
+		if r.Spec.Scaling.Autoscaler == KubernetesClusterAutoscaler {
+			if min == nil {
+				allErrs = append(allErrs, field.Required(minField, "must be set for the cluster-autoscaler"))
+			}
+			if max == nil {
+				allErrs = append(allErrs, field.Required(maxField, "must be set for the cluster-autoscaler"))
+			}
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validateUpgradeSettings() field.ErrorList {
+	var allErrs field.ErrorList
+	settings := r.Spec.UpgradeSettings
+	if settings == nil {
+		return nil
+	}
+	fldPath := field.NewPath("spec", "upgradeSettings")
+	isBlueGreen := settings.Strategy != nil && *settings.Strategy == BlueGreenUpgradeStrategy
+	if isBlueGreen {
+		if settings.MaxSurge != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxSurge"), "cannot be set with the BlueGreen strategy"))
+		}
+		if settings.MaxUnavailable != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxUnavailable"), "cannot be set with the BlueGreen strategy"))
+		}
+	} else {
+		if settings.BlueGreenSettings != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("blueGreenSettings"), "can only be set with the BlueGreen strategy"))
+		}
+		if settings.MaxSurge != nil && settings.MaxUnavailable != nil && *settings.MaxSurge == 0 && *settings.MaxUnavailable == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnavailable"), *settings.MaxUnavailable, "maxSurge and maxUnavailable cannot both be zero"))
+		}
+	}
+	if settings.BlueGreenSettings != nil && settings.BlueGreenSettings.StandardRolloutPolicy != nil {
+		policy := settings.BlueGreenSettings.StandardRolloutPolicy
+		if policy.BatchPercentage != nil && policy.BatchNodeCount != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("blueGreenSettings", "standardRolloutPolicy", "batchNodeCount"), "cannot be set together with batchPercentage"))
+		}
 	}
 	if len(allErrs) == 0 {
 		return nil
@@ -93,6 +136,9 @@ func (r *GCPManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, r.validateUpgradeSettings()...)
+	allErrs = append(allErrs, r.validateNetworkConfig()...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -113,10 +159,27 @@ func (r *GCPManagedMachinePool) ValidateUpdate(oldRaw runtime.Object) (admission
 		)
 	}
 
+	if !cmp.Equal(r.Spec.MaxPodsPerNode, old.Spec.MaxPodsPerNode) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "maxPodsPerNode"),
+				r.Spec.MaxPodsPerNode, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(podRange(r.Spec.NetworkConfig), podRange(old.Spec.NetworkConfig)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "networkConfig"),
+				r.Spec.NetworkConfig, "pod range is immutable"),
+		)
+	}
+
 	if errs := r.validateScaling(); errs != nil || len(errs) == 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, r.validateUpgradeSettings()...)
+	allErrs = append(allErrs, r.validateNetworkConfig()...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -130,3 +193,39 @@ func (r *GCPManagedMachinePool) ValidateDelete() (admission.Warnings, error) {
 
 	return nil, nil
 }
+
+func (r *GCPManagedMachinePool) validateNetworkConfig() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec")
+
+	if config := r.Spec.NetworkConfig; config != nil {
+		if config.CreatePodRange && config.PodRange == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("networkConfig", "podRange"), "podRange is required when createPodRange is true"))
+		}
+		if !config.CreatePodRange && config.PodIPv4CIDRBlock != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkConfig", "podIPv4CIDRBlock"), "can only be set when createPodRange is true"))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, zone := range r.Spec.NodeLocations {
+		if seen[zone] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("nodeLocations").Index(i), zone))
+		}
+		seen[zone] = true
+	}
+
+	return allErrs
+}
+
+// podRange returns the settings of the pod range of the network config, which can't be changed once the node pool is created.
+func podRange(config *NodePoolNetworkConfig) NodePoolNetworkConfig {
+	if config == nil {
+		return NodePoolNetworkConfig{}
+	}
+	return NodePoolNetworkConfig{
+		PodRange:         config.PodRange,
+		CreatePodRange:   config.CreatePodRange,
+		PodIPv4CIDRBlock: config.PodIPv4CIDRBlock,
+	}
+}
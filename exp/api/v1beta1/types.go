@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1beta1
 
-import "cloud.google.com/go/container/apiv1/containerpb"
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // TaintEffect is the effect for a Kubernetes taint.
 type TaintEffect string
@@ -48,6 +51,17 @@ func convertToSdkTaintEffect(effect TaintEffect) containerpb.NodeTaint_Effect {
 	}
 }
 
+func convertFromSdkTaintEffect(effect containerpb.NodeTaint_Effect) TaintEffect {
+	switch effect {
+	case containerpb.NodeTaint_NO_EXECUTE:
+		return "NoExecute"
+	case containerpb.NodeTaint_PREFER_NO_SCHEDULE:
+		return "PreferNoSchedule"
+	default:
+		return "NoSchedule"
+	}
+}
+
 // ConvertToSdkTaint converts taints to format that is used by GCP SDK.
 func ConvertToSdkTaint(taints Taints) []*containerpb.NodeTaint {
 	if taints == nil {
@@ -63,3 +77,43 @@ func ConvertToSdkTaint(taints Taints) []*containerpb.NodeTaint {
 	}
 	return res
 }
+
+// ConvertFromSdkTaints converts taints from the format that is used by GCP SDK.
+func ConvertFromSdkTaints(taints []*containerpb.NodeTaint) Taints {
+	if len(taints) == 0 {
+		return nil
+	}
+	res := Taints{}
+	for _, taint := range taints {
+		res = append(res, Taint{
+			Key:    taint.GetKey(),
+			Value:  taint.GetValue(),
+			Effect: convertFromSdkTaintEffect(taint.GetEffect()),
+		})
+	}
+	return res
+}
+
+// AdoptionPolicy configures the adoption of an existing GKE resource that wasn't created by the provider.
+type AdoptionPolicy struct {
+	// Enabled allows the provider to take over the management of an existing GKE resource with the
+	// same name. Spec fields that aren't set are filled from the live resource before it's adopted.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// AcknowledgedDiffs lists the spec fields that differ from the live resource and that the provider
+	// may apply once the resource is adopted. Adoption is blocked until every difference is acknowledged.
+	// +optional
+	AcknowledgedDiffs []string `json:"acknowledgedDiffs,omitempty"`
+}
+
+// GKEOperation is a long-running GKE operation.
+type GKEOperation struct {
+	// Name is the full name of the operation, e.g. projects/my-project/locations/us-central1/operations/operation-123.
+	Name string `json:"name"`
+	// Type is the type of the operation, e.g. UPDATE_CLUSTER.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// StartTime is the time at which the operation was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
@@ -22,243 +22,286 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apiv1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	cluster_apiapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedCluster) DeepCopyInto(out *GCPManagedCluster) {
+func (in *AddonsConfig) DeepCopyInto(out *AddonsConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.HTTPLoadBalancing != nil {
+		in, out := &in.HTTPLoadBalancing, &out.HTTPLoadBalancing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HorizontalPodAutoscaling != nil {
+		in, out := &in.HorizontalPodAutoscaling, &out.HorizontalPodAutoscaling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcePersistentDiskCsiDriver != nil {
+		in, out := &in.GcePersistentDiskCsiDriver, &out.GcePersistentDiskCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcpFilestoreCsiDriver != nil {
+		in, out := &in.GcpFilestoreCsiDriver, &out.GcpFilestoreCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcsFuseCsiDriver != nil {
+		in, out := &in.GcsFuseCsiDriver, &out.GcsFuseCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSCache != nil {
+		in, out := &in.DNSCache, &out.DNSCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigConnector != nil {
+		in, out := &in.ConfigConnector, &out.ConfigConnector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GatewayAPIChannel != nil {
+		in, out := &in.GatewayAPIChannel, &out.GatewayAPIChannel
+		*out = new(GatewayAPIChannel)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedCluster.
-func (in *GCPManagedCluster) DeepCopy() *GCPManagedCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsConfig.
+func (in *AddonsConfig) DeepCopy() *AddonsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedCluster)
+	out := new(AddonsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedClusterList) DeepCopyInto(out *GCPManagedClusterList) {
+func (in *AdoptionPolicy) DeepCopyInto(out *AdoptionPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPManagedCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AcknowledgedDiffs != nil {
+		in, out := &in.AcknowledgedDiffs, &out.AcknowledgedDiffs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterList.
-func (in *GCPManagedClusterList) DeepCopy() *GCPManagedClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionPolicy.
+func (in *AdoptionPolicy) DeepCopy() *AdoptionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedClusterList)
+	out := new(AdoptionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticatorGroupsConfig) DeepCopyInto(out *AuthenticatorGroupsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticatorGroupsConfig.
+func (in *AuthenticatorGroupsConfig) DeepCopy() *AuthenticatorGroupsConfig {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(AuthenticatorGroupsConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedClusterSpec) DeepCopyInto(out *GCPManagedClusterSpec) {
+func (in *AutoprovisioningNodePoolDefaults) DeepCopyInto(out *AutoprovisioningNodePoolDefaults) {
 	*out = *in
-	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
-	in.Network.DeepCopyInto(&out.Network)
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(apiv1beta1.Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(string)
+		**out = **in
 	}
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(apiv1beta1.ObjectReference)
+	if in.OauthScopes != nil {
+		in, out := &in.OauthScopes, &out.OauthScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootDiskKMSKey != nil {
+		in, out := &in.BootDiskKMSKey, &out.BootDiskKMSKey
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterSpec.
-func (in *GCPManagedClusterSpec) DeepCopy() *GCPManagedClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoprovisioningNodePoolDefaults.
+func (in *AutoprovisioningNodePoolDefaults) DeepCopy() *AutoprovisioningNodePoolDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedClusterSpec)
+	out := new(AutoprovisioningNodePoolDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedClusterStatus) DeepCopyInto(out *GCPManagedClusterStatus) {
+func (in *BlueGreenSettings) DeepCopyInto(out *BlueGreenSettings) {
 	*out = *in
-	if in.FailureDomains != nil {
-		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make(cluster_apiapiv1beta1.FailureDomains, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.StandardRolloutPolicy != nil {
+		in, out := &in.StandardRolloutPolicy, &out.StandardRolloutPolicy
+		*out = new(StandardRolloutPolicy)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Network.DeepCopyInto(&out.Network)
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.NodePoolSoakDuration != nil {
+		in, out := &in.NodePoolSoakDuration, &out.NodePoolSoakDuration
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterStatus.
-func (in *GCPManagedClusterStatus) DeepCopy() *GCPManagedClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenSettings.
+func (in *BlueGreenSettings) DeepCopy() *BlueGreenSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedClusterStatus)
+	out := new(BlueGreenSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlane) DeepCopyInto(out *GCPManagedControlPlane) {
+func (in *ClusterAutoscaling) DeepCopyInto(out *ClusterAutoscaling) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = make([]ResourceLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoscalingProfile != nil {
+		in, out := &in.AutoscalingProfile, &out.AutoscalingProfile
+		*out = new(AutoscalingProfile)
+		**out = **in
+	}
+	if in.AutoprovisioningNodePoolDefaults != nil {
+		in, out := &in.AutoprovisioningNodePoolDefaults, &out.AutoprovisioningNodePoolDefaults
+		*out = new(AutoprovisioningNodePoolDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlane.
-func (in *GCPManagedControlPlane) DeepCopy() *GCPManagedControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaling.
+func (in *ClusterAutoscaling) DeepCopy() *ClusterAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlane)
+	out := new(ClusterAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneList) DeepCopyInto(out *GCPManagedControlPlaneList) {
+func (in *ClusterDNS) DeepCopyInto(out *ClusterDNS) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPManagedControlPlane, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(ClusterDNSScope)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneList.
-func (in *GCPManagedControlPlaneList) DeepCopy() *GCPManagedControlPlaneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNS.
+func (in *ClusterDNS) DeepCopy() *ClusterDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneList)
+	out := new(ClusterDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSpec) {
+func (in *ClusterNetworking) DeepCopyInto(out *ClusterNetworking) {
 	*out = *in
-	if in.ReleaseChannel != nil {
-		in, out := &in.ReleaseChannel, &out.ReleaseChannel
-		*out = new(ReleaseChannel)
+	if in.DatapathProvider != nil {
+		in, out := &in.DatapathProvider, &out.DatapathProvider
+		*out = new(DatapathProvider)
 		**out = **in
 	}
-	if in.ControlPlaneVersion != nil {
-		in, out := &in.ControlPlaneVersion, &out.ControlPlaneVersion
-		*out = new(string)
+	if in.EnableNetworkPolicy != nil {
+		in, out := &in.EnableNetworkPolicy, &out.EnableNetworkPolicy
+		*out = new(bool)
 		**out = **in
 	}
-	out.Endpoint = in.Endpoint
-	if in.MasterAuthorizedNetworksConfig != nil {
-		in, out := &in.MasterAuthorizedNetworksConfig, &out.MasterAuthorizedNetworksConfig
-		*out = new(MasterAuthorizedNetworksConfig)
+	if in.EnableIntraNodeVisibility != nil {
+		in, out := &in.EnableIntraNodeVisibility, &out.EnableIntraNodeVisibility
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableDefaultSNAT != nil {
+		in, out := &in.DisableDefaultSNAT, &out.DisableDefaultSNAT
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(ClusterDNS)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
-func (in *GCPManagedControlPlaneSpec) DeepCopy() *GCPManagedControlPlaneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworking.
+func (in *ClusterNetworking) DeepCopy() *ClusterNetworking {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneSpec)
+	out := new(ClusterNetworking)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlaneStatus) {
+func (in *DailyMaintenanceWindow) DeepCopyInto(out *DailyMaintenanceWindow) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DailyMaintenanceWindow.
+func (in *DailyMaintenanceWindow) DeepCopy() *DailyMaintenanceWindow {
+	if in == nil {
+		return nil
 	}
+	out := new(DailyMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
-func (in *GCPManagedControlPlaneStatus) DeepCopy() *GCPManagedControlPlaneStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseEncryption) DeepCopyInto(out *DatabaseEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseEncryption.
+func (in *DatabaseEncryption) DeepCopy() *DatabaseEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneStatus)
+	out := new(DatabaseEncryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
+func (in *GCPMachinePool) DeepCopyInto(out *GCPMachinePool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -266,18 +309,18 @@ func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePool.
-func (in *GCPManagedMachinePool) DeepCopy() *GCPManagedMachinePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePool.
+func (in *GCPMachinePool) DeepCopy() *GCPMachinePool {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePool)
+	out := new(GCPMachinePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
+func (in *GCPMachinePool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -285,31 +328,31 @@ func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePoolList) DeepCopyInto(out *GCPManagedMachinePoolList) {
+func (in *GCPMachinePoolList) DeepCopyInto(out *GCPMachinePoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPManagedMachinePool, len(*in))
+		*out = make([]GCPMachinePool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolList.
-func (in *GCPManagedMachinePoolList) DeepCopy() *GCPManagedMachinePoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolList.
+func (in *GCPMachinePoolList) DeepCopy() *GCPMachinePoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePoolList)
+	out := new(GCPMachinePoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
+func (in *GCPMachinePoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -317,32 +360,14 @@ func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec) {
+func (in *GCPMachinePoolSpec) DeepCopyInto(out *GCPMachinePoolSpec) {
 	*out = *in
-	if in.Scaling != nil {
-		in, out := &in.Scaling, &out.Scaling
-		*out = new(NodePoolAutoScaling)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(MachinePoolUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.KubernetesLabels != nil {
-		in, out := &in.KubernetesLabels, &out.KubernetesLabels
-		*out = make(apiv1beta1.Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.KubernetesTaints != nil {
-		in, out := &in.KubernetesTaints, &out.KubernetesTaints
-		*out = make(Taints, len(*in))
-		copy(*out, *in)
-	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(apiv1beta1.Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 	if in.ProviderIDList != nil {
 		in, out := &in.ProviderIDList, &out.ProviderIDList
 		*out = make([]string, len(*in))
@@ -350,19 +375,36 @@ func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolSpec.
-func (in *GCPManagedMachinePoolSpec) DeepCopy() *GCPManagedMachinePoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolSpec.
+func (in *GCPMachinePoolSpec) DeepCopy() *GCPMachinePoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePoolSpec)
+	out := new(GCPMachinePoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePoolStatus) DeepCopyInto(out *GCPManagedMachinePoolStatus) {
+func (in *GCPMachinePoolStatus) DeepCopyInto(out *GCPMachinePoolStatus) {
 	*out = *in
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachinePoolStatusFailure)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]apiv1beta1.GCEOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
@@ -372,30 +414,860 @@ func (in *GCPManagedMachinePoolStatus) DeepCopyInto(out *GCPManagedMachinePoolSt
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolStatus.
-func (in *GCPManagedMachinePoolStatus) DeepCopy() *GCPManagedMachinePoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolStatus.
+func (in *GCPMachinePoolStatus) DeepCopy() *GCPMachinePoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePoolStatus)
+	out := new(GCPMachinePoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MasterAuthorizedNetworksConfig) DeepCopyInto(out *MasterAuthorizedNetworksConfig) {
+func (in *GCPManagedCluster) DeepCopyInto(out *GCPManagedCluster) {
 	*out = *in
-	if in.CidrBlocks != nil {
-		in, out := &in.CidrBlocks, &out.CidrBlocks
-		*out = make([]*MasterAuthorizedNetworksConfigCidrBlock, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(MasterAuthorizedNetworksConfigCidrBlock)
-				**out = **in
-			}
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedCluster.
+func (in *GCPManagedCluster) DeepCopy() *GCPManagedCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedClusterList) DeepCopyInto(out *GCPManagedClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterList.
+func (in *GCPManagedClusterList) DeepCopy() *GCPManagedClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedClusterSpec) DeepCopyInto(out *GCPManagedClusterSpec) {
+	*out = *in
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	in.Network.DeepCopyInto(&out.Network)
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(apiv1beta1.Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(apiv1beta1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterSpec.
+func (in *GCPManagedClusterSpec) DeepCopy() *GCPManagedClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedClusterStatus) DeepCopyInto(out *GCPManagedClusterStatus) {
+	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make(cluster_apiapiv1beta1.FailureDomains, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.Network.DeepCopyInto(&out.Network)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]apiv1beta1.GCEOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterStatus.
+func (in *GCPManagedClusterStatus) DeepCopy() *GCPManagedClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlane) DeepCopyInto(out *GCPManagedControlPlane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlane.
+func (in *GCPManagedControlPlane) DeepCopy() *GCPManagedControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneList) DeepCopyInto(out *GCPManagedControlPlaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneList.
+func (in *GCPManagedControlPlaneList) DeepCopy() *GCPManagedControlPlaneList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSpec) {
+	*out = *in
+	if in.ReleaseChannel != nil {
+		in, out := &in.ReleaseChannel, &out.ReleaseChannel
+		*out = new(ReleaseChannel)
+		**out = **in
+	}
+	if in.ControlPlaneVersion != nil {
+		in, out := &in.ControlPlaneVersion, &out.ControlPlaneVersion
+		*out = new(string)
+		**out = **in
+	}
+	out.Endpoint = in.Endpoint
+	if in.MasterAuthorizedNetworksConfig != nil {
+		in, out := &in.MasterAuthorizedNetworksConfig, &out.MasterAuthorizedNetworksConfig
+		*out = new(MasterAuthorizedNetworksConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(AddonsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenancePolicy != nil {
+		in, out := &in.MaintenancePolicy, &out.MaintenancePolicy
+		*out = new(MaintenancePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(ClusterNetworking)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoggingConfig != nil {
+		in, out := &in.LoggingConfig, &out.LoggingConfig
+		*out = new(LoggingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitoringConfig != nil {
+		in, out := &in.MonitoringConfig, &out.MonitoringConfig
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAutoscaling != nil {
+		in, out := &in.ClusterAutoscaling, &out.ClusterAutoscaling
+		*out = new(ClusterAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableShieldedNodes != nil {
+		in, out := &in.EnableShieldedNodes, &out.EnableShieldedNodes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BinaryAuthorizationEvaluationMode != nil {
+		in, out := &in.BinaryAuthorizationEvaluationMode, &out.BinaryAuthorizationEvaluationMode
+		*out = new(BinaryAuthorizationEvaluationMode)
+		**out = **in
+	}
+	if in.DatabaseEncryption != nil {
+		in, out := &in.DatabaseEncryption, &out.DatabaseEncryption
+		*out = new(DatabaseEncryption)
+		**out = **in
+	}
+	if in.AuthenticatorGroupsConfig != nil {
+		in, out := &in.AuthenticatorGroupsConfig, &out.AuthenticatorGroupsConfig
+		*out = new(AuthenticatorGroupsConfig)
+		**out = **in
+	}
+	if in.UserKubeconfig != nil {
+		in, out := &in.UserKubeconfig, &out.UserKubeconfig
+		*out = new(UserKubeconfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeconfigServiceAccount != nil {
+		in, out := &in.KubeconfigServiceAccount, &out.KubeconfigServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
+func (in *GCPManagedControlPlaneSpec) DeepCopy() *GCPManagedControlPlaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlaneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = (*in).DeepCopy()
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingOperation != nil {
+		in, out := &in.PendingOperation, &out.PendingOperation
+		*out = new(GKEOperation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
+func (in *GCPManagedControlPlaneStatus) DeepCopy() *GCPManagedControlPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePool.
+func (in *GCPManagedMachinePool) DeepCopy() *GCPManagedMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolList) DeepCopyInto(out *GCPManagedMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolList.
+func (in *GCPManagedMachinePoolList) DeepCopy() *GCPManagedMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachine) DeepCopyInto(out *GCPManagedMachinePoolMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachine.
+func (in *GCPManagedMachinePoolMachine) DeepCopy() *GCPManagedMachinePoolMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineList) DeepCopyInto(out *GCPManagedMachinePoolMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedMachinePoolMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineList.
+func (in *GCPManagedMachinePoolMachineList) DeepCopy() *GCPManagedMachinePoolMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineSpec) DeepCopyInto(out *GCPManagedMachinePoolMachineSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineSpec.
+func (in *GCPManagedMachinePoolMachineSpec) DeepCopy() *GCPManagedMachinePoolMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineStatus) DeepCopyInto(out *GCPManagedMachinePoolMachineStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineStatus.
+func (in *GCPManagedMachinePoolMachineStatus) DeepCopy() *GCPManagedMachinePoolMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec) {
+	*out = *in
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(NodePoolAutoScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesLabels != nil {
+		in, out := &in.KubernetesLabels, &out.KubernetesLabels
+		*out = make(apiv1beta1.Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubernetesTaints != nil {
+		in, out := &in.KubernetesTaints, &out.KubernetesTaints
+		*out = make(Taints, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkTags != nil {
+		in, out := &in.NetworkTags, &out.NetworkTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinuxNodeConfig != nil {
+		in, out := &in.LinuxNodeConfig, &out.LinuxNodeConfig
+		*out = new(LinuxNodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(KubeletConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(apiv1beta1.Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Management != nil {
+		in, out := &in.Management, &out.Management
+		*out = new(NodePoolManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(NodePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkConfig != nil {
+		in, out := &in.NetworkConfig, &out.NetworkConfig
+		*out = new(NodePoolNetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPodsPerNode != nil {
+		in, out := &in.MaxPodsPerNode, &out.MaxPodsPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeLocations != nil {
+		in, out := &in.NodeLocations, &out.NodeLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderIDList != nil {
+		in, out := &in.ProviderIDList, &out.ProviderIDList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolSpec.
+func (in *GCPManagedMachinePoolSpec) DeepCopy() *GCPManagedMachinePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolStatus) DeepCopyInto(out *GCPManagedMachinePoolStatus) {
+	*out = *in
+	if in.ZoneReplicas != nil {
+		in, out := &in.ZoneReplicas, &out.ZoneReplicas
+		*out = make([]ZoneReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceGroupURLs != nil {
+		in, out := &in.InstanceGroupURLs, &out.InstanceGroupURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePoolConditions != nil {
+		in, out := &in.NodePoolConditions, &out.NodePoolConditions
+		*out = make([]NodePoolStatusCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachinePoolStatusFailure)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.PendingOperation != nil {
+		in, out := &in.PendingOperation, &out.PendingOperation
+		*out = new(GKEOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]apiv1beta1.GCEOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolStatus.
+func (in *GCPManagedMachinePoolStatus) DeepCopy() *GCPManagedMachinePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEOperation) DeepCopyInto(out *GKEOperation) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEOperation.
+func (in *GKEOperation) DeepCopy() *GKEOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigExecConfig) DeepCopyInto(out *KubeconfigExecConfig) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]KubeconfigExecEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigExecConfig.
+func (in *KubeconfigExecConfig) DeepCopy() *KubeconfigExecConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigExecConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigExecEnvVar) DeepCopyInto(out *KubeconfigExecEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigExecEnvVar.
+func (in *KubeconfigExecEnvVar) DeepCopy() *KubeconfigExecEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigExecEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+	*out = *in
+	if in.CPUManagerPolicy != nil {
+		in, out := &in.CPUManagerPolicy, &out.CPUManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPUCFSQuota != nil {
+		in, out := &in.CPUCFSQuota, &out.CPUCFSQuota
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CPUCFSQuotaPeriod != nil {
+		in, out := &in.CPUCFSQuotaPeriod, &out.CPUCFSQuotaPeriod
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodPidsLimit != nil {
+		in, out := &in.PodPidsLimit, &out.PodPidsLimit
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfig.
+func (in *KubeletConfig) DeepCopy() *KubeletConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxNodeConfig) DeepCopyInto(out *LinuxNodeConfig) {
+	*out = *in
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make([]SysctlConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.CgroupMode != nil {
+		in, out := &in.CgroupMode, &out.CgroupMode
+		*out = new(CgroupMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxNodeConfig.
+func (in *LinuxNodeConfig) DeepCopy() *LinuxNodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxNodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingConfig) DeepCopyInto(out *LoggingConfig) {
+	*out = *in
+	if in.EnableComponents != nil {
+		in, out := &in.EnableComponents, &out.EnableComponents
+		*out = make([]LoggingComponent, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingConfig.
+func (in *LoggingConfig) DeepCopy() *LoggingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolUpdateStrategy) DeepCopyInto(out *MachinePoolUpdateStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolUpdateStrategy.
+func (in *MachinePoolUpdateStrategy) DeepCopy() *MachinePoolUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceExclusion) DeepCopyInto(out *MaintenanceExclusion) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(MaintenanceExclusionScope)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceExclusion.
+func (in *MaintenanceExclusion) DeepCopy() *MaintenanceExclusion {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceExclusion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenancePolicy) DeepCopyInto(out *MaintenancePolicy) {
+	*out = *in
+	if in.DailyMaintenanceWindow != nil {
+		in, out := &in.DailyMaintenanceWindow, &out.DailyMaintenanceWindow
+		*out = new(DailyMaintenanceWindow)
+		**out = **in
+	}
+	if in.RecurringMaintenanceWindow != nil {
+		in, out := &in.RecurringMaintenanceWindow, &out.RecurringMaintenanceWindow
+		*out = new(RecurringMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceExclusions != nil {
+		in, out := &in.MaintenanceExclusions, &out.MaintenanceExclusions
+		*out = make([]MaintenanceExclusion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenancePolicy.
+func (in *MaintenancePolicy) DeepCopy() *MaintenancePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenancePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterAuthorizedNetworksConfig) DeepCopyInto(out *MasterAuthorizedNetworksConfig) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]*MasterAuthorizedNetworksConfigCidrBlock, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MasterAuthorizedNetworksConfigCidrBlock)
+				**out = **in
+			}
+		}
+	}
 	if in.GcpPublicCidrsAccessEnabled != nil {
 		in, out := &in.GcpPublicCidrsAccessEnabled, &out.GcpPublicCidrsAccessEnabled
 		*out = new(bool)
@@ -428,6 +1300,31 @@ func (in *MasterAuthorizedNetworksConfigCidrBlock) DeepCopy() *MasterAuthorizedN
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.EnableComponents != nil {
+		in, out := &in.EnableComponents, &out.EnableComponents
+		*out = make([]MonitoringComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedPrometheus != nil {
+		in, out := &in.ManagedPrometheus, &out.ManagedPrometheus
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolAutoScaling) DeepCopyInto(out *NodePoolAutoScaling) {
 	*out = *in
@@ -453,6 +1350,178 @@ func (in *NodePoolAutoScaling) DeepCopy() *NodePoolAutoScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolManagement) DeepCopyInto(out *NodePoolManagement) {
+	*out = *in
+	if in.AutoRepair != nil {
+		in, out := &in.AutoRepair, &out.AutoRepair
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoUpgrade != nil {
+		in, out := &in.AutoUpgrade, &out.AutoUpgrade
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolManagement.
+func (in *NodePoolManagement) DeepCopy() *NodePoolManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolNetworkConfig) DeepCopyInto(out *NodePoolNetworkConfig) {
+	*out = *in
+	if in.EnablePrivateNodes != nil {
+		in, out := &in.EnablePrivateNodes, &out.EnablePrivateNodes
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolNetworkConfig.
+func (in *NodePoolNetworkConfig) DeepCopy() *NodePoolNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolStatusCondition) DeepCopyInto(out *NodePoolStatusCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatusCondition.
+func (in *NodePoolStatusCondition) DeepCopy() *NodePoolStatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolStatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolUpgradeSettings) DeepCopyInto(out *NodePoolUpgradeSettings) {
+	*out = *in
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(NodePoolUpgradeStrategy)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BlueGreenSettings != nil {
+		in, out := &in.BlueGreenSettings, &out.BlueGreenSettings
+		*out = new(BlueGreenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolUpgradeSettings.
+func (in *NodePoolUpgradeSettings) DeepCopy() *NodePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringMaintenanceWindow) DeepCopyInto(out *RecurringMaintenanceWindow) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringMaintenanceWindow.
+func (in *RecurringMaintenanceWindow) DeepCopy() *RecurringMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceLimit) DeepCopyInto(out *ResourceLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceLimit.
+func (in *ResourceLimit) DeepCopy() *ResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StandardRolloutPolicy) DeepCopyInto(out *StandardRolloutPolicy) {
+	*out = *in
+	if in.BatchPercentage != nil {
+		in, out := &in.BatchPercentage, &out.BatchPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchNodeCount != nil {
+		in, out := &in.BatchNodeCount, &out.BatchNodeCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchSoakDuration != nil {
+		in, out := &in.BatchSoakDuration, &out.BatchSoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandardRolloutPolicy.
+func (in *StandardRolloutPolicy) DeepCopy() *StandardRolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StandardRolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysctlConfig) DeepCopyInto(out *SysctlConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysctlConfig.
+func (in *SysctlConfig) DeepCopy() *SysctlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SysctlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Taint) DeepCopyInto(out *Taint) {
 	*out = *in
@@ -486,3 +1555,66 @@ func (in Taints) DeepCopy() Taints {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingNodePools != nil {
+		in, out := &in.PendingNodePools, &out.PendingNodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserKubeconfig) DeepCopyInto(out *UserKubeconfig) {
+	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(KubeconfigExecConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserKubeconfig.
+func (in *UserKubeconfig) DeepCopy() *UserKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UserKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReplicaStatus) DeepCopyInto(out *ZoneReplicaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReplicaStatus.
+func (in *ZoneReplicaStatus) DeepCopy() *ZoneReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
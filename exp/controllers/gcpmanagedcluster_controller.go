@@ -27,7 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
@@ -135,7 +135,7 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Handle non-deleted clusters
-	return ctrl.Result{}, r.reconcile(ctx, clusterScope)
+	return r.reconcile(ctx, clusterScope)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -167,23 +167,23 @@ func (r *GCPManagedClusterReconciler) SetupWithManager(ctx context.Context, mgr
 	return nil
 }
 
-func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScope *scope.ManagedClusterScope) error {
+func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("controller", "gcpmanagedcluster")
 	log.Info("Reconciling GCPManagedCluster")
 
 	controllerutil.AddFinalizer(clusterScope.GCPManagedCluster, infrav1exp.ClusterFinalizer)
 	if err := clusterScope.PatchObject(); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	region, err := clusterScope.Cloud().Regions().Get(ctx, meta.GlobalKey(clusterScope.Region()))
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	zones, err := clusterScope.Cloud().Zones().List(ctx, filter.Regexp("region", region.SelfLink))
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	failureDomains := make(clusterv1.FailureDomains, len(zones))
@@ -194,18 +194,23 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 	}
 	clusterScope.SetFailureDomains(failureDomains)
 
-	reconcilers := map[string]cloud.Reconciler{
-		"networks": networks.New(clusterScope),
-		"subnets":  subnets.New(clusterScope),
+	services, err := newManagedClusterServices(clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for name, r := range reconcilers {
-		log.V(4).Info("Calling reconciler", "reconciler", name)
-		if err := r.Reconcile(ctx); err != nil {
-			log.Error(err, "Reconcile error", "reconciler", name)
-			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
-			return err
+	res, err := services.Reconcile(ctx)
+	if err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
 		}
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.V(4).Info("Reconciler requested requeue", "after", res.RequeueAfter)
+		return res, nil
 	}
 
 	clusterScope.SetReady()
@@ -221,7 +226,7 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 		record.Eventf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Got control-plane endpoint - %s", controlPlaneEndpoint.Host)
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
@@ -233,18 +238,23 @@ func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clust
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
-	reconcilers := map[string]cloud.Reconciler{
-		"subnets":  subnets.New(clusterScope),
-		"networks": networks.New(clusterScope),
+	services, err := newManagedClusterServices(clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for name, r := range reconcilers {
-		log.V(4).Info("Calling reconciler delete", "reconciler", name)
-		if err := r.Delete(ctx); err != nil {
-			log.Error(err, "Reconcile error", "reconciler", name)
-			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+	res, err := services.Delete(ctx)
+	if err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
+		if after := gcperrors.RetryAfter(err); after > 0 {
+			return ctrl.Result{RequeueAfter: after}, nil
 		}
+		return ctrl.Result{}, err
+	}
+	if !res.IsZero() {
+		log.V(4).Info("Reconciler requested requeue", "after", res.RequeueAfter)
+		return res, nil
 	}
 
 	controllerutil.RemoveFinalizer(clusterScope.GCPManagedCluster, infrav1exp.ClusterFinalizer)
@@ -295,3 +305,12 @@ func (r *GCPManagedClusterReconciler) managedControlPlaneMapper() handler.MapFun
 		}
 	}
 }
+
+// newManagedClusterServices returns the graph of the cluster infrastructure services. The subnets are created in
+// the network, so they are reconciled once it exists and deleted before it.
+func newManagedClusterServices(clusterScope *scope.ManagedClusterScope) (*reconciler.Graph, error) {
+	return reconciler.NewGraph(
+		reconciler.Service{Name: "networks", Reconciler: networks.New(clusterScope)},
+		reconciler.Service{Name: "subnets", Reconciler: subnets.New(clusterScope), DependsOn: []string{"networks"}},
+	)
+}
@@ -26,8 +26,10 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/clusters"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -50,6 +52,8 @@ type GCPManagedControlPlaneReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// PreflightClients are the clients of the preflight checks, reused across reconciliations.
+	PreflightClients *preflight.ClientCache
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -166,6 +170,15 @@ func (r *GCPManagedControlPlaneReconciler) reconcile(ctx context.Context, manage
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
+	requirements := preflight.ManagedControlPlaneRequirements(managedControlPlaneScope.GCPManagedControlPlane)
+	if res, err := preflight.New(managedControlPlaneScope, requirements, r.PreflightClients).Reconcile(ctx); err != nil || !res.IsZero() {
+		if err != nil {
+			log.Error(err, "Error running preflight checks")
+			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Preflight checks error - %v", err)
+		}
+		return res, err
+	}
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"container_clusters": clusters.New(managedControlPlaneScope),
 	}
@@ -175,6 +188,10 @@ func (r *GCPManagedControlPlaneReconciler) reconcile(ctx context.Context, manage
 		if err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
+			if after := gcperrors.RetryAfter(err); after > 0 {
+				log.Info("Retrying later", "reconciler", name, "reason", gcperrors.Classify(err), "after", after)
+				return ctrl.Result{RequeueAfter: after}, nil
+			}
 			return ctrl.Result{}, err
 		}
 		if res.RequeueAfter > 0 {
@@ -203,6 +220,10 @@ func (r *GCPManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		if err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
+			if after := gcperrors.RetryAfter(err); after > 0 {
+				log.Info("Retrying later", "reconciler", name, "reason", gcperrors.Classify(err), "after", after)
+				return ctrl.Result{RequeueAfter: after}, nil
+			}
 			return ctrl.Result{}, err
 		}
 		if res.RequeueAfter > 0 {
@@ -22,14 +22,14 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/codes"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/nodepools"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -58,6 +58,8 @@ type GCPManagedMachinePoolReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// PreflightClients are the clients of the preflight checks, reused across reconciliations.
+	PreflightClients *preflight.ClientCache
 }
 
 // GetOwnerClusterKey returns only the Cluster name and namespace.
@@ -166,6 +168,10 @@ func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 			&infrav1exp.GCPManagedControlPlane{},
 			handler.EnqueueRequestsFromMapFunc(managedControlPlaneToManagedMachinePoolMapFunc(r.Client, gvk, log)),
 		).
+		Watches(
+			&infrav1exp.GCPManagedMachinePoolMachine{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1exp.GCPManagedMachinePool{}),
+		).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
@@ -218,9 +224,13 @@ func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.Object
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools/finalizers,verbs=update
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepoolmachines,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepoolmachines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=patch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 
 func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -324,6 +334,15 @@ func (r *GCPManagedMachinePoolReconciler) reconcile(ctx context.Context, managed
 		return ctrl.Result{}, err
 	}
 
+	requirements := preflight.ManagedMachinePoolRequirements(managedMachinePoolScope.GCPManagedControlPlane, managedMachinePoolScope.GCPManagedMachinePool, managedMachinePoolScope.MachinePool)
+	if res, err := preflight.New(managedMachinePoolScope, requirements, r.PreflightClients).Reconcile(ctx); err != nil || !res.IsZero() {
+		if err != nil {
+			log.Error(err, "Error running preflight checks")
+			record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Preflight checks error - %v", err)
+		}
+		return res, err
+	}
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"nodepools": nodepools.New(managedMachinePoolScope),
 	}
@@ -332,15 +351,16 @@ func (r *GCPManagedMachinePoolReconciler) reconcile(ctx context.Context, managed
 		log.V(4).Info("Calling reconciler", "reconciler", name)
 		res, err := r.Reconcile(ctx)
 		if err != nil {
-			var e *apierror.APIError
-			if ok := errors.As(err, &e); ok {
-				if e.GRPCStatus().Code() == codes.FailedPrecondition {
-					log.Info("Cannot perform update when there's other operation, retry later", "reconciler", name)
-					return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
-				}
+			if gcperrors.IsFailedPrecondition(err) {
+				log.Info("Cannot perform update when there's other operation, retry later", "reconciler", name)
+				return ctrl.Result{RequeueAfter: gcperrors.RetryAfter(err)}, nil
 			}
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconcile error - %v", err)
+			if after := gcperrors.RetryAfter(err); after > 0 {
+				log.Info("Retrying later", "reconciler", name, "reason", gcperrors.Classify(err), "after", after)
+				return ctrl.Result{RequeueAfter: after}, nil
+			}
 			return ctrl.Result{}, err
 		}
 		if res.RequeueAfter > 0 {
@@ -36,11 +36,14 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha3" //nolint: staticcheck
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha4" //nolint: staticcheck
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/machinetypes"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
 	"sigs.k8s.io/cluster-api-provider-gcp/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	expcontrollers "sigs.k8s.io/cluster-api-provider-gcp/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-gcp/feature"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/stockout"
 	"sigs.k8s.io/cluster-api-provider-gcp/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -81,6 +84,7 @@ var (
 	webhookPort                 int
 	reconcileTimeout            time.Duration
 	syncPeriod                  time.Duration
+	zoneStockoutCooldown        time.Duration
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
@@ -171,17 +175,30 @@ func main() {
 }
 
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
+	preflightClients := preflight.NewClientCache(preflight.DefaultClientTTL)
+
 	if err := (&controllers.GCPMachineReconciler{
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		ExhaustedZones:   stockout.NewCache(zoneStockoutCooldown),
+		PreflightClients: preflightClients,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPMachine controller: %w", err)
 	}
+	if err := (&controllers.GCPMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+		MachineTypes:     machinetypes.NewCache(machinetypes.DefaultTTL),
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
+		return fmt.Errorf("setting up GCPMachineTemplate controller: %w", err)
+	}
 	if err := (&controllers.GCPClusterReconciler{
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		PreflightClients: preflightClients,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPCluster controller: %w", err)
 	}
@@ -201,6 +218,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
 			WatchFilterValue: watchFilterValue,
+			PreflightClients: preflightClients,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedControlPlane controller: %w", err)
 		}
@@ -209,11 +227,24 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
 			WatchFilterValue: watchFilterValue,
+			PreflightClients: preflightClients,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedMachinePool controller: %w", err)
 		}
 	}
 
+	if feature.Gates.Enabled(feature.MachinePool) {
+		setupLog.Info("Enabling MachinePool reconcilers")
+
+		if err := (&expcontrollers.GCPMachinePoolReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
+			return fmt.Errorf("setting up GCPMachinePool controller: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -342,6 +373,12 @@ func initFlags(fs *pflag.FlagSet) {
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)",
 	)
 
+	fs.DurationVar(&zoneStockoutCooldown,
+		"zone-stockout-cooldown",
+		stockout.DefaultCooldown,
+		"The period during which a zone that ran out of resources for a machine type is avoided by GCPMachines without a failure domain (e.g. 10m)",
+	)
+
 	fs.IntVar(&webhookPort,
 		"webhook-port",
 		9443,
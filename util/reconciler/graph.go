@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Service is a cloud service reconciled as part of a Graph.
+type Service struct {
+	// Name identifies the service in the graph, the logs and the errors.
+	Name string
+	// Reconciler reconciles and deletes the resources of the service.
+	Reconciler cloud.ReconcilerWithResult
+	// DependsOn lists the names of the services whose resources must exist before the service is reconciled,
+	// and which are deleted only once the service is deleted.
+	DependsOn []string
+}
+
+// Graph reconciles cloud services concurrently, following the dependencies between them.
+type Graph struct {
+	services []Service
+	// dependents maps each service to the services that depend on it.
+	dependents map[string][]string
+}
+
+// NewGraph returns a Graph of the services. It fails if a service depends on an unknown service or if the
+// dependencies form a cycle.
+func NewGraph(services ...Service) (*Graph, error) {
+	g := &Graph{
+		services:   services,
+		dependents: make(map[string][]string, len(services)),
+	}
+	for _, svc := range services {
+		if _, ok := g.dependents[svc.Name]; ok {
+			return nil, errors.Errorf("duplicate service %q", svc.Name)
+		}
+		g.dependents[svc.Name] = nil
+	}
+
+	for _, svc := range services {
+		for _, dep := range svc.DependsOn {
+			if _, ok := g.dependents[dep]; !ok {
+				return nil, errors.Errorf("service %q depends on unknown service %q", svc.Name, dep)
+			}
+			g.dependents[dep] = append(g.dependents[dep], svc.Name)
+		}
+	}
+
+	if err := g.checkAcyclic(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Reconcile reconciles each service once all the services it depends on are reconciled. The services that are
+// still waiting on a dependency aren't reconciled. The errors of all the services are returned together, otherwise
+// the result requests the earliest requeue of the services.
+func (g *Graph) Reconcile(ctx context.Context) (ctrl.Result, error) {
+	prerequisites := make(map[string][]string, len(g.services))
+	for _, svc := range g.services {
+		prerequisites[svc.Name] = svc.DependsOn
+	}
+
+	return g.run(ctx, prerequisites, "reconcile", cloud.ReconcilerWithResult.Reconcile)
+}
+
+// Delete deletes each service once all the services depending on it are deleted. The errors of all the services
+// are returned together, otherwise the result requests the earliest requeue of the services.
+func (g *Graph) Delete(ctx context.Context) (ctrl.Result, error) {
+	return g.run(ctx, g.dependents, "delete", cloud.ReconcilerWithResult.Delete)
+}
+
+// run calls the action of each service in its own goroutine, once the action of all its prerequisites completed
+// without error nor requeue.
+func (g *Graph) run(ctx context.Context, prerequisites map[string][]string, action string, fn func(cloud.ReconcilerWithResult, context.Context) (ctrl.Result, error)) (ctrl.Result, error) {
+	type node struct {
+		done      chan struct{}
+		completed bool
+	}
+	nodes := make(map[string]*node, len(g.services))
+	for _, svc := range g.services {
+		nodes[svc.Name] = &node{done: make(chan struct{})}
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result ctrl.Result
+		errs   []error
+	)
+	for _, svc := range g.services {
+		svc := svc
+		n := nodes[svc.Name]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(n.done)
+
+			for _, name := range prerequisites[svc.Name] {
+				prerequisite := nodes[name]
+				<-prerequisite.done
+				if !prerequisite.completed {
+					return
+				}
+			}
+
+			log := log.FromContext(ctx).WithValues("reconciler", svc.Name)
+			log.V(4).Info("Calling reconciler", "action", action)
+			res, err := fn(svc.Reconciler, ctrl.LoggerInto(ctx, log))
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				errs = append(errs, errors.Wrapf(err, "failed to %s %s", action, svc.Name))
+			case !res.IsZero():
+				log.V(4).Info("Reconciler requested requeue", "after", res.RequeueAfter)
+				result = util.LowestNonZeroResult(result, res)
+			default:
+				n.completed = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+
+	return result, nil
+}
+
+// checkAcyclic returns an error if the dependencies between the services form a cycle.
+func (g *Graph) checkAcyclic() error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(g.services))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return errors.Errorf("dependency cycle through service %q", name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dependent := range g.dependents[name] {
+			if err := visit(dependent); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, svc := range g.services {
+		if err := visit(svc.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
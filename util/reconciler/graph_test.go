@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// fakeService records the order in which the services are called.
+type fakeService struct {
+	name   string
+	calls  *[]string
+	mu     *sync.Mutex
+	result ctrl.Result
+	err    error
+}
+
+func (f *fakeService) call() (ctrl.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	*f.calls = append(*f.calls, f.name)
+	return f.result, f.err
+}
+
+func (f *fakeService) Reconcile(_ context.Context) (ctrl.Result, error) {
+	return f.call()
+}
+
+func (f *fakeService) Delete(_ context.Context) (ctrl.Result, error) {
+	return f.call()
+}
+
+func TestGraph(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Delete        bool
+		Results       map[string]ctrl.Result
+		Errors        map[string]error
+		ExpectedCalls []string
+		ExpectedFirst string
+		ExpectedLast  string
+		ExpectedErr   []string
+		Expected      ctrl.Result
+	}{
+		{
+			Name:          "ReconcilesDependenciesFirst",
+			ExpectedCalls: []string{"networks", "firewalls", "subnets", "loadbalancers"},
+			ExpectedFirst: "networks",
+		},
+		{
+			Name:          "DeletesDependentsFirst",
+			Delete:        true,
+			ExpectedCalls: []string{"networks", "firewalls", "subnets", "loadbalancers"},
+			ExpectedLast:  "networks",
+		},
+		{
+			Name:          "WaitsForRequeuedDependency",
+			Results:       map[string]ctrl.Result{"networks": {RequeueAfter: 5 * time.Second}},
+			ExpectedCalls: []string{"networks"},
+			Expected:      ctrl.Result{RequeueAfter: 5 * time.Second},
+		},
+		{
+			Name: "ReturnsLowestRequeue",
+			Results: map[string]ctrl.Result{
+				"firewalls": {RequeueAfter: 15 * time.Second},
+				"subnets":   {RequeueAfter: 5 * time.Second},
+			},
+			ExpectedCalls: []string{"networks", "firewalls", "subnets", "loadbalancers"},
+			Expected:      ctrl.Result{RequeueAfter: 5 * time.Second},
+		},
+		{
+			Name: "CollectsErrors",
+			Errors: map[string]error{
+				"firewalls": errors.New("quota exceeded"),
+				"subnets":   errors.New("invalid range"),
+			},
+			Results:       map[string]ctrl.Result{"loadbalancers": {RequeueAfter: 5 * time.Second}},
+			ExpectedCalls: []string{"networks", "firewalls", "subnets", "loadbalancers"},
+			ExpectedErr:   []string{"failed to reconcile firewalls: quota exceeded", "failed to reconcile subnets: invalid range"},
+		},
+		{
+			Name:          "SkipsDependentsOfFailedDeletion",
+			Delete:        true,
+			Errors:        map[string]error{"subnets": errors.New("resource in use")},
+			ExpectedCalls: []string{"firewalls", "subnets", "loadbalancers"},
+			ExpectedErr:   []string{"failed to delete subnets: resource in use"},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+
+			var (
+				calls []string
+				mu    sync.Mutex
+			)
+			newService := func(name string, dependsOn ...string) reconciler.Service {
+				return reconciler.Service{
+					Name: name,
+					Reconciler: &fakeService{
+						name:   name,
+						calls:  &calls,
+						mu:     &mu,
+						result: c.Results[name],
+						err:    c.Errors[name],
+					},
+					DependsOn: dependsOn,
+				}
+			}
+			graph, err := reconciler.NewGraph(
+				newService("networks"),
+				newService("firewalls", "networks"),
+				newService("loadbalancers", "networks"),
+				newService("subnets", "networks"),
+			)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			var res ctrl.Result
+			if c.Delete {
+				res, err = graph.Delete(context.TODO())
+			} else {
+				res, err = graph.Reconcile(context.TODO())
+			}
+
+			if len(c.ExpectedErr) > 0 {
+				g.Expect(err).To(gomega.HaveOccurred())
+				for _, msg := range c.ExpectedErr {
+					g.Expect(err.Error()).To(gomega.ContainSubstring(msg))
+				}
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(res).To(gomega.Equal(c.Expected))
+			}
+			g.Expect(calls).To(gomega.ConsistOf(c.ExpectedCalls))
+			if c.ExpectedFirst != "" {
+				g.Expect(calls[0]).To(gomega.Equal(c.ExpectedFirst))
+			}
+			if c.ExpectedLast != "" {
+				g.Expect(calls[len(calls)-1]).To(gomega.Equal(c.ExpectedLast))
+			}
+		})
+	}
+}
+
+func TestNewGraphErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := reconciler.NewGraph(
+		reconciler.Service{Name: "subnets", DependsOn: []string{"networks"}},
+	)
+	g.Expect(err).To(gomega.MatchError(`service "subnets" depends on unknown service "networks"`))
+
+	_, err = reconciler.NewGraph(
+		reconciler.Service{Name: "networks"},
+		reconciler.Service{Name: "networks"},
+	)
+	g.Expect(err).To(gomega.MatchError(`duplicate service "networks"`))
+
+	_, err = reconciler.NewGraph(
+		reconciler.Service{Name: "networks", DependsOn: []string{"subnets"}},
+		reconciler.Service{Name: "subnets", DependsOn: []string{"networks"}},
+	)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("dependency cycle")))
+}
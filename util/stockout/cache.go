@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stockout remembers the zones that ran out of resources for a machine type.
+package stockout
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/clock"
+)
+
+// DefaultCooldown is the period during which a zone that ran out of resources for a machine type is not tried again.
+const DefaultCooldown = 10 * time.Minute
+
+type key struct {
+	machineType string
+	zone        string
+}
+
+// Cache records the zones that ran out of resources for a machine type, until their cooldown period is over.
+// It is safe for concurrent use.
+type Cache struct {
+	cooldown  time.Duration
+	exhausted *cache.Expiring
+}
+
+// NewCache returns an empty Cache with the given cooldown period.
+func NewCache(cooldown time.Duration) *Cache {
+	return newCache(cooldown, clock.RealClock{})
+}
+
+func newCache(cooldown time.Duration, clock clock.Clock) *Cache {
+	return &Cache{
+		cooldown:  cooldown,
+		exhausted: cache.NewExpiringWithClock(clock),
+	}
+}
+
+// MarkExhausted records that the zone ran out of resources for the machine type.
+func (c *Cache) MarkExhausted(machineType, zone string) {
+	c.exhausted.Set(key{machineType: machineType, zone: zone}, struct{}{}, c.cooldown)
+}
+
+// IsExhausted returns true if the zone ran out of resources for the machine type less than a cooldown period ago.
+func (c *Cache) IsExhausted(machineType, zone string) bool {
+	_, ok := c.exhausted.Get(key{machineType: machineType, zone: zone})
+	return ok
+}
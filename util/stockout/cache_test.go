@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stockout
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestCache(t *testing.T) {
+	g := NewWithT(t)
+
+	clock := testingclock.NewFakeClock(time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC))
+	c := newCache(10*time.Minute, clock)
+
+	g.Expect(c.IsExhausted("n1-standard-2", "us-central1-a")).To(BeFalse())
+
+	c.MarkExhausted("n1-standard-2", "us-central1-a")
+	g.Expect(c.IsExhausted("n1-standard-2", "us-central1-a")).To(BeTrue())
+	g.Expect(c.IsExhausted("n1-standard-2", "us-central1-b")).To(BeFalse())
+	g.Expect(c.IsExhausted("e2-medium", "us-central1-a")).To(BeFalse())
+
+	clock.Step(9 * time.Minute)
+	g.Expect(c.IsExhausted("n1-standard-2", "us-central1-a")).To(BeTrue())
+
+	clock.Step(time.Minute)
+	g.Expect(c.IsExhausted("n1-standard-2", "us-central1-a")).To(BeFalse())
+}